@@ -1,13 +1,86 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"strconv"
 	"time"
 	"github.com/Ka10ken1/better-brevo-service/internal/background"
+	"github.com/Ka10ken1/better-brevo-service/internal/brevo"
+	"github.com/Ka10ken1/better-brevo-service/internal/logfile"
+	"github.com/joho/godotenv"
 	"github.com/robfig/cron/v3"
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run runs the command and returns its exit code. Commands return instead
+// of calling os.Exit so the log file is closed before the process exits.
+func run() int {
+	senderEmail := flag.String("sender-email", "", "send as this sender instead of the configured one (overrides SENDER_OVERRIDE_EMAIL)")
+	senderName := flag.String("sender-name", "", "sender name to use with -sender-email (overrides SENDER_OVERRIDE_NAME)")
+	flag.Parse()
+
+	// The service reads its configuration from the environment, so the
+	// flags are passed on as the override variables they take precedence
+	// over.
+	if *senderEmail != "" {
+		os.Setenv("SENDER_OVERRIDE_EMAIL", *senderEmail)
+	}
+	if *senderName != "" {
+		os.Setenv("SENDER_OVERRIDE_NAME", *senderName)
+	}
+
+	// Loaded here too so LOG_FILE can come from .env; the service loads it
+	// again and reports a missing file.
+	_ = godotenv.Load()
+
+	logFile, err := logfile.FromEnv()
+	if err != nil {
+		log.Fatalf("Failed to open log file: %v", err)
+	}
+	if logFile != nil {
+		defer logFile.Close()
+		log.SetOutput(io.MultiWriter(os.Stderr, logFile))
+	}
+
+	if flag.Arg(0) == "selftest" {
+		return runSelfTest()
+	}
+
+	if flag.Arg(0) == "send-cumulative" {
+		return runSendCumulative()
+	}
+
+	if flag.Arg(0) == "send-list" {
+		return runSendForList(flag.Arg(1))
+	}
+
+	schedule := background.Schedule()
+
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		log.Fatalf("Invalid cron schedule %q: %v", schedule, err)
+	}
+
+	worker, err := background.NewWorker()
+	if err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+
+	if err := worker.ValidateConfig(); err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+
+	if data, err := json.MarshalIndent(worker.EffectiveConfig(), "", "  "); err == nil {
+		log.Printf("Effective configuration:\n%s", data)
+	}
+
 	loc, err := time.LoadLocation("Local")
 	if err != nil {
 		log.Fatalf("Failed to load local timezone: %v", err)
@@ -15,12 +88,12 @@ func main() {
 
 	c := cron.New(cron.WithLocation(loc))
 
-	// Run() at 2:00 AM every day
+	// Run() on the schedule, by default at 2:00 AM every day
 	// 0 - Minutes
 	// 2 - Hours
-	_, err = c.AddFunc("0 2 * * *", func() {
+	_, err = c.AddFunc(schedule, func() {
 		log.Println("Running scheduled task at", time.Now().Format(time.RFC3339))
-		background.Run()
+		worker.Run()
 	})
 
 	if err != nil {
@@ -29,8 +102,74 @@ func main() {
 
 	c.Start()
 
-	log.Println("Scheduler is running. Task will run at 2:00 AM every day.")
+	log.Printf("Scheduler is running. Task will run on schedule %q.", schedule)
 
 	select {} // block forever
 }
 
+// runSelfTest runs the post-deploy smoke test and returns the exit code.
+func runSelfTest() int {
+	report, err := brevo.SelfTest()
+	if err != nil {
+		log.Printf("Self-test could not start: %v", err)
+		return 1
+	}
+
+	for _, step := range report.Steps {
+		status := "PASS"
+		if !step.Passed {
+			status = "FAIL"
+		}
+		fmt.Printf("%s  %-12s %8s  %s\n", status, step.Name, step.Duration, step.Error)
+	}
+
+	if !report.Passed {
+		fmt.Printf("Self-test failed for %s\n", report.Recipient)
+		return 1
+	}
+
+	fmt.Printf("Self-test passed for %s\n", report.Recipient)
+	return 0
+}
+
+// runSendCumulative sends the weekly campaign for the cumulative list and
+// returns the exit code.
+func runSendCumulative() int {
+	result, err := brevo.SendCumulative()
+	if err != nil {
+		log.Printf("Cumulative send failed: %v", err)
+		return 1
+	}
+
+	if result.Skipped != "" {
+		fmt.Printf("Cumulative campaign not sent: %s\n", result.Skipped)
+		return 0
+	}
+
+	fmt.Printf("Cumulative campaign %d sent to list %d (%d recipients)\n", result.Campaign.CampaignID, result.ListID, result.Recipients)
+	return 0
+}
+
+// runSendForList sends the configured campaign to an existing list, skipping
+// the CSV import, and returns the exit code.
+func runSendForList(arg string) int {
+	listID, err := strconv.Atoi(arg)
+	if err != nil || listID <= 0 {
+		log.Printf("Usage: send-list <list ID>")
+		return 2
+	}
+
+	result, err := brevo.SendForList(listID)
+	if err != nil {
+		log.Printf("Campaign for list %d failed: %v", listID, err)
+		return 1
+	}
+
+	if result.Pending != nil {
+		fmt.Printf("Campaign %d for list %d awaits confirmation (%d recipients)\n", result.Campaign.CampaignID, result.ListID, result.Recipients)
+		return 0
+	}
+
+	fmt.Printf("Campaign %d sent to list %d (%d recipients)\n", result.Campaign.CampaignID, result.ListID, result.Recipients)
+	return 0
+}
@@ -3,6 +3,6 @@ module github.com/Ka10ken1/better-brevo-service
 go 1.24.5
 
 require (
-	github.com/joho/godotenv v1.5.1 // indirect
-	github.com/robfig/cron/v3 v3.0.1 // indirect
+	github.com/joho/godotenv v1.5.1
+	github.com/robfig/cron/v3 v3.0.1
 )
@@ -1,6 +1,8 @@
 package background
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
@@ -11,6 +13,49 @@ import (
 
 
 
+// DefaultSchedule runs the job at 2:00 AM every day.
+const DefaultSchedule = "0 2 * * *"
+
+// Schedule returns CRON_SCHEDULE, or DefaultSchedule when it is unset.
+func Schedule() string {
+	if schedule := os.Getenv("CRON_SCHEDULE"); schedule != "" {
+		return schedule
+	}
+	return DefaultSchedule
+}
+
+// EffectiveConfig is everything a scheduled run resolves to.
+type EffectiveConfig struct {
+	Schedule string       `json:"schedule"`
+	CSVPath  string       `json:"csv_path"`
+	Brevo    brevo.Config `json:"brevo"`
+}
+
+// Worker runs the scheduled job with a single Brevo service, so the
+// configuration it reports is the one its runs use.
+type Worker struct {
+	service *brevo.BrevoService
+}
+
+// NewWorker loads the Brevo configuration from the environment.
+func NewWorker() (*Worker, error) {
+	service, err := brevo.NewBrevoService()
+	if err != nil {
+		return nil, fmt.Errorf("configuration: %w", err)
+	}
+	return &Worker{service: service}, nil
+}
+
+// EffectiveConfig returns the schedule, today's CSV path and the Brevo
+// configuration the worker runs with, with secrets redacted.
+func (w *Worker) EffectiveConfig() EffectiveConfig {
+	return EffectiveConfig{
+		Schedule: Schedule(),
+		CSVPath:  generateTodayPath(),
+		Brevo:    w.service.EffectiveConfig(),
+	}
+}
+
 func generateTodayPath() string {
 	// basePath := `C:/Users/Administrator/Desktop/winners`
 	// filenamePattern := "applications_{date}_past_1days/profiles"
@@ -25,14 +70,38 @@ func generateTodayPath() string {
 	return fullPath
 }
 
-func Run() {
+// ValidateConfig checks the Brevo configuration and that the folder the daily
+// CSV lands in exists.
+func (w *Worker) ValidateConfig() error {
+	var problems []error
+
+	if err := w.service.ValidateConfig(); err != nil {
+		problems = append(problems, err)
+	}
+
+	dir := filepath.Dir(generateTodayPath())
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		problems = append(problems, fmt.Errorf("csv path: directory %s does not exist", dir))
+	}
+
+	return errors.Join(problems...)
+}
+
+func (w *Worker) Run() {
 	todayPath := generateTodayPath()
 
-	if _, err := os.Stat(todayPath); os.IsNotExist(err) {
+	info, err := os.Stat(todayPath)
+	if os.IsNotExist(err) {
 		log.Printf("CSV file not found: %s. Skipping this run.", todayPath)
 		return
 	}
 
-	brevo.Start(todayPath)
+	// An empty file is a legitimately quiet day, not a failure.
+	if err == nil && info.Size() == 0 {
+		log.Printf("CSV file is empty: %s. Nothing to process.", todayPath)
+		return
+	}
+
+	w.service.Start(todayPath)
 }
 
@@ -0,0 +1,163 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// accountStatsTTL is how long GetAccountStats serves a cached snapshot.
+const accountStatsTTL = 5 * time.Minute
+
+// recentCampaignsWindow is the period counted as recent campaigns.
+const recentCampaignsWindow = 30 * 24 * time.Hour
+
+// AccountStats is a health snapshot of the Brevo account.
+type AccountStats struct {
+	Email       string          `json:"email"`
+	CompanyName string          `json:"company_name"`
+	Credits     []AccountCredit `json:"credits"`
+	// TotalContacts counts every contact in the account.
+	TotalContacts int `json:"total_contacts"`
+	// BlockedContacts counts contacts Brevo refuses to email because they
+	// unsubscribed, bounced or complained.
+	BlockedContacts int `json:"blocked_contacts"`
+	// SentCampaigns counts campaigns sent in the last 30 days.
+	SentCampaigns      int       `json:"sent_campaigns"`
+	ScheduledCampaigns int       `json:"scheduled_campaigns"`
+	FetchedAt          time.Time `json:"fetched_at"`
+}
+
+// AccountCredit is the remaining balance of one plan.
+type AccountCredit struct {
+	PlanType    string  `json:"type"`
+	Credits     float64 `json:"credits"`
+	CreditsType string  `json:"creditsType"`
+}
+
+type accountResponse struct {
+	Email       string          `json:"email"`
+	CompanyName string          `json:"companyName"`
+	Plan        []AccountCredit `json:"plan"`
+}
+
+type countResponse struct {
+	Count int `json:"count"`
+}
+
+// accountStatsCache is shared by copies of a BrevoService.
+type accountStatsCache struct {
+	mu    sync.Mutex
+	stats AccountStats
+}
+
+// GetAccountStats combines credits, contact counts and recent campaign
+// counts from several Brevo endpoints. Results are cached for a few minutes
+// so a dashboard can poll it freely.
+func (b *BrevoService) GetAccountStats() (AccountStats, error) {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	b.statsCache.mu.Lock()
+	defer b.statsCache.mu.Unlock()
+
+	if !b.statsCache.stats.FetchedAt.IsZero() && time.Since(b.statsCache.stats.FetchedAt) < accountStatsTTL {
+		return b.statsCache.stats, nil
+	}
+
+	account, err := b.getAccount()
+	if err != nil {
+		return AccountStats{}, err
+	}
+
+	stats := AccountStats{
+		Email:       account.Email,
+		CompanyName: account.CompanyName,
+		Credits:     account.Plan,
+	}
+
+	now := time.Now()
+	counts := []struct {
+		target *int
+		name   string
+		url    string
+	}{
+		{&stats.TotalContacts, "contacts", "https://api.brevo.com/v3/contacts?limit=1&offset=0"},
+		{&stats.BlockedContacts, "blocked contacts", "https://api.brevo.com/v3/smtp/blockedContacts?limit=1&offset=0"},
+		{&stats.SentCampaigns, "sent campaigns", fmt.Sprintf("https://api.brevo.com/v3/emailCampaigns?status=sent&limit=1&offset=0&startDate=%s&endDate=%s",
+			now.Add(-recentCampaignsWindow).UTC().Format(time.RFC3339), now.UTC().Format(time.RFC3339))},
+		{&stats.ScheduledCampaigns, "scheduled campaigns", "https://api.brevo.com/v3/emailCampaigns?status=queued&limit=1&offset=0"},
+	}
+
+	for _, c := range counts {
+		count, err := b.getCount(c.url)
+		if err != nil {
+			return AccountStats{}, fmt.Errorf("failed to count %s: %w", c.name, err)
+		}
+		*c.target = count
+	}
+
+	stats.FetchedAt = now
+	b.statsCache.stats = stats
+
+	log.Printf("Account stats: %d contacts, %d blocked, %d campaigns sent in the last 30 days",
+		stats.TotalContacts, stats.BlockedContacts, stats.SentCampaigns)
+	return stats, nil
+}
+
+func (b *BrevoService) getAccount() (accountResponse, error) {
+	body, err := b.getJSON("https://api.brevo.com/v3/account")
+	if err != nil {
+		return accountResponse{}, fmt.Errorf("failed to fetch account: %w", err)
+	}
+
+	var account accountResponse
+	if err := json.Unmarshal(body, &account); err != nil {
+		return accountResponse{}, fmt.Errorf("failed to decode account response: %w", err)
+	}
+
+	return account, nil
+}
+
+// getCount reads the total "count" Brevo reports alongside a list endpoint.
+func (b *BrevoService) getCount(url string) (int, error) {
+	body, err := b.getJSON(url)
+	if err != nil {
+		return 0, err
+	}
+
+	var resp countResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0, fmt.Errorf("failed to decode count response: %w", err)
+	}
+
+	return resp.Count, nil
+}
+
+func (b *BrevoService) getJSON(url string) ([]byte, error) {
+	resp, err := b.makeAPIRequest(opFetch, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Brevo answers an empty collection with 204 and no body.
+	if resp.StatusCode == http.StatusNoContent {
+		return []byte("{}"), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d - %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
@@ -0,0 +1,133 @@
+package brevo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetAccountStats(t *testing.T) {
+	tests := []struct {
+		name         string
+		failPath     string
+		emptyBlocked bool
+		want         AccountStats
+		wantErr      bool
+	}{
+		{
+			name: "combines every endpoint",
+			want: AccountStats{
+				Email:              "ops@example.com",
+				CompanyName:        "Winners",
+				Credits:            []AccountCredit{{PlanType: "payAsYouGo", Credits: 1500, CreditsType: "sendLimit"}},
+				TotalContacts:      1200,
+				BlockedContacts:    15,
+				SentCampaigns:      4,
+				ScheduledCampaigns: 1,
+			},
+		},
+		{
+			name:         "no blocked contacts answers 204",
+			emptyBlocked: true,
+			want: AccountStats{
+				Email:              "ops@example.com",
+				CompanyName:        "Winners",
+				Credits:            []AccountCredit{{PlanType: "payAsYouGo", Credits: 1500, CreditsType: "sendLimit"}},
+				TotalContacts:      1200,
+				SentCampaigns:      4,
+				ScheduledCampaigns: 1,
+			},
+		},
+		{name: "account endpoint fails", failPath: "/v3/account", wantErr: true},
+		{name: "count endpoint fails", failPath: "/v3/contacts", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == tt.failPath {
+					http.Error(w, `{"message":"unavailable"}`, http.StatusServiceUnavailable)
+					return
+				}
+
+				switch r.URL.Path {
+				case "/v3/account":
+					fmt.Fprint(w, `{"email":"ops@example.com","companyName":"Winners","plan":[{"type":"payAsYouGo","credits":1500,"creditsType":"sendLimit"}]}`)
+				case "/v3/contacts":
+					fmt.Fprint(w, `{"contacts":[],"count":1200}`)
+				case "/v3/smtp/blockedContacts":
+					if tt.emptyBlocked {
+						w.WriteHeader(http.StatusNoContent)
+						return
+					}
+					fmt.Fprint(w, `{"contacts":[],"count":15}`)
+				case "/v3/emailCampaigns":
+					switch r.URL.Query().Get("status") {
+					case "sent":
+						if r.URL.Query().Get("startDate") == "" {
+							t.Errorf("sent campaigns counted without a start date")
+						}
+						fmt.Fprint(w, `{"campaigns":[],"count":4}`)
+					case "queued":
+						fmt.Fprint(w, `{"campaigns":[],"count":1}`)
+					default:
+						t.Errorf("unexpected campaign status filter %q", r.URL.Query().Get("status"))
+					}
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			}))
+
+			stats, err := service.GetAccountStats()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetAccountStats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if stats.FetchedAt.IsZero() {
+				t.Errorf("FetchedAt is not set")
+			}
+			stats.FetchedAt = time.Time{}
+			if fmt.Sprint(stats) != fmt.Sprint(tt.want) {
+				t.Errorf("GetAccountStats() = %+v, want %+v", stats, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAccountStatsCache(t *testing.T) {
+	accounts := 0
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/account" {
+			accounts++
+			fmt.Fprint(w, `{"email":"ops@example.com"}`)
+			return
+		}
+		fmt.Fprint(w, `{"count":1}`)
+	}))
+
+	first, err := service.GetAccountStats()
+	if err != nil {
+		t.Fatalf("GetAccountStats() error = %v", err)
+	}
+
+	// Copies of the service share the cache.
+	second, err := service.WithContext(service.context()).GetAccountStats()
+	if err != nil {
+		t.Fatalf("cached GetAccountStats() error = %v", err)
+	}
+	if accounts != 1 || !second.FetchedAt.Equal(first.FetchedAt) {
+		t.Errorf("account fetched %d times, want the second call served from the cache", accounts)
+	}
+
+	service.statsCache.stats.FetchedAt = time.Now().Add(-accountStatsTTL - time.Second)
+	if _, err := service.GetAccountStats(); err != nil {
+		t.Fatalf("refreshed GetAccountStats() error = %v", err)
+	}
+	if accounts != 2 {
+		t.Errorf("account fetched %d times, want an expired cache to refetch", accounts)
+	}
+}
@@ -0,0 +1,92 @@
+package brevo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+)
+
+// DefaultMaxAPICallsPerRun is far above what a normal run needs; it only
+// stops runaway loops before they exhaust the account's quota.
+const DefaultMaxAPICallsPerRun int = 100000
+
+// ErrAPIBudgetExceeded is returned, wrapped, once a run makes more API calls
+// than MaxAPICallsPerRun allows. The run is aborted.
+var ErrAPIBudgetExceeded = errors.New("API call budget exceeded")
+
+// apiBudget counts the API calls of one run and cancels the run once it has
+// used them all. A nil *apiBudget counts nothing.
+type apiBudget struct {
+	max    int64
+	calls  atomic.Int64
+	cancel context.CancelCauseFunc
+}
+
+// take accounts for one more call and fails once the budget is spent.
+func (a *apiBudget) take() error {
+	if a == nil {
+		return nil
+	}
+
+	n := a.calls.Add(1)
+	if a.max <= 0 || n <= a.max {
+		return nil
+	}
+
+	err := fmt.Errorf("%w: limit of %d calls per run reached", ErrAPIBudgetExceeded, a.max)
+	if n == a.max+1 {
+		log.Printf("Aborting the run: %v", err)
+	}
+	a.cancel(err)
+	return err
+}
+
+// used returns how many calls the run made, not counting refused ones.
+func (a *apiBudget) used() int {
+	if a == nil {
+		return 0
+	}
+	return int(min(a.calls.Load(), a.max))
+}
+
+// withAPIBudget returns the service to run with under MaxAPICallsPerRun.
+// Every run entry point and every public method that pages through Brevo
+// calls it; a service already inside a run keeps the run's budget, so
+// nested entry points share one count. Exceeding it
+// cancels the returned service's context with ErrAPIBudgetExceeded as the
+// cause. The cancel func must always be called.
+func (b *BrevoService) withAPIBudget() (*BrevoService, context.CancelFunc) {
+	if b.apiBudget != nil {
+		return b, func() {}
+	}
+
+	max := b.config.MaxAPICallsPerRun
+	if max <= 0 {
+		max = DefaultMaxAPICallsPerRun
+	}
+
+	ctx, cancel := context.WithCancelCause(b.context())
+	budgeted := b.WithContext(ctx)
+	budgeted.apiBudget = &apiBudget{max: int64(max), cancel: cancel}
+	return budgeted, func() { cancel(nil) }
+}
+
+// afterRun returns the service for the steps that report on a run, such as
+// the webhook and the run report's archive. They get a context of their own
+// and no budget, so a run the budget or a cancellation cut short is still
+// reported.
+func (b *BrevoService) afterRun() *BrevoService {
+	reporting := b.WithContext(context.WithoutCancel(b.context()))
+	reporting.apiBudget = nil
+	return reporting
+}
+
+// apiBudgetError returns the budget error when it cut the run short.
+func (b *BrevoService) apiBudgetError() error {
+	if cause := context.Cause(b.context()); errors.Is(cause, ErrAPIBudgetExceeded) {
+		return cause
+	}
+	return nil
+}
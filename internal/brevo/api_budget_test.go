@@ -0,0 +1,158 @@
+package brevo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAPIBudgetTake(t *testing.T) {
+	tests := []struct {
+		name     string
+		budget   *apiBudget
+		calls    int
+		wantErrs int
+		wantUsed int
+	}{
+		{name: "nil budget counts nothing", budget: nil, calls: 5, wantErrs: 0, wantUsed: 0},
+		{name: "within budget", budget: &apiBudget{max: 5}, calls: 5, wantErrs: 0, wantUsed: 5},
+		{name: "over budget", budget: &apiBudget{max: 3}, calls: 5, wantErrs: 2, wantUsed: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cause error
+			if tt.budget != nil {
+				tt.budget.cancel = func(err error) { cause = err }
+			}
+
+			errs := 0
+			for range tt.calls {
+				if err := tt.budget.take(); err != nil {
+					if !errors.Is(err, ErrAPIBudgetExceeded) {
+						t.Fatalf("take() error = %v, want ErrAPIBudgetExceeded", err)
+					}
+					errs++
+				}
+			}
+
+			if errs != tt.wantErrs {
+				t.Errorf("refused calls = %d, want %d", errs, tt.wantErrs)
+			}
+			if got := tt.budget.used(); got != tt.wantUsed {
+				t.Errorf("used() = %d, want %d", got, tt.wantUsed)
+			}
+			if tt.wantErrs > 0 && !errors.Is(cause, ErrAPIBudgetExceeded) {
+				t.Errorf("cancel cause = %v, want ErrAPIBudgetExceeded", cause)
+			}
+		})
+	}
+}
+
+func TestWithAPIBudgetNested(t *testing.T) {
+	service, _ := newTestService(t, http.NotFoundHandler())
+	service.config.MaxAPICallsPerRun = 7
+
+	run, cancel := service.withAPIBudget()
+	defer cancel()
+	if run.apiBudget == nil || run.apiBudget.max != 7 {
+		t.Fatalf("apiBudget = %+v, want max 7", run.apiBudget)
+	}
+
+	nested, nestedCancel := run.withAPIBudget()
+	defer nestedCancel()
+	if nested.apiBudget != run.apiBudget {
+		t.Error("nested entry point got a budget of its own, want the run's")
+	}
+
+	if run.afterRun().apiBudget != nil {
+		t.Error("afterRun() kept the run's budget")
+	}
+}
+
+func TestRunStopsAtAPIBudget(t *testing.T) {
+	const maxCalls = 8
+
+	service, mock := newRunService(t)
+	service.config.MaxAPICallsPerRun = maxCalls
+
+	var requests atomic.Int64
+	forward := service.httpClient.Transport
+	service.httpClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		return forward.RoundTrip(r)
+	})
+
+	lines := []string{"email,first_name"}
+	for i := range 50 {
+		lines = append(lines, fmt.Sprintf("winner%d@example.com,Winner", i))
+	}
+	service.config.Process.Decoder = HeaderDecoder{}
+
+	results, err := service.runOnce(writeCSV(t, lines...))
+	if !errors.Is(err, ErrAPIBudgetExceeded) {
+		t.Fatalf("runOnce() error = %v, want ErrAPIBudgetExceeded", err)
+	}
+	if got := requests.Load(); got > maxCalls {
+		t.Errorf("Brevo received %d requests, want at most %d", got, maxCalls)
+	}
+	if mock.sends != 0 {
+		t.Errorf("campaign sent %d times after the budget ran out", mock.sends)
+	}
+	if service.isRetryableRun(results, err) {
+		t.Error("isRetryableRun() = true for a run the budget stopped")
+	}
+}
+
+func TestClearListStopsAtAPIBudget(t *testing.T) {
+	const maxCalls = 6
+
+	// The list never shrinks, so without the budget ClearList would page
+	// forever.
+	var requests atomic.Int64
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method == http.MethodPost {
+			fmt.Fprint(w, `{"contacts":{"success":[1]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"contacts":[{"id":1,"email":"stuck@example.com"}],"count":1}`)
+	}))
+	service.config.MaxAPICallsPerRun = maxCalls
+
+	_, err := service.ClearList(10)
+	if !errors.Is(err, ErrAPIBudgetExceeded) {
+		t.Fatalf("ClearList() error = %v, want ErrAPIBudgetExceeded", err)
+	}
+	if got := requests.Load(); got > maxCalls {
+		t.Errorf("Brevo received %d requests, want at most %d", got, maxCalls)
+	}
+}
+
+func TestGetFolderListsStopsAtAPIBudget(t *testing.T) {
+	const maxCalls = 5
+
+	// Every page is full, so without the budget GetFolderLists would page
+	// forever.
+	var requests atomic.Int64
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		lists := make([]ContactList, folderListsPageSize)
+		for i := range lists {
+			lists[i] = ContactList{ID: i + 1, Name: fmt.Sprintf("List %d", i+1)}
+		}
+		json.NewEncoder(w).Encode(ListsResponse{Lists: lists})
+	}))
+	service.config.MaxAPICallsPerRun = maxCalls
+
+	_, err := service.GetFolderLists(3)
+	if !errors.Is(err, ErrAPIBudgetExceeded) {
+		t.Fatalf("GetFolderLists() error = %v, want ErrAPIBudgetExceeded", err)
+	}
+	if got := requests.Load(); got > maxCalls {
+		t.Errorf("Brevo received %d requests, want at most %d", got, maxCalls)
+	}
+}
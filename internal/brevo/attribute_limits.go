@@ -0,0 +1,103 @@
+package brevo
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultAttributeMaxLength is the limit for text attributes without their
+// own entry in AttributeLimits.MaxLength.
+const DefaultAttributeMaxLength int = 255
+
+// AttributeLimits keeps oversized values from getting a whole contact
+// rejected. Lengths count characters, not bytes, so Georgian text gets the
+// same room as Latin.
+type AttributeLimits struct {
+	// MaxLength overrides the limit for individual attributes.
+	MaxLength map[string]int
+	// DefaultMaxLength applies to every other text attribute. Zero uses
+	// DefaultAttributeMaxLength; a negative value disables the check.
+	DefaultMaxLength int
+	// SkipOversized drops oversized values instead of truncating them.
+	SkipOversized bool
+}
+
+// AttributeTruncation records an attribute value that exceeded its limit.
+type AttributeTruncation struct {
+	Attribute string `json:"attribute"`
+	Length    int    `json:"length"`
+	Limit     int    `json:"limit"`
+	Action    string `json:"action"`
+}
+
+func (l AttributeLimits) limitFor(attribute string) int {
+	if limit, ok := l.MaxLength[attribute]; ok {
+		return limit
+	}
+	if l.DefaultMaxLength == 0 {
+		return DefaultAttributeMaxLength
+	}
+	return l.DefaultMaxLength
+}
+
+// apply truncates or removes oversized string values in attributes and
+// reports what it changed, in attribute name order.
+func (l AttributeLimits) apply(email string, attributes map[string]any) []AttributeTruncation {
+	var truncations []AttributeTruncation
+
+	for _, attribute := range sortedKeys(attributes) {
+		text, ok := attributes[attribute].(string)
+		if !ok {
+			continue
+		}
+
+		limit := l.limitFor(attribute)
+		length := utf8.RuneCountInString(text)
+		if limit < 0 || length <= limit {
+			continue
+		}
+
+		truncation := AttributeTruncation{Attribute: attribute, Length: length, Limit: limit}
+
+		// A cut-off phone number is a wrong number, so SMS is never truncated.
+		if l.SkipOversized || attribute == "SMS" {
+			delete(attributes, attribute)
+			truncation.Action = "skipped"
+		} else {
+			attributes[attribute] = string([]rune(text)[:limit])
+			truncation.Action = "truncated"
+		}
+
+		log.Printf("Warning: %s of %s is %d characters, limit is %d. Value %s.", attribute, email, length, limit, truncation.Action)
+		truncations = append(truncations, truncation)
+	}
+
+	return truncations
+}
+
+// parseAttributeLimits reads "ATTR=N,ATTR=N" per-attribute limits.
+func parseAttributeLimits(value string) (map[string]int, error) {
+	limits := make(map[string]int)
+	if strings.TrimSpace(value) == "" {
+		return limits, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		name, number, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected ATTRIBUTE=LENGTH, got '%s'", entry)
+		}
+
+		limit, err := strconv.Atoi(strings.TrimSpace(number))
+		if err != nil {
+			return nil, fmt.Errorf("invalid length in '%s': %w", entry, err)
+		}
+
+		limits[strings.ToUpper(strings.TrimSpace(name))] = limit
+	}
+
+	return limits, nil
+}
@@ -0,0 +1,31 @@
+package brevo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAttributeLimitsApplyInNameOrder(t *testing.T) {
+	limits := AttributeLimits{DefaultMaxLength: 3}
+	attributes := map[string]any{
+		"TENDER_CODE":  "ABCDE",
+		"COMPANY_NAME": "Acme Ltd",
+		"SMS":          "995555111",
+		"COUNTRY":      "GE",
+		"COMPANY_ID":   "12345",
+	}
+
+	truncations := limits.apply("a@example.com", attributes)
+
+	var got []string
+	for _, truncation := range truncations {
+		got = append(got, truncation.Attribute+":"+truncation.Action)
+	}
+	want := "COMPANY_ID:truncated COMPANY_NAME:truncated SMS:skipped TENDER_CODE:truncated"
+	if strings.Join(got, " ") != want {
+		t.Errorf("truncations = %v, want %s", got, want)
+	}
+	if _, ok := attributes["SMS"]; ok || attributes["COMPANY_NAME"] != "Acm" {
+		t.Errorf("attributes = %v, want SMS removed and COMPANY_NAME cut to 3 characters", attributes)
+	}
+}
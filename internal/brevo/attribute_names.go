@@ -0,0 +1,160 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// renamedAttributes remembers which attribute names the service already
+// reported as normalized, so a bad name is warned about once rather than
+// per contact. A nil *renamedAttributes warns every time.
+type renamedAttributes struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func newRenamedAttributes() *renamedAttributes {
+	return &renamedAttributes{seen: make(map[string]bool)}
+}
+
+// first records name and reports whether it was not seen before.
+func (r *renamedAttributes) first(name string) bool {
+	if r == nil {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.seen[name] {
+		return false
+	}
+	r.seen[name] = true
+	return true
+}
+
+// ContactAttribute is one entry of the account's attribute schema.
+type ContactAttribute struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Type     string `json:"type,omitempty"`
+}
+
+// normalizeAttributeName applies Brevo's naming rules: names are uppercase,
+// use only letters, digits and underscores, and start with a letter. Brevo
+// renames or rejects anything else, which silently drops the value.
+func normalizeAttributeName(name string) string {
+	name = strings.ToUpper(strings.TrimSpace(name))
+
+	var sb strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+
+	normalized := sb.String()
+	if normalized != "" && (normalized[0] < 'A' || normalized[0] > 'Z') {
+		normalized = "ATTR_" + normalized
+	}
+
+	return normalized
+}
+
+// normalizeAttributeKeys renames attributes whose names break Brevo's rules,
+// warning the first time each name is changed.
+func (b *BrevoService) normalizeAttributeKeys(attributes map[string]any) {
+	for name, value := range attributes {
+		normalized := normalizeAttributeName(name)
+		if normalized == name {
+			continue
+		}
+
+		delete(attributes, name)
+		if normalized == "" {
+			log.Printf("Warning: Attribute name '%s' has no valid characters. Dropping it.", name)
+			continue
+		}
+
+		attributes[normalized] = value
+		if b.renamedAttributes.first(name) {
+			log.Printf("Warning: Attribute name '%s' does not follow Brevo's naming rules. Sending it as '%s'.", name, normalized)
+		}
+	}
+}
+
+// GetAttributeSchema returns the contact attributes defined in the account.
+func (b *BrevoService) GetAttributeSchema() ([]ContactAttribute, error) {
+	resp, err := b.makeAPIRequest(opFetch, "GET", "https://api.brevo.com/v3/contacts/attributes", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching attribute schema: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attribute schema response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch attribute schema: status %d - %s", resp.StatusCode, string(body))
+	}
+
+	var schema struct {
+		Attributes []ContactAttribute `json:"attributes"`
+	}
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, fmt.Errorf("failed to decode attribute schema: %w", err)
+	}
+
+	return schema.Attributes, nil
+}
+
+// mappedAttributes are the attribute names a run writes.
+func (b *BrevoService) mappedAttributes() []string {
+	names := csvFilledAttributes(b.config.ContactPerson.Attribute)
+	if b.config.SourceRowAttribute != "" {
+		names = append(names, b.config.SourceRowAttribute)
+	}
+	if b.config.Reason.Attribute != "" {
+		names = append(names, b.config.Reason.Attribute)
+	}
+	if b.config.Consent.enabled() {
+		names = append(names, b.config.Consent.attribute())
+	}
+	return names
+}
+
+// checkAttributeSchema cross-checks the mapped attribute names against the
+// account's schema and returns a warning for each one Brevo does not know,
+// since values for unknown attributes are dropped without an error.
+func (b *BrevoService) checkAttributeSchema() []string {
+	schema, err := b.GetAttributeSchema()
+	if err != nil {
+		log.Printf("Warning: Could not check attribute names against the schema: %v", err)
+		return nil
+	}
+
+	known := make(map[string]bool, len(schema))
+	for _, attribute := range schema {
+		known[strings.ToUpper(attribute.Name)] = true
+	}
+
+	var warnings []string
+	for _, name := range b.mappedAttributes() {
+		if !known[name] {
+			warning := fmt.Sprintf("attribute '%s' is not defined in Brevo; its values will be dropped", name)
+			log.Printf("Warning: %s", warning)
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,72 @@
+package brevo
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeAttributeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"COMPANY_NAME", "COMPANY_NAME"},
+		{" company name ", "COMPANY_NAME"},
+		{"tender-code", "TENDER_CODE"},
+		{"1ST_CONTACT", "ATTR_1ST_CONTACT"},
+		{"_hidden", "ATTR__HIDDEN"},
+		{"სახელი", "ATTR_______"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeAttributeName(tt.name); got != tt.want {
+				t.Errorf("normalizeAttributeName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeAttributeKeysWarnsOncePerService(t *testing.T) {
+	var logs bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(previous) })
+
+	first := &BrevoService{renamedAttributes: newRenamedAttributes()}
+	second := &BrevoService{renamedAttributes: newRenamedAttributes()}
+
+	for _, b := range []*BrevoService{first, first, second} {
+		attributes := map[string]any{"company name": "Acme", "SMS": "+995"}
+		b.normalizeAttributeKeys(attributes)
+
+		if fmt.Sprint(attributes) != fmt.Sprint(map[string]any{"COMPANY_NAME": "Acme", "SMS": "+995"}) {
+			t.Errorf("normalizeAttributeKeys() = %v", attributes)
+		}
+	}
+
+	if warnings := strings.Count(logs.String(), "does not follow Brevo's naming rules"); warnings != 2 {
+		t.Errorf("warnings = %d, want one per service", warnings)
+	}
+}
+
+func TestCheckAttributeSchema(t *testing.T) {
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"attributes":[{"name":"company_name"},{"name":"COMPANY_ID"},{"name":"SMS"},{"name":"COUNTRY"}]}`)
+	}))
+	service.config.ContactPerson.Attribute = "CONTACT_PERSON"
+
+	warnings := service.checkAttributeSchema()
+	want := []string{
+		"attribute 'TENDER_CODE' is not defined in Brevo; its values will be dropped",
+		"attribute 'CONTACT_PERSON' is not defined in Brevo; its values will be dropped",
+	}
+	if fmt.Sprint(warnings) != fmt.Sprint(want) {
+		t.Errorf("checkAttributeSchema() = %q, want %q", warnings, want)
+	}
+}
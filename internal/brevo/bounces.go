@@ -0,0 +1,55 @@
+package brevo
+
+import (
+	"fmt"
+	"log"
+)
+
+// BounceHygieneResult reports what BlacklistCampaignBounces processed.
+type BounceHygieneResult struct {
+	CampaignID  int `json:"campaign_id"`
+	Bounced     int `json:"bounced"`
+	Blacklisted int `json:"blacklisted"`
+}
+
+// GetCampaignBounces returns the lowercased emails that hard bounced on
+// campaignID, taken from a recipients export.
+func (b *BrevoService) GetCampaignBounces(campaignID int) ([]string, error) {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	emails, err := b.exportRecipients(campaignID, "hardBounces")
+	if err != nil {
+		return nil, fmt.Errorf("failed to export hard bounces of campaign %d: %w", campaignID, err)
+	}
+
+	return emails, nil
+}
+
+// BlacklistCampaignBounces blacklists the hard bounces of campaignID, so
+// runs with SkipBlacklisted leave them out of future lists.
+func (b *BrevoService) BlacklistCampaignBounces(campaignID int) (BounceHygieneResult, error) {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	result := BounceHygieneResult{CampaignID: campaignID}
+
+	bounces, err := b.GetCampaignBounces(campaignID)
+	if err != nil {
+		return result, err
+	}
+	result.Bounced = len(bounces)
+
+	if len(bounces) == 0 {
+		log.Printf("Campaign %d has no hard bounces", campaignID)
+		return result, nil
+	}
+
+	result.Blacklisted, err = b.BlacklistEmails(bounces)
+	if err != nil {
+		return result, fmt.Errorf("blacklisted %d of %d hard bounces of campaign %d: %w", result.Blacklisted, result.Bounced, campaignID, err)
+	}
+
+	log.Printf("Processed %d hard bounces of campaign %d: %d blacklisted", result.Bounced, campaignID, result.Blacklisted)
+	return result, nil
+}
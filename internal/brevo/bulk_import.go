@@ -0,0 +1,388 @@
+package brevo
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	importChunkSize      int           = 1000
+	importPollInterval   time.Duration = 2 * time.Second
+	DefaultImportTimeout time.Duration = 10 * time.Minute
+)
+
+// ErrImportTimeout is returned, wrapped, when an import process does not
+// complete within the poll timeout.
+var ErrImportTimeout = errors.New("import process did not complete in time")
+
+type ImportStatus struct {
+	ProcessID int    `json:"id"`
+	Status    string `json:"status"`
+	Name      string `json:"name"`
+	// ExportURL is where an export process leaves its file.
+	ExportURL string `json:"export_url,omitempty"`
+}
+
+func (s ImportStatus) Completed() bool {
+	return s.Status == "completed"
+}
+
+type importContact struct {
+	Email      string         `json:"email"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+type importPayload struct {
+	JSONBody               []importContact `json:"jsonBody"`
+	ListIDs                []int           `json:"listIds"`
+	UpdateExistingContacts bool            `json:"updateExistingContacts"`
+	EmptyContactsAttrs     bool            `json:"emptyContactsAttributes"`
+}
+
+// PollImportProcess waits until Brevo finishes the asynchronous process
+// processID, checking every couple of seconds until timeout.
+func (b *BrevoService) PollImportProcess(processID int, timeout time.Duration) (ImportStatus, error) {
+	deadline := time.Now().Add(timeout)
+	url := fmt.Sprintf("https://api.brevo.com/v3/processes/%d", processID)
+
+	for {
+		resp, err := b.makeAPIRequest(opFetch, "GET", url, nil)
+		if err != nil {
+			return ImportStatus{ProcessID: processID}, fmt.Errorf("error polling process %d: %w", processID, err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return ImportStatus{ProcessID: processID}, fmt.Errorf("failed to read process %d response body: %w", processID, err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return ImportStatus{ProcessID: processID}, fmt.Errorf("failed to fetch process %d: status %d - %s", processID, resp.StatusCode, string(body))
+		}
+
+		var status ImportStatus
+		if err := json.Unmarshal(body, &status); err != nil {
+			return ImportStatus{ProcessID: processID}, fmt.Errorf("failed to decode process %d response: %w", processID, err)
+		}
+
+		if status.Completed() {
+			log.Printf("Import process %d completed", processID)
+			return status, nil
+		}
+
+		if time.Now().After(deadline) {
+			return status, fmt.Errorf("%w: process %d still '%s' after %s", ErrImportTimeout, processID, status.Status, timeout)
+		}
+
+		log.Printf("Import process %d is '%s'. Waiting...", processID, status.Status)
+
+		select {
+		case <-time.After(importPollInterval):
+		case <-b.context().Done():
+			return status, b.context().Err()
+		}
+	}
+}
+
+func (b *BrevoService) startImport(listID int, contacts []importContact) (int, error) {
+	payload := importPayload{
+		JSONBody:               contacts,
+		ListIDs:                []int{listID},
+		UpdateExistingContacts: true,
+	}
+
+	resp, err := b.makeAPIRequest(opAdd, "POST", "https://api.brevo.com/v3/contacts/import", payload)
+	if err != nil {
+		return 0, fmt.Errorf("exception starting import: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read import response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return 0, fmt.Errorf("failed to start import: %w", &apiStatusError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("status %d - %s", resp.StatusCode, string(body)),
+		})
+	}
+
+	var result struct {
+		ProcessID int `json:"processId"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil || result.ProcessID <= 0 {
+		return 0, fmt.Errorf("invalid or missing processId in import response: %s", string(body))
+	}
+
+	return result.ProcessID, nil
+}
+
+// importReport returns the contacts a finished import process rejected,
+// keyed by lowercased email, with Brevo's reason. Brevo only attaches a
+// report, at the process's export_url, when some contacts failed. Report
+// URLs are pre-signed like export URLs.
+func (b *BrevoService) importReport(status ImportStatus) (map[string]string, error) {
+	if status.ExportURL == "" {
+		return nil, nil
+	}
+
+	resp, err := b.downloadProcessFile(status.ExportURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download import report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download import report: status %d", resp.StatusCode)
+	}
+
+	failures, err := readImportReport(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import report: %w", err)
+	}
+	return failures, nil
+}
+
+// downloadProcessFile fetches the file a finished process left at fileURL.
+// The URL is pre-signed, so the API key is not sent, but the download still
+// counts against the run's API budget and is bound by the fetch timeout.
+func (b *BrevoService) downloadProcessFile(fileURL string) (*http.Response, error) {
+	if err := b.apiBudget.take(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(b.context(), b.config.Timeouts.forOperation(opFetch))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fileURL, nil)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// readImportReport reads an import report: a CSV whose header names an
+// email column and, usually, an error column.
+func readImportReport(r io.Reader) (map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	emailColumn, errorColumn := -1, -1
+	for i, header := range records[0] {
+		header = strings.ToLower(strings.TrimSpace(header))
+		switch {
+		case emailColumn < 0 && strings.Contains(header, "email"):
+			emailColumn = i
+		case errorColumn < 0 && (strings.Contains(header, "error") || strings.Contains(header, "reason")):
+			errorColumn = i
+		}
+	}
+	if emailColumn < 0 {
+		return nil, fmt.Errorf("no email column in header %v", records[0])
+	}
+
+	failures := make(map[string]string)
+	for _, record := range records[1:] {
+		if emailColumn >= len(record) {
+			continue
+		}
+
+		email := strings.ToLower(strings.TrimSpace(record[emailColumn]))
+		if email == "" {
+			continue
+		}
+
+		reason := "rejected by the import"
+		if errorColumn >= 0 && errorColumn < len(record) && strings.TrimSpace(record[errorColumn]) != "" {
+			reason = strings.TrimSpace(record[errorColumn])
+		}
+		failures[email] = reason
+	}
+
+	return failures, nil
+}
+
+// bulkImportContacts imports csvData into listID through Brevo's async
+// import endpoint. A 202 only means the import was queued, so each chunk is
+// polled to completion and the outcome per contact is taken from the
+// process report, not the initial ack: contacts it rejected are failed, or
+// retried one by one with RetryFailedImports, and the rest were loaded.
+// Outcomes, retried contacts included, are recorded in CSV order. A chunk
+// rejected with a non-retryable status, such as a 400 or 401, stops the
+// import.
+//
+// The import does not say which contacts it created. A contact in
+// existingContacts was updated; one missing from it was added only when
+// complete reports that existingContacts holds the whole account, and is
+// recorded as imported otherwise. Immutable attributes of existing contacts
+// are checked against the attributes states fetched.
+func (b *BrevoService) bulkImportContacts(csvData []CSVData, listID int, existingContacts map[string]bool, complete bool, states *contactStates, opts ProcessOptions, results *ProcessingResults) error {
+	submitted := make(map[string]int, len(csvData))
+	truncations := make(map[int][]AttributeTruncation)
+	preserved := make(map[int][]string)
+	contacts := make([]importContact, 0, len(csvData))
+	indexes := make([]int, 0, len(csvData))
+
+	for i := range csvData {
+		data := &csvData[i]
+		row := i + 2 // 1-based, after the header line
+
+		if data.Email == "" {
+			results.Errors.addRow(row, "", ErrMissingEmail, "Skipping contact with no email address")
+			continue
+		}
+
+		if results.skipExcluded(row, data) {
+			continue
+		}
+
+		submitted[strings.ToLower(data.Email)] = i
+		attributes := b.buildAttributes(data)
+		b.addSourceRow(attributes, row)
+		b.addReason(attributes, data)
+		b.addConsentDate(attributes, data, !existingContacts[strings.ToLower(data.Email)])
+		if t := b.config.AttributeLimits.apply(data.Email, attributes); len(t) > 0 {
+			truncations[i] = t
+		}
+		if existingContacts[strings.ToLower(data.Email)] {
+			before, known := states.attributesOf(data.Email)
+			if p := b.applyImmutable(data.Email, attributes, before, known); len(p) > 0 {
+				preserved[i] = p
+			}
+		}
+		payload := ContactPayload{Email: data.Email, Attributes: attributes, ListIds: []int{listID}, UpdateEnabled: true}
+		b.transformPayload(&payload)
+		contacts = append(contacts, importContact{Email: data.Email, Attributes: payload.Attributes})
+		indexes = append(indexes, i)
+	}
+
+	// Contacts of chunks rejected with a transient error, retried one by one.
+	retrying := make(map[int]bool)
+	rejected := make(map[string]string)
+
+	timeout := opts.ImportTimeout
+	if timeout <= 0 {
+		timeout = DefaultImportTimeout
+	}
+
+	for start := 0; start < len(contacts); start += importChunkSize {
+		end := min(start+importChunkSize, len(contacts))
+
+		processID, err := b.startImport(listID, contacts[start:end])
+		if err != nil && opts.RetryFailedImports && isRetryableError(classifyError(err), err) {
+			log.Printf("Import of contacts %d-%d was rejected: %v. Importing them one by one.", start+1, end, err)
+			for _, i := range indexes[start:end] {
+				retrying[i] = true
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to import contacts %d-%d: %w", start+1, end, err)
+		}
+
+		log.Printf("Started import process %d for contacts %d-%d", processID, start+1, end)
+
+		status, err := b.PollImportProcess(processID, timeout)
+		if err != nil {
+			return fmt.Errorf("import process %d for contacts %d-%d: %w", processID, start+1, end, err)
+		}
+
+		failures, err := b.importReport(status)
+		if err != nil {
+			return fmt.Errorf("import process %d for contacts %d-%d: %w", processID, start+1, end, err)
+		}
+		if len(failures) > 0 {
+			log.Printf("Import process %d rejected %d of contacts %d-%d", processID, len(failures), start+1, end)
+		}
+		for email, reason := range failures {
+			rejected[email] = reason
+		}
+	}
+
+	retried, recovered := 0, 0
+	for _, i := range indexes {
+		data := &csvData[i]
+		row := i + 2
+		email := strings.ToLower(data.Email)
+		reason, failed := rejected[email]
+
+		if retrying[i] || (failed && opts.RetryFailedImports && submitted[email] == i) {
+			retried++
+			if b.retryImportIndividually(csvData, i, listID, existingContacts, opts, results) {
+				recovered++
+			}
+			continue
+		}
+
+		// A repeated email was imported once, with its last row.
+		if submitted[email] != i {
+			continue
+		}
+
+		if failed {
+			results.Errors.addRow(row, data.Email, errors.New(reason), "Bulk import rejected contact")
+			continue
+		}
+
+		contactResult := ContactResult{Row: row, Email: data.Email, Data: data, Truncations: truncations[i], Preserved: preserved[i], InclusionReason: b.contactReason(data)}
+		switch {
+		case existingContacts[email]:
+			contactResult.Action = ContactActionUpdated
+			results.UpdatedContacts = append(results.UpdatedContacts, contactResult)
+		case complete:
+			contactResult.Action = ContactActionAdded
+			results.AddedToCampaign = append(results.AddedToCampaign, contactResult)
+		default:
+			contactResult.Action = ContactActionImported
+			results.AddedToCampaign = append(results.AddedToCampaign, contactResult)
+		}
+	}
+
+	if retried > 0 {
+		log.Printf("Recovered %d of %d contacts the bulk import did not load, %d still failed", recovered, retried, retried-recovered)
+	}
+
+	return nil
+}
+
+// retryImportIndividually imports the contact at index i through the
+// single-contact path, which records its final outcome like a normal
+// per-contact run. It reports whether the contact was added or updated;
+// contacts the single-contact path skipped or excluded do not count.
+func (b *BrevoService) retryImportIndividually(csvData []CSVData, i int, listID int, existingContacts map[string]bool, opts ProcessOptions, results *ProcessingResults) bool {
+	contactService, cancel := b.withContactBudget(opts)
+	defer cancel()
+
+	loaded := len(results.AddedToCampaign) + len(results.UpdatedContacts)
+	contactService.importContact(i+2, &csvData[i], existingContacts, []int{listID}, results)
+	return len(results.AddedToCampaign)+len(results.UpdatedContacts) > loaded
+}
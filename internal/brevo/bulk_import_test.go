@@ -0,0 +1,363 @@
+package brevo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestReadImportReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		report  string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "email and error columns",
+			report: "EMAIL,ERROR\nBad@Example.com,Invalid email address\n",
+			want:   map[string]string{"bad@example.com": "Invalid email address"},
+		},
+		{
+			name:   "reason column after other columns",
+			report: "row,contact email,reason\n3,bad@example.com,Blacklisted\n4,,Missing\n",
+			want:   map[string]string{"bad@example.com": "Blacklisted"},
+		},
+		{
+			name:   "no error column",
+			report: "email\nbad@example.com\n",
+			want:   map[string]string{"bad@example.com": "rejected by the import"},
+		},
+		{
+			name:   "empty report",
+			report: "",
+			want:   nil,
+		},
+		{
+			name:    "no email column",
+			report:  "id,error\n1,Invalid\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readImportReport(strings.NewReader(tt.report))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readImportReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("readImportReport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkImportContactsMixedSuccessChunk(t *testing.T) {
+	tests := []struct {
+		name         string
+		retry        bool
+		importStatus int
+		wantErr      bool
+		wantAdded    []string
+		wantFailed   []string
+		wantSingle   []string
+		smsConflict  bool
+		wantLog      string
+	}{
+		{
+			name:         "rejected contact recorded without retry",
+			importStatus: http.StatusAccepted,
+			wantAdded:    []string{"good@example.com"},
+			wantFailed:   []string{"bad@example.com"},
+		},
+		{
+			name:         "only the rejected contact is retried",
+			retry:        true,
+			importStatus: http.StatusAccepted,
+			wantAdded:    []string{"bad@example.com", "good@example.com"},
+			wantSingle:   []string{"bad@example.com"},
+			wantLog:      "Recovered 1 of 1 contacts",
+		},
+		{
+			name:         "retried contact skipped for its SMS is not recovered",
+			retry:        true,
+			importStatus: http.StatusAccepted,
+			smsConflict:  true,
+			wantAdded:    []string{"good@example.com"},
+			wantSingle:   []string{"bad@example.com"},
+			wantLog:      "Recovered 0 of 1 contacts",
+		},
+		{
+			name:         "transient chunk failure retries the chunk one by one",
+			retry:        true,
+			importStatus: http.StatusServiceUnavailable,
+			wantAdded:    []string{"bad@example.com", "good@example.com"},
+			wantSingle:   []string{"bad@example.com", "good@example.com"},
+			wantLog:      "Recovered 2 of 2 contacts",
+		},
+		{
+			name:         "unauthorized chunk stops the import",
+			retry:        true,
+			importStatus: http.StatusUnauthorized,
+			wantErr:      true,
+		},
+		{
+			name:         "bad request chunk stops the import",
+			retry:        true,
+			importStatus: http.StatusBadRequest,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var single []string
+			var reportURL string
+
+			service, server := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/v3/contacts/import":
+					w.WriteHeader(tt.importStatus)
+					if tt.importStatus == http.StatusAccepted {
+						fmt.Fprint(w, `{"processId":7}`)
+					} else {
+						fmt.Fprint(w, `{"code":"error","message":"rejected"}`)
+					}
+				case r.URL.Path == "/v3/processes/7":
+					json.NewEncoder(w).Encode(ImportStatus{ProcessID: 7, Status: "completed", ExportURL: reportURL})
+				case r.URL.Path == "/report.csv":
+					fmt.Fprint(w, "email,error\nbad@example.com,Invalid email address\n")
+				case r.Method == "POST" && r.URL.Path == "/v3/contacts":
+					var payload struct {
+						Email string `json:"email"`
+					}
+					json.NewDecoder(r.Body).Decode(&payload)
+					mu.Lock()
+					single = append(single, payload.Email)
+					mu.Unlock()
+					if tt.smsConflict {
+						w.WriteHeader(http.StatusBadRequest)
+						fmt.Fprint(w, `{"code":"duplicate_parameter","message":"SMS is already associated with another Contact"}`)
+						return
+					}
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"id":1}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			reportURL = server.URL + "/report.csv"
+			service.config.SMSConflictStrategy = SMSConflictSkip
+
+			var logs bytes.Buffer
+			previous := log.Writer()
+			log.SetOutput(&logs)
+			t.Cleanup(func() { log.SetOutput(previous) })
+
+			opts := service.config.Process
+			opts.RetryFailedImports = tt.retry
+			csvData := []CSVData{{Email: "good@example.com"}, {Email: "bad@example.com"}}
+
+			var results ProcessingResults
+			err := service.bulkImportContacts(csvData, 5, map[string]bool{}, true, nil, opts, &results)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("bulkImportContacts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var added, failed []string
+			for _, result := range results.AddedToCampaign {
+				added = append(added, result.Email)
+			}
+			for _, result := range results.Errors {
+				failed = append(failed, result.Email)
+			}
+			slices.Sort(added)
+			slices.Sort(single)
+
+			if !slices.Equal(added, tt.wantAdded) {
+				t.Errorf("added = %v, want %v", added, tt.wantAdded)
+			}
+			if !slices.Equal(failed, tt.wantFailed) {
+				t.Errorf("failed = %v, want %v", failed, tt.wantFailed)
+			}
+			if !slices.Equal(single, tt.wantSingle) {
+				t.Errorf("single-contact imports = %v, want %v", single, tt.wantSingle)
+			}
+			if tt.wantLog != "" && !strings.Contains(logs.String(), tt.wantLog) {
+				t.Errorf("logs do not contain %q", tt.wantLog)
+			}
+		})
+	}
+}
+
+func TestBulkImportContactsOutcomesFollowCSVOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		rows   []string
+		report string
+		retry  bool
+		want   []string
+	}{
+		{
+			name: "no report loads every contact",
+			rows: []string{"c@example.com", "a@example.com", "b@example.com"},
+			want: []string{"c@example.com", "a@example.com", "b@example.com"},
+		},
+		{
+			name:   "rejected contacts are left out",
+			rows:   []string{"c@example.com", "a@example.com", "b@example.com"},
+			report: "email,error\na@example.com,Invalid\n",
+			want:   []string{"c@example.com", "b@example.com"},
+		},
+		{
+			name:   "retried contact keeps its row position",
+			rows:   []string{"c@example.com", "a@example.com", "b@example.com"},
+			report: "email,error\na@example.com,Temporary failure\n",
+			retry:  true,
+			want:   []string{"c@example.com", "a@example.com", "b@example.com"},
+		},
+		{
+			name: "repeated email is recorded once",
+			rows: []string{"a@example.com", "b@example.com", "A@example.com"},
+			want: []string{"b@example.com", "A@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reportURL string
+			service, server := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v3/contacts/import":
+					w.WriteHeader(http.StatusAccepted)
+					fmt.Fprint(w, `{"processId":3}`)
+				case "/v3/processes/3":
+					status := ImportStatus{ProcessID: 3, Status: "completed"}
+					if tt.report != "" {
+						status.ExportURL = reportURL
+					}
+					json.NewEncoder(w).Encode(status)
+				case "/report.csv":
+					fmt.Fprint(w, tt.report)
+				case "/v3/contacts":
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"id":1}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			reportURL = server.URL + "/report.csv"
+
+			csvData := make([]CSVData, len(tt.rows))
+			for i, email := range tt.rows {
+				csvData[i].Email = email
+			}
+
+			opts := service.config.Process
+			opts.RetryFailedImports = tt.retry
+
+			var results ProcessingResults
+			if err := service.bulkImportContacts(csvData, 5, map[string]bool{}, true, nil, opts, &results); err != nil {
+				t.Fatalf("bulkImportContacts() error = %v", err)
+			}
+
+			var got []string
+			for _, result := range results.AddedToCampaign {
+				got = append(got, result.Email)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("added = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkImportContactsAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		complete bool
+		want     map[string]string
+	}{
+		{
+			name:     "whole account fetched",
+			complete: true,
+			want:     map[string]string{"old@example.com": ContactActionUpdated, "new@example.com": ContactActionAdded},
+		},
+		{
+			name: "only the target list fetched",
+			want: map[string]string{"old@example.com": ContactActionUpdated, "new@example.com": ContactActionImported},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v3/contacts/import":
+					w.WriteHeader(http.StatusAccepted)
+					fmt.Fprint(w, `{"processId":3}`)
+				case "/v3/processes/3":
+					fmt.Fprint(w, `{"id":3,"status":"completed"}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			csvData := []CSVData{{Email: "old@example.com"}, {Email: "new@example.com"}}
+			existing := map[string]bool{"old@example.com": true}
+
+			var results ProcessingResults
+			if err := service.bulkImportContacts(csvData, 5, existing, tt.complete, nil, service.config.Process, &results); err != nil {
+				t.Fatalf("bulkImportContacts() error = %v", err)
+			}
+
+			got := make(map[string]string)
+			for _, contacts := range [][]ContactResult{results.AddedToCampaign, results.UpdatedContacts} {
+				for _, result := range contacts {
+					got[result.Email] = result.Action
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("actions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportReportCountsAgainstBudget(t *testing.T) {
+	var downloads int
+	service, server := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		if r.Header.Get("api-key") != "" {
+			t.Error("import report download sent the API key")
+		}
+		fmt.Fprint(w, "email,error\nbad@example.com,Invalid\n")
+	}))
+
+	run, cancel := service.withAPIBudget()
+	defer cancel()
+	run.apiBudget.max = 1
+
+	status := ImportStatus{ProcessID: 3, Status: "completed", ExportURL: server.URL + "/report.csv"}
+	if _, err := run.importReport(status); err != nil {
+		t.Fatalf("importReport() error = %v", err)
+	}
+	if _, err := run.importReport(status); !errors.Is(err, ErrAPIBudgetExceeded) {
+		t.Errorf("second importReport() error = %v, want ErrAPIBudgetExceeded", err)
+	}
+	if downloads != 1 {
+		t.Errorf("downloads = %d, want 1", downloads)
+	}
+}
@@ -0,0 +1,60 @@
+package brevo
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNoCampaignContent is returned, wrapped, when Brevo returns a campaign
+// without its HTML, e.g. for campaigns built in the drag-and-drop editor
+// that have not been rendered yet.
+var ErrNoCampaignContent = errors.New("campaign has no HTML content")
+
+// SaveSentCampaignHTML fetches campaignID back from Brevo and writes its
+// HTML to path, capturing exactly what was sent, including anything Brevo
+// injected such as the footer.
+func (b *BrevoService) SaveSentCampaignHTML(campaignID int, path string) error {
+	campaign, err := b.getCampaign(campaignID)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(campaign.HTMLContent) == "" {
+		return fmt.Errorf("%w: campaign %d", ErrNoCampaignContent, campaignID)
+	}
+
+	if err := os.WriteFile(path, []byte(campaign.HTMLContent), 0o644); err != nil {
+		return fmt.Errorf("failed to write campaign %d HTML to '%s': %w", campaignID, path, err)
+	}
+
+	log.Printf("Campaign %d HTML saved to %s", campaignID, path)
+	return nil
+}
+
+// campaignHTMLPath names the archive file next to the run report, e.g.
+// report.json becomes report.campaign-42.html.
+func campaignHTMLPath(reportPath string, campaignID int) string {
+	base := strings.TrimSuffix(reportPath, filepath.Ext(reportPath))
+	return fmt.Sprintf("%s.campaign-%d.html", base, campaignID)
+}
+
+// archiveCampaignHTML saves the run's campaign HTML next to the report and
+// returns the path, or "" when nothing was saved. Failures are only logged.
+func (b *BrevoService) archiveCampaignHTML(results ProcessingResults) string {
+	campaignID := results.CampaignInfo.CampaignID
+	if !b.config.ArchiveCampaignHTML || b.config.ReportPath == "" || campaignID <= 0 {
+		return ""
+	}
+
+	path := campaignHTMLPath(b.config.ReportPath, campaignID)
+	if err := b.SaveSentCampaignHTML(campaignID, path); err != nil {
+		log.Printf("Warning: Could not archive campaign HTML: %v", err)
+		return ""
+	}
+
+	return path
+}
@@ -0,0 +1,277 @@
+package brevo
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	DefaultTemplateFile    string = "message_template.html"
+	DefaultCampaignSubject string = "დოკუმენტაციის თარგმნა ნოტარიულად დამოწმებით"
+	DefaultNameTemplate    string = "CSV Import Campaign - {{.Timestamp}}"
+
+	maxCampaignNameRetries int = 3
+
+	// Brevo rejects campaigns whose HTML grows too large, so inline images
+	// are capped individually and the final HTML is capped as a whole.
+	MaxInlineImageBytes int = 1 << 20
+	MaxHTMLContentBytes int = 4 << 20
+)
+
+// Extensions Brevo accepts for a campaign attachmentUrl.
+var allowedAttachmentExtensions = map[string]bool{
+	".pdf": true, ".doc": true, ".docx": true, ".xls": true, ".xlsx": true,
+	".csv": true, ".txt": true, ".rtf": true, ".zip": true, ".ics": true,
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".bmp": true,
+	".tif": true, ".tiff": true,
+}
+
+type CampaignConfig struct {
+	TemplateFile string
+	// Language selects a localized template, e.g. "en" picks
+	// message_template.en.html. Empty picks the language of the recipients'
+	// country when they all share one, and uses TemplateFile as is
+	// otherwise.
+	Language string
+	// InlineHTML, when set, is used as the campaign body instead of
+	// loading TemplateFile from disk.
+	InlineHTML    string
+	Subject       string
+	NameTemplate  string
+	AttachmentURL string
+	InlineImages  []InlineImage
+	// InlineImageActivation asks Brevo to embed images in the email itself
+	// rather than linking them.
+	InlineImageActivation bool
+	// InlineCSS moves <style> rules into style attributes, for clients that
+	// ignore stylesheets. MinifyHTML strips comments and indentation.
+	InlineCSS  bool
+	MinifyHTML bool
+	// ValidateHTML checks the final body with ValidateCampaignHTML and logs
+	// what it finds. StrictHTML also refuses to create the campaign when a
+	// check fails with an error.
+	ValidateHTML bool
+	StrictHTML   bool
+	// Tags are added to every campaign besides the automatic run and source
+	// tags, for filtering in the dashboard. Brevo keeps one tag per
+	// campaign, so only the first tag is sent; see campaignTags.
+	Tags []string
+}
+
+// InlineImage is embedded into the template as a data URI wherever
+// Placeholder appears, so the image does not need external hosting.
+type InlineImage struct {
+	Placeholder string
+	ContentType string
+	Data        []byte
+}
+
+// CampaignMeta is the run metadata a NameTemplate can reference.
+type CampaignMeta struct {
+	CSVName string
+	Count   int
+	// RunID and Source feed the automatic campaign tags. Source defaults
+	// to CSVName.
+	RunID  string
+	Source string
+}
+
+// campaignTags returns cfg's tags followed by the automatic "run:" and
+// "source:" tags, without duplicates. Brevo's tag field holds a single
+// tag, so campaigns are created with the first one, the one they can be
+// filtered by; the full list is only kept in CampaignResult.Tags.
+func campaignTags(cfg CampaignConfig, meta CampaignMeta) []string {
+	source := meta.Source
+	if source == "" {
+		source = meta.CSVName
+	}
+
+	tags := []string{}
+	seen := make(map[string]bool)
+	add := func(tag string) {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	for _, tag := range cfg.Tags {
+		add(tag)
+	}
+	if meta.RunID != "" {
+		add("run:" + meta.RunID)
+	}
+	if source != "" {
+		add("source:" + source)
+	}
+
+	return tags
+}
+
+// campaignTag is the tag a campaign is created with: the first of tags.
+func campaignTag(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return tags[0]
+}
+
+type campaignNameData struct {
+	CSVName   string
+	Date      string
+	Count     int
+	Timestamp int64
+}
+
+func DefaultCampaignConfig() CampaignConfig {
+	return CampaignConfig{
+		TemplateFile: DefaultTemplateFile,
+		Subject:      DefaultCampaignSubject,
+		NameTemplate: DefaultNameTemplate,
+	}
+}
+
+// RenderName renders NameTemplate (or DefaultNameTemplate when unset) with
+// {{.CSVName}}, {{.Date}}, {{.Count}} and {{.Timestamp}}.
+func (c CampaignConfig) RenderName(meta CampaignMeta) (string, error) {
+	nameTemplate := c.NameTemplate
+	if nameTemplate == "" {
+		nameTemplate = DefaultNameTemplate
+	}
+
+	tmpl, err := template.New("campaign-name").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid name template '%s': %w", nameTemplate, err)
+	}
+
+	now := time.Now()
+	data := campaignNameData{
+		CSVName:   meta.CSVName,
+		Date:      now.Format("2006-01-02"),
+		Count:     meta.Count,
+		Timestamp: now.Unix(),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render name template '%s': %w", nameTemplate, err)
+	}
+
+	name := strings.TrimSpace(buf.String())
+	if name == "" {
+		return "", fmt.Errorf("name template '%s' rendered an empty name", nameTemplate)
+	}
+
+	return name, nil
+}
+
+func (c CampaignConfig) Validate() error {
+	name, err := c.RenderName(CampaignMeta{CSVName: "validate", Count: 1})
+	if err != nil {
+		return err
+	}
+
+	// The subject is Georgian; invalid UTF-8 would reach inboxes as mojibake.
+	if !utf8.ValidString(c.Subject) || !utf8.ValidString(name) {
+		return fmt.Errorf("campaign subject and name must be valid UTF-8")
+	}
+
+	if c.Language != "" {
+		if err := validateLanguage(c.Language); err != nil {
+			return err
+		}
+	}
+
+	if c.InlineHTML != "" {
+		if err := validateInlineHTML(c.InlineHTML); err != nil {
+			return err
+		}
+	}
+
+	if c.AttachmentURL != "" {
+		u, err := url.Parse(c.AttachmentURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid attachment URL '%s'", c.AttachmentURL)
+		}
+
+		ext := strings.ToLower(path.Ext(u.Path))
+		if !allowedAttachmentExtensions[ext] {
+			return fmt.Errorf("attachment URL '%s' has unsupported extension '%s'", c.AttachmentURL, ext)
+		}
+	}
+
+	for _, img := range c.InlineImages {
+		if img.Placeholder == "" {
+			return fmt.Errorf("inline image is missing a placeholder")
+		}
+		if !strings.HasPrefix(img.ContentType, "image/") {
+			return fmt.Errorf("inline image '%s' has non-image content type '%s'", img.Placeholder, img.ContentType)
+		}
+		if len(img.Data) == 0 {
+			return fmt.Errorf("inline image '%s' is empty", img.Placeholder)
+		}
+		if len(img.Data) > MaxInlineImageBytes {
+			return fmt.Errorf("inline image '%s' is %d bytes, limit is %d", img.Placeholder, len(img.Data), MaxInlineImageBytes)
+		}
+	}
+
+	return nil
+}
+
+var htmlTagPattern = regexp.MustCompile(`(?i)<(html|body|div|table|p|span|a|img|h[1-6])[\s>/]`)
+
+func validateInlineHTML(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("inline HTML is blank")
+	}
+
+	if !htmlTagPattern.MatchString(content) {
+		return fmt.Errorf("inline HTML does not contain any HTML markup")
+	}
+
+	return nil
+}
+
+func applyInlineImages(htmlContent string, images []InlineImage) (string, error) {
+	for _, img := range images {
+		if !strings.Contains(htmlContent, img.Placeholder) {
+			return "", fmt.Errorf("placeholder '%s' not found in template", img.Placeholder)
+		}
+
+		dataURI := fmt.Sprintf("data:%s;base64,%s", img.ContentType, base64.StdEncoding.EncodeToString(img.Data))
+		htmlContent = strings.ReplaceAll(htmlContent, img.Placeholder, dataURI)
+	}
+
+	if len(htmlContent) > MaxHTMLContentBytes {
+		return "", fmt.Errorf("HTML content is %d bytes, limit is %d", len(htmlContent), MaxHTMLContentBytes)
+	}
+
+	return htmlContent, nil
+}
+
+func isDuplicateCampaignNameError(statusCode int, body string) bool {
+	lower := strings.ToLower(body)
+	return statusCode == http.StatusBadRequest &&
+		strings.Contains(lower, "name") &&
+		(strings.Contains(lower, "already exist") || strings.Contains(lower, "duplicate"))
+}
+
+func shortSuffix() string {
+	buf := make([]byte, 3)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano()&0xffffff)
+	}
+	return hex.EncodeToString(buf)
+}
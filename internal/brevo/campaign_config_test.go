@@ -0,0 +1,124 @@
+package brevo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCampaignTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		meta    CampaignMeta
+		want    []string
+		wantTag string
+	}{
+		{
+			name:    "automatic tags only",
+			meta:    CampaignMeta{CSVName: "winners.csv", RunID: "r1"},
+			want:    []string{"run:r1", "source:winners.csv"},
+			wantTag: "run:r1",
+		},
+		{
+			name:    "configured tags come first and are deduplicated",
+			tags:    []string{" weekly ", "run:r1", "", "weekly"},
+			meta:    CampaignMeta{CSVName: "winners.csv", RunID: "r1", Source: "portal"},
+			want:    []string{"weekly", "run:r1", "source:portal"},
+			wantTag: "weekly",
+		},
+		{
+			name:    "no tags",
+			want:    []string{},
+			wantTag: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := campaignTags(CampaignConfig{Tags: tt.tags}, tt.meta)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("campaignTags() = %q, want %q", got, tt.want)
+			}
+			if tag := campaignTag(got); tag != tt.wantTag {
+				t.Errorf("campaignTag() = %q, want %q", tag, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestCampaignPayloadUTF8RoundTrip(t *testing.T) {
+	tests := []struct {
+		name                  string
+		subject               string
+		campaignName          string
+		inlineImageActivation bool
+	}{
+		{name: "Georgian default subject", subject: DefaultCampaignSubject, campaignName: "CSV Import Campaign - 2024-01-02"},
+		{name: "Georgian name", subject: "Hello", campaignName: "გამარჯვებულები - 2024-01-02"},
+		{name: "mixed scripts with inline images", subject: "თარგმნა & <translation> \"quoted\"", campaignName: "Winners ✓", inlineImageActivation: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received []byte
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received, _ = io.ReadAll(r.Body)
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id":1}`))
+			}))
+
+			result, _ := service.postCampaign(CampaignPayload{
+				Name:                  tt.campaignName,
+				Subject:               tt.subject,
+				InlineImageActivation: tt.inlineImageActivation,
+			})
+			if !result.Success {
+				t.Fatalf("postCampaign() failed: %s", result.Error)
+			}
+
+			var decoded map[string]any
+			if err := json.Unmarshal(received, &decoded); err != nil {
+				t.Fatalf("decode sent payload: %v", err)
+			}
+			if decoded["subject"] != tt.subject {
+				t.Errorf("sent subject = %q, want %q", decoded["subject"], tt.subject)
+			}
+			if decoded["name"] != tt.campaignName {
+				t.Errorf("sent name = %q, want %q", decoded["name"], tt.campaignName)
+			}
+			if !bytes.Contains(received, []byte(strings.Split(tt.subject, " ")[0])) {
+				t.Errorf("sent payload %s escapes the subject instead of sending it as UTF-8", received)
+			}
+
+			_, activated := decoded["inlineImageActivation"]
+			if activated != tt.inlineImageActivation {
+				t.Errorf("inlineImageActivation sent = %v, want %v", activated, tt.inlineImageActivation)
+			}
+		})
+	}
+}
+
+func TestValidateRejectsInvalidUTF8(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     CampaignConfig
+		wantErr bool
+	}{
+		{name: "Georgian subject", cfg: CampaignConfig{Subject: DefaultCampaignSubject}},
+		{name: "invalid subject", cfg: CampaignConfig{Subject: "\xff\xfe"}, wantErr: true},
+		{name: "invalid name template", cfg: CampaignConfig{Subject: "ok", NameTemplate: "Winners \xc3"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package brevo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+const (
+	campaignPollInterval time.Duration = 5 * time.Second
+	CampaignStatusSent   string        = "sent"
+)
+
+// ErrCampaignStatusTimeout is returned, wrapped, when a campaign does not
+// reach the expected status within the poll timeout.
+var ErrCampaignStatusTimeout = errors.New("campaign did not reach the expected status in time")
+
+type campaignStatusResponse struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Campaign statuses Brevo reports once sendNow has been accepted.
+var sentCampaignStatuses = map[string]bool{
+	"sent":       true,
+	"queued":     true,
+	"in_process": true,
+	"inProcess":  true,
+	"archive":    true,
+}
+
+func (b *BrevoService) GetCampaignStatus(campaignID int) (string, error) {
+	url := fmt.Sprintf("https://api.brevo.com/v3/emailCampaigns/%d", campaignID)
+
+	resp, err := b.makeAPIRequest(opCampaign, "GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("error fetching campaign %d: %w", campaignID, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read campaign %d response body: %w", campaignID, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch campaign %d: status %d - %s", campaignID, resp.StatusCode, string(body))
+	}
+
+	var campaign campaignStatusResponse
+	if err := json.Unmarshal(body, &campaign); err != nil {
+		return "", fmt.Errorf("failed to decode campaign %d response: %w", campaignID, err)
+	}
+
+	return campaign.Status, nil
+}
+
+func isCampaignAlreadySent(status string) bool {
+	return sentCampaignStatuses[status]
+}
+
+// PollCampaignStatus waits until campaignID reaches status, checking every
+// few seconds until timeout. It returns the last status Brevo reported.
+func (b *BrevoService) PollCampaignStatus(campaignID int, status string, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		current, err := b.GetCampaignStatus(campaignID)
+		if err != nil {
+			return "", fmt.Errorf("error polling campaign %d: %w", campaignID, err)
+		}
+
+		if current == status {
+			log.Printf("Campaign %d is '%s'", campaignID, status)
+			return current, nil
+		}
+
+		if time.Now().After(deadline) {
+			return current, fmt.Errorf("%w: campaign %d still '%s' after %s", ErrCampaignStatusTimeout, campaignID, current, timeout)
+		}
+
+		log.Printf("Campaign %d is '%s'. Waiting for '%s'...", campaignID, current, status)
+
+		select {
+		case <-time.After(campaignPollInterval):
+		case <-b.context().Done():
+			return current, b.context().Err()
+		}
+	}
+}
+
+// verifyCampaignSent confirms a campaign Brevo accepted for sending really
+// went out, recording the status it reached. A stalled send is reported as
+// a warning since the campaign itself was created and queued.
+func (b *BrevoService) verifyCampaignSent(campaignID int, timeout time.Duration, results *ProcessingResults) {
+	status, err := b.PollCampaignStatus(campaignID, CampaignStatusSent, timeout)
+	results.CampaignVerifiedStatus = status
+	if err != nil {
+		warning := fmt.Sprintf("campaign %d could not be verified as sent: %v", campaignID, err)
+		log.Printf("Warning: %s", warning)
+		results.Warnings = append(results.Warnings, warning)
+	}
+}
+
+// recentCampaignsLimit is how many of the newest campaigns findCampaignByName
+// searches. A create whose response was lost is among the newest.
+const recentCampaignsLimit int = 100
+
+// findCampaignByName looks for a campaign named name among the newest ones,
+// to tell whether a create that got no answer went through.
+func (b *BrevoService) findCampaignByName(name string) (int, bool, error) {
+	url := fmt.Sprintf("https://api.brevo.com/v3/emailCampaigns?limit=%d&offset=0&sort=desc&excludeHtmlContent=true", recentCampaignsLimit)
+
+	resp, err := b.makeAPIRequest(opCampaign, "GET", url, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("error listing campaigns: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read campaigns response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, false, fmt.Errorf("failed to list campaigns: status %d - %s", resp.StatusCode, string(body))
+	}
+
+	var campaigns struct {
+		Campaigns []campaignStatusResponse `json:"campaigns"`
+	}
+	if err := json.Unmarshal(body, &campaigns); err != nil {
+		return 0, false, fmt.Errorf("failed to decode campaigns response: %w", err)
+	}
+
+	for _, campaign := range campaigns.Campaigns {
+		if campaign.Name == name {
+			return campaign.ID, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
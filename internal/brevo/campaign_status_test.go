@@ -0,0 +1,117 @@
+package brevo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// campaignStatusServer mocks a single campaign whose status flips to sent
+// once sendNow is accepted.
+type campaignStatusServer struct {
+	mu     sync.Mutex
+	status string
+	sends  int
+}
+
+func (s *campaignStatusServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/sendNow"):
+		s.sends++
+		s.status = "sent"
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && r.URL.Path == "/v3/emailCampaigns/7":
+		fmt.Fprintf(w, `{"id":7,"name":"Winners","status":%q}`, s.status)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestSendCampaignToContactsRefusesResend(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          string
+		wantAlreadySent bool
+		wantSends       int
+	}{
+		{name: "draft is sent", status: "draft", wantSends: 1},
+		{name: "sent is refused", status: "sent", wantAlreadySent: true},
+		{name: "queued is refused", status: "queued", wantAlreadySent: true},
+		{name: "in process is refused", status: "in_process", wantAlreadySent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &campaignStatusServer{status: tt.status}
+			service, _ := newTestService(t, mock)
+
+			result := service.SendCampaignToContacts(7)
+
+			if !result.Success {
+				t.Fatalf("SendCampaignToContacts() failed: %s", result.Error)
+			}
+			if result.AlreadySent != tt.wantAlreadySent {
+				t.Errorf("AlreadySent = %v, want %v", result.AlreadySent, tt.wantAlreadySent)
+			}
+			if mock.sends != tt.wantSends {
+				t.Errorf("sendNow called %d times, want %d", mock.sends, tt.wantSends)
+			}
+		})
+	}
+}
+
+func TestSendCampaignToContactsAfterLostAck(t *testing.T) {
+	mock := &campaignStatusServer{status: "draft"}
+	service, _ := newTestService(t, mock)
+
+	// Brevo accepts the first send, but its answer never arrives.
+	forward := service.httpClient.Transport
+	lost := false
+	service.httpClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := forward.RoundTrip(req)
+		if err == nil && !lost && strings.HasSuffix(req.URL.Path, "/sendNow") {
+			lost = true
+			resp.Body.Close()
+			return nil, errors.New("connection reset by peer")
+		}
+		return resp, err
+	})
+
+	first := service.SendCampaignToContacts(7)
+	if first.Success {
+		t.Fatalf("first send succeeded, want the lost ack to fail it")
+	}
+
+	second := service.SendCampaignToContacts(7)
+	if !second.Success || !second.AlreadySent {
+		t.Errorf("retried send = %+v, want an already-sent success", second)
+	}
+	if mock.sends != 1 {
+		t.Errorf("sendNow called %d times, want 1", mock.sends)
+	}
+}
+
+func TestSendCampaignToContactsStatusLookupFails(t *testing.T) {
+	sends := 0
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/sendNow") {
+			sends++
+		}
+		http.Error(w, `{"message":"unavailable"}`, http.StatusServiceUnavailable)
+	}))
+
+	result := service.SendCampaignToContacts(7)
+
+	if result.Success {
+		t.Errorf("SendCampaignToContacts() succeeded without knowing the campaign status")
+	}
+	if sends != 0 {
+		t.Errorf("sendNow called %d times, want 0", sends)
+	}
+}
@@ -0,0 +1,162 @@
+package brevo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// DefaultCheckpointMaxAge is how long a saved fetch checkpoint stays
+// usable. An older one describes an account that has changed since.
+const DefaultCheckpointMaxAge time.Duration = 24 * time.Hour
+
+// FetchCheckpoint persists the progress of GetExistingContantsEmail so a
+// failed fetch on a large account resumes where it stopped instead of
+// starting again from offset 0. scope names what was fetched, the whole
+// account or one list, so a fetch never resumes another scope's progress.
+type FetchCheckpoint interface {
+	// Load returns offset 0 and an empty map when nothing usable was saved
+	// for scope.
+	Load(scope string) (int, map[string]bool, error)
+	Save(scope string, offset int, contacts map[string]bool) error
+	Clear() error
+}
+
+// FileFetchCheckpoint keeps the checkpoint in a JSON file. A checkpoint
+// older than MaxAge is discarded; zero uses DefaultCheckpointMaxAge.
+type FileFetchCheckpoint struct {
+	Path   string
+	MaxAge time.Duration
+}
+
+type fetchCheckpointState struct {
+	Scope    string          `json:"scope"`
+	SavedAt  time.Time       `json:"saved_at"`
+	Offset   int             `json:"offset"`
+	Contacts map[string]bool `json:"contacts"`
+}
+
+func NewFileFetchCheckpoint(path string) *FileFetchCheckpoint {
+	return &FileFetchCheckpoint{Path: path}
+}
+
+func (f *FileFetchCheckpoint) Load(scope string) (int, map[string]bool, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, map[string]bool{}, nil
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read checkpoint '%s': %w", f.Path, err)
+	}
+
+	var state fetchCheckpointState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return 0, nil, fmt.Errorf("failed to decode checkpoint '%s': %w", f.Path, err)
+	}
+
+	if state.Scope != scope {
+		log.Printf("Discarding checkpoint '%s': it was saved by a fetch of %q, not %q", f.Path, state.Scope, scope)
+		return 0, map[string]bool{}, nil
+	}
+
+	maxAge := f.MaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultCheckpointMaxAge
+	}
+	if age := time.Since(state.SavedAt); age > maxAge {
+		log.Printf("Discarding checkpoint '%s': saved %s ago, older than %s", f.Path, age.Round(time.Second), maxAge)
+		return 0, map[string]bool{}, nil
+	}
+
+	if state.Contacts == nil {
+		state.Contacts = map[string]bool{}
+	}
+
+	return state.Offset, state.Contacts, nil
+}
+
+// Save writes to a temporary file first so a crash mid-write never leaves a
+// truncated checkpoint behind.
+func (f *FileFetchCheckpoint) Save(scope string, offset int, contacts map[string]bool) error {
+	data, err := json.Marshal(fetchCheckpointState{Scope: scope, SavedAt: time.Now(), Offset: offset, Contacts: contacts})
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	tmpPath := f.Path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write checkpoint '%s': %w", tmpPath, err)
+	}
+
+	return os.Rename(tmpPath, f.Path)
+}
+
+func (f *FileFetchCheckpoint) Clear() error {
+	if err := os.Remove(f.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove checkpoint '%s': %w", f.Path, err)
+	}
+	return nil
+}
+
+// SetFetchCheckpoint enables resumable contact fetches. Pass nil to disable.
+func (b *BrevoService) SetFetchCheckpoint(checkpoint FetchCheckpoint) {
+	b.checkpoint = checkpoint
+}
+
+// Checkpoint scopes of the account-wide and the per-list contact fetch.
+const accountFetchScope string = "account"
+
+func listFetchScope(listID int) string {
+	return fmt.Sprintf("list %d", listID)
+}
+
+// resumeFetch returns the offset and contacts a fetch of scope starts from:
+// those of a saved checkpoint, or offset 0 and an empty map. A checkpoint
+// only holds emails, so it is not resumed when states is set.
+func (b *BrevoService) resumeFetch(scope string, states *contactStates) (int, map[string]bool) {
+	if b.checkpoint == nil {
+		return 0, make(map[string]bool)
+	}
+
+	if states != nil {
+		log.Printf("Not resuming the contact fetch from a checkpoint: contact states are needed for every page")
+		return 0, make(map[string]bool)
+	}
+
+	offset, contacts, err := b.checkpoint.Load(scope)
+	if err != nil {
+		log.Printf("Warning: Could not load fetch checkpoint: %v. Starting from offset 0.", err)
+		return 0, make(map[string]bool)
+	}
+	if offset <= 0 {
+		return 0, make(map[string]bool)
+	}
+
+	log.Printf("Resuming contact fetch from checkpoint at offset %d with %d contacts", offset, len(contacts))
+	return offset, contacts
+}
+
+// saveFetch records that a fetch of scope got as far as offset.
+func (b *BrevoService) saveFetch(scope string, offset int, contacts map[string]bool) {
+	if b.checkpoint == nil {
+		return
+	}
+
+	if err := b.checkpoint.Save(scope, offset, contacts); err != nil {
+		log.Printf("Warning: Could not save fetch checkpoint at offset %d: %v", offset, err)
+	}
+}
+
+// clearFetch removes the checkpoint of a fetch that completed.
+func (b *BrevoService) clearFetch() {
+	if b.checkpoint == nil {
+		return
+	}
+
+	if err := b.checkpoint.Clear(); err != nil {
+		log.Printf("Warning: Could not clear fetch checkpoint: %v", err)
+	}
+}
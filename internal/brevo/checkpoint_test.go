@@ -0,0 +1,170 @@
+package brevo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// contactsPageServer serves total contacts in pages and fails every request
+// at failAt while failAt is non-negative.
+type contactsPageServer struct {
+	total   int
+	failAt  int
+	offsets []int
+}
+
+func (s *contactsPageServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	s.offsets = append(s.offsets, offset)
+
+	if offset == s.failAt {
+		http.Error(w, `{"message":"bad gateway"}`, http.StatusBadGateway)
+		return
+	}
+
+	var page ContactsResponse
+	for i := offset; i < min(offset+limit, s.total); i++ {
+		page.Contacts = append(page.Contacts, BrevoContact{Email: fmt.Sprintf("User%d@Example.com", i)})
+	}
+	page.Count = s.total
+	json.NewEncoder(w).Encode(page)
+}
+
+func TestGetExistingContactsResumesFromCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fetch.json")
+	mock := &contactsPageServer{total: 2500, failAt: 2000}
+	service, _ := newTestService(t, mock)
+	service.SetFetchCheckpoint(NewFileFetchCheckpoint(path))
+
+	contacts, err := service.GetExistingContantsEmail()
+
+	var partialErr *PartialFetchError
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("first fetch error = %v, want a PartialFetchError", err)
+	}
+	if partialErr.Offset != 2000 || len(contacts) != 2000 {
+		t.Fatalf("first fetch stopped at offset %d with %d contacts, want 2000 and 2000", partialErr.Offset, len(contacts))
+	}
+
+	offset, saved, err := NewFileFetchCheckpoint(path).Load(accountFetchScope)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if offset != 2000 || len(saved) != 2000 {
+		t.Fatalf("checkpoint holds offset %d with %d contacts, want 2000 and 2000", offset, len(saved))
+	}
+
+	mock.failAt = -1
+	mock.offsets = nil
+
+	contacts, err = service.GetExistingContantsEmail()
+	if err != nil {
+		t.Fatalf("resumed fetch error = %v", err)
+	}
+	if len(contacts) != 2500 {
+		t.Errorf("resumed fetch found %d contacts, want 2500", len(contacts))
+	}
+	if !contacts["user0@example.com"] || !contacts["user2499@example.com"] {
+		t.Errorf("resumed fetch lost contacts from before or after the failure")
+	}
+	if len(mock.offsets) != 1 || mock.offsets[0] != 2000 {
+		t.Errorf("resumed fetch requested offsets %v, want only [2000]", mock.offsets)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("checkpoint still exists after a complete fetch: %v", err)
+	}
+}
+
+func TestFetchWithStatesIgnoresCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fetch.json")
+	if err := NewFileFetchCheckpoint(path).Save(accountFetchScope, 1000, map[string]bool{"user0@example.com": true}); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := &contactsPageServer{total: 1500, failAt: -1}
+	service, _ := newTestService(t, mock)
+	service.SetFetchCheckpoint(NewFileFetchCheckpoint(path))
+
+	states := newContactStates(ProcessOptions{SkipBlacklisted: true}, false)
+	contacts, err := service.fetchExistingContacts(states)
+	if err != nil {
+		t.Fatalf("fetchExistingContacts() error = %v", err)
+	}
+	if len(contacts) != 1500 {
+		t.Errorf("fetched %d contacts, want 1500", len(contacts))
+	}
+	if len(mock.offsets) == 0 || mock.offsets[0] != 0 {
+		t.Errorf("requested offsets %v, want the fetch to start at 0", mock.offsets)
+	}
+}
+
+func TestFileFetchCheckpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		save       bool
+		wantOffset int
+		wantCount  int
+		wantErr    bool
+	}{
+		{name: "missing file starts over"},
+		{name: "saved state round-trips", save: true, wantOffset: 3000, wantCount: 2},
+		{name: "corrupt file", content: "{not json", wantErr: true},
+		{name: "no contacts saved", content: checkpointJSON(accountFetchScope, time.Now(), 1000), wantOffset: 1000},
+		{name: "other scope is discarded", content: checkpointJSON(listFetchScope(12), time.Now(), 1000)},
+		{name: "stale checkpoint is discarded", content: checkpointJSON(accountFetchScope, time.Now().Add(-2*DefaultCheckpointMaxAge), 1000)},
+		{name: "checkpoint without scope is discarded", content: `{"offset":1000}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkpoint := NewFileFetchCheckpoint(filepath.Join(t.TempDir(), "fetch.json"))
+
+			if tt.content != "" {
+				if err := os.WriteFile(checkpoint.Path, []byte(tt.content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.save {
+				contacts := map[string]bool{"a@example.com": true, "b@example.com": true}
+				if err := checkpoint.Save(accountFetchScope, 3000, contacts); err != nil {
+					t.Fatalf("Save() error = %v", err)
+				}
+			}
+
+			offset, contacts, err := checkpoint.Load(accountFetchScope)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if offset != tt.wantOffset || len(contacts) != tt.wantCount {
+				t.Errorf("Load() = offset %d with %d contacts, want %d with %d", offset, len(contacts), tt.wantOffset, tt.wantCount)
+			}
+			if contacts == nil {
+				t.Errorf("Load() returned a nil map")
+			}
+
+			if err := checkpoint.Clear(); err != nil {
+				t.Errorf("Clear() error = %v", err)
+			}
+			if err := checkpoint.Clear(); err != nil {
+				t.Errorf("Clear() of a missing checkpoint error = %v", err)
+			}
+		})
+	}
+}
+
+func checkpointJSON(scope string, savedAt time.Time, offset int) string {
+	return fmt.Sprintf(`{"scope":%q,"saved_at":%q,"offset":%d}`, scope, savedAt.Format(time.RFC3339Nano), offset)
+}
@@ -0,0 +1,77 @@
+package brevo
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+)
+
+// csvAttributes are the fixed Brevo attributes buildAttributes fills from
+// the CSV. The contact person attribute is configurable, so
+// csvFilledAttributes adds it.
+var csvAttributes = []string{"COMPANY_NAME", "COMPANY_ID", "SMS", "TENDER_CODE", "COUNTRY"}
+
+// csvFilledAttributes returns csvAttributes followed by contactPerson, the
+// configured ContactPersonConfig.Attribute, when it is set.
+func csvFilledAttributes(contactPerson string) []string {
+	names := slices.Clone(csvAttributes)
+	if contactPerson != "" && !slices.Contains(names, contactPerson) {
+		names = append(names, contactPerson)
+	}
+	return names
+}
+
+// applyClearOnEmpty sets each ClearOnEmpty attribute missing from attributes
+// to an empty value, so updating an existing contact clears what Brevo holds
+// instead of keeping the stale value.
+func (b *BrevoService) applyClearOnEmpty(email string, attributes map[string]any) map[string]any {
+	if len(b.config.ClearOnEmpty) == 0 {
+		return attributes
+	}
+
+	for _, name := range csvFilledAttributes(b.config.ContactPerson.Attribute) {
+		if !b.config.ClearOnEmpty[name] {
+			continue
+		}
+		if _, ok := attributes[name]; ok {
+			continue
+		}
+
+		if attributes == nil {
+			attributes = make(map[string]any)
+		}
+		attributes[name] = ""
+		log.Printf("Clearing %s of %s: the CSV value is empty", name, email)
+	}
+
+	return attributes
+}
+
+// parseAttributeSet reads a comma-separated list of CSV-filled attributes,
+// as used by CLEAR_ON_EMPTY and IMMUTABLE_ATTRIBUTES. filled lists the
+// attributes allowed, see csvFilledAttributes.
+func parseAttributeSet(value string, filled []string) (map[string]bool, error) {
+	names := make(map[string]bool)
+	if strings.TrimSpace(value) == "" {
+		return names, nil
+	}
+
+	known := make(map[string]bool, len(filled))
+	for _, name := range filled {
+		known[name] = true
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		name := strings.ToUpper(strings.TrimSpace(entry))
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("'%s' is not filled from the CSV (use %s)", name, strings.Join(filled, ", "))
+		}
+		names[name] = true
+	}
+
+	return names, nil
+}
@@ -0,0 +1,53 @@
+package brevo
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseAttributeSet(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		contactPerson string
+		want          map[string]bool
+		wantErr       bool
+	}{
+		{name: "empty", value: "", want: map[string]bool{}},
+		{name: "csv attributes", value: " sms, company_name ", want: map[string]bool{"SMS": true, "COMPANY_NAME": true}},
+		{name: "contact person attribute", value: "CONTACT_PERSON", contactPerson: "CONTACT_PERSON", want: map[string]bool{"CONTACT_PERSON": true}},
+		{name: "contact person attribute not configured", value: "CONTACT_PERSON", wantErr: true},
+		{name: "unknown attribute", value: "FIRSTNAME", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAttributeSet(tt.value, csvFilledAttributes(tt.contactPerson))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAttributeSet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("parseAttributeSet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContactPersonAttributeRules(t *testing.T) {
+	b := &BrevoService{config: Config{
+		ContactPerson:       ContactPersonConfig{Attribute: "CONTACT_PERSON"},
+		ClearOnEmpty:        map[string]bool{"CONTACT_PERSON": true},
+		ImmutableAttributes: map[string]bool{"CONTACT_PERSON": true},
+	}}
+
+	cleared := b.applyClearOnEmpty("a@example.com", map[string]any{})
+	if value, ok := cleared["CONTACT_PERSON"]; !ok || value != "" {
+		t.Errorf("applyClearOnEmpty() = %v, want CONTACT_PERSON cleared", cleared)
+	}
+
+	attributes := map[string]any{"CONTACT_PERSON": "Ana"}
+	preserved := b.applyImmutable("a@example.com", attributes, map[string]any{"CONTACT_PERSON": "Nino"}, true)
+	if _, ok := attributes["CONTACT_PERSON"]; ok || len(preserved) != 1 {
+		t.Errorf("applyImmutable() kept %v, preserved %v, want CONTACT_PERSON preserved", attributes, preserved)
+	}
+}
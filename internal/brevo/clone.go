@@ -0,0 +1,117 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+type campaignDetails struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Subject     string `json:"subject"`
+	Status      string `json:"status"`
+	HTMLContent string `json:"htmlContent"`
+	Sender      Sender `json:"sender"`
+}
+
+func (b *BrevoService) getCampaign(campaignID int) (campaignDetails, error) {
+	url := fmt.Sprintf("https://api.brevo.com/v3/emailCampaigns/%d", campaignID)
+
+	resp, err := b.makeAPIRequest(opCampaign, "GET", url, nil)
+	if err != nil {
+		return campaignDetails{}, fmt.Errorf("error fetching campaign %d: %w", campaignID, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return campaignDetails{}, fmt.Errorf("failed to read campaign %d response body: %w", campaignID, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return campaignDetails{}, fmt.Errorf("campaign %d not found", campaignID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return campaignDetails{}, fmt.Errorf("failed to fetch campaign %d: status %d - %s", campaignID, resp.StatusCode, string(body))
+	}
+
+	var campaign campaignDetails
+	if err := json.Unmarshal(body, &campaign); err != nil {
+		return campaignDetails{}, fmt.Errorf("failed to decode campaign %d response: %w", campaignID, err)
+	}
+
+	return campaign, nil
+}
+
+// CloneCampaign creates a copy of a campaign designed in the Brevo editor,
+// keeping its content and sender but sending it to listID under a name
+// rendered from cfg. A non-empty cfg.Subject replaces the source subject.
+func (b *BrevoService) CloneCampaign(sourceID int, listID int, cfg CampaignConfig) CampaignResult {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	source, err := b.getCampaign(sourceID)
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to load source campaign: %v", err),
+			StatusCode: 0,
+		}
+	}
+
+	if source.HTMLContent == "" {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Source campaign %d has no HTML content to clone", sourceID),
+			StatusCode: 0,
+		}
+	}
+
+	name, err := cfg.RenderName(CampaignMeta{CSVName: source.Name})
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to render campaign name: %v", err),
+			StatusCode: 0,
+		}
+	}
+
+	subject := cfg.Subject
+	if subject == "" {
+		subject = source.Subject
+	}
+
+	sender := source.Sender
+	if sender.Email == "" {
+		sender = Sender{Name: b.config.SenderName, Email: b.config.SenderEmail}
+	}
+
+	payload := CampaignPayload{
+		Sender: map[string]string{
+			"name":  sender.Name,
+			"email": sender.Email,
+		},
+		Name:                  name,
+		Subject:               subject,
+		HTMLContent:           source.HTMLContent,
+		Recipients:            map[string][]int{"listIds": {listID}},
+		AttachmentURL:         cfg.AttachmentURL,
+		InlineImageActivation: cfg.InlineImageActivation,
+	}
+
+	tags := campaignTags(cfg, CampaignMeta{Source: fmt.Sprintf("campaign-%d", sourceID)})
+	payload.Tag = campaignTag(tags)
+
+	log.Printf("Cloning campaign %d ('%s') as '%s' for list %d", sourceID, source.Name, name, listID)
+
+	result, _ := b.postCampaignWithRetry(payload)
+	result.SenderEmail = sender.Email
+	if result.Success {
+		result.Tags = tags
+	}
+	return result
+}
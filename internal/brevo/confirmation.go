@@ -0,0 +1,71 @@
+package brevo
+
+import "log"
+
+// PendingConfirmation is returned instead of sending when a campaign would
+// reach more than ProcessOptions.ConfirmationThreshold recipients. The
+// campaign already exists in Brevo; ConfirmAndSend sends it.
+type PendingConfirmation struct {
+	CampaignID int `json:"campaign_id"`
+	Recipients int `json:"recipients"`
+	Threshold  int `json:"threshold"`
+}
+
+// pendingConfirmation reports whether the campaign to listID has to wait for
+// ConfirmAndSend.
+func (b *BrevoService) pendingConfirmation(opts ProcessOptions, campaignID int, listID int, runCount int) *PendingConfirmation {
+	if opts.ConfirmationThreshold <= 0 {
+		return nil
+	}
+
+	count := b.recipientCount(listID, runCount)
+	if count <= opts.ConfirmationThreshold {
+		return nil
+	}
+
+	return &PendingConfirmation{
+		CampaignID: campaignID,
+		Recipients: count,
+		Threshold:  opts.ConfirmationThreshold,
+	}
+}
+
+// ConfirmAndSend sends a campaign that a run left pending confirmation. A
+// cumulative campaign's list is cleared once it is sent when ClearAfterSend
+// is set.
+func (b *BrevoService) ConfirmAndSend(campaignID int) SendCampaignResult {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	log.Printf("Send of campaign %d confirmed", campaignID)
+
+	result := b.SendCampaignToContacts(campaignID)
+	if result.Success {
+		b.clearConfirmedCumulativeList(campaignID)
+	}
+	return result
+}
+
+// ResumeSend retries the send of a campaign a run created but failed to
+// send, without importing contacts or creating a new campaign. Each attempt
+// checks the campaign's status first, so a campaign Brevo already reports as
+// sent is never sent again.
+func (b *BrevoService) ResumeSend(campaignID int) SendCampaignResult {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	log.Printf("Resuming send of campaign %d", campaignID)
+
+	result := b.SendCampaignToContacts(campaignID)
+	for attempt := 1; !result.Success && isRetryableStatus(result.StatusCode) && attempt < b.config.Retry.MaxAttempts; attempt++ {
+		log.Printf("Send attempt %d for campaign %d failed (%s). Retrying in %s...", attempt, campaignID, result.Error, b.config.Retry.backoff(attempt))
+
+		if !b.wait(attempt) {
+			break
+		}
+
+		result = b.SendCampaignToContacts(campaignID)
+	}
+
+	return result
+}
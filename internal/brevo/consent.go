@@ -0,0 +1,98 @@
+package brevo
+
+import (
+	"log"
+	"strings"
+	"time"
+)
+
+// DefaultConsentAttribute is the Brevo date attribute the opt-in date goes to.
+const DefaultConsentAttribute string = "OPT_IN_DATE"
+
+// consentDateLayouts are tried in order when CONSENT_DATE_LAYOUT is not set
+// or does not match.
+var consentDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"02/01/2006",
+	"02.01.2006",
+}
+
+// ConsentConfig records when each contact opted in, for the GDPR audit trail.
+type ConsentConfig struct {
+	// Column names the CSV column holding the opt-in date: one of the export
+	// columns, or any extra header when the header decoder is used.
+	Column string
+	// Attribute is the Brevo date attribute; empty uses
+	// DefaultConsentAttribute.
+	Attribute string
+	// Layout is tried before the built-in layouts, in Go time layout form.
+	Layout string
+	// DefaultToImportDate uses the import date for new contacts whose CSV
+	// has no consent date. Existing contacts keep the date Brevo holds.
+	DefaultToImportDate bool
+}
+
+func (c ConsentConfig) enabled() bool {
+	return c.Column != "" || c.DefaultToImportDate
+}
+
+func (c ConsentConfig) attribute() string {
+	if c.Attribute == "" {
+		return DefaultConsentAttribute
+	}
+	return c.Attribute
+}
+
+// value returns the raw consent date of data from Column.
+func (c ConsentConfig) value(data *CSVData) string {
+	column := normalizeHeaderName(c.Column)
+	for i, name := range csvColumns {
+		if name == column {
+			return strings.TrimSpace(*data.columnFields()[i])
+		}
+	}
+	return strings.TrimSpace(data.Extra[column])
+}
+
+// parse reads value with Layout and then the built-in layouts.
+func (c ConsentConfig) parse(value string) (time.Time, bool) {
+	layouts := consentDateLayouts
+	if c.Layout != "" {
+		layouts = append([]string{c.Layout}, layouts...)
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// addConsentDate sets the consent attribute from the CSV, or to the import
+// date for a new contact when configured. Unparseable dates are skipped with
+// a warning rather than replaced by the default.
+func (b *BrevoService) addConsentDate(attributes map[string]any, data *CSVData, isNew bool) {
+	cfg := b.config.Consent
+	if !cfg.enabled() || data == nil {
+		return
+	}
+
+	var date time.Time
+	if value := cfg.value(data); value != "" {
+		parsed, ok := cfg.parse(value)
+		if !ok {
+			log.Printf("Warning: Unparseable consent date '%s' for %s. Skipping %s.", value, data.Email, cfg.attribute())
+			return
+		}
+		date = parsed
+	} else if cfg.DefaultToImportDate && isNew {
+		date = b.now()
+	} else {
+		return
+	}
+
+	attributes[cfg.attribute()] = date.Format("2006-01-02")
+}
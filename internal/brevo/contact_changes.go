@@ -0,0 +1,76 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// AttributeChange is one attribute's value before and after an update.
+// Old is nil when the attribute was not set before.
+type AttributeChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// contactOutcome is what addContact reports besides the response.
+type contactOutcome struct {
+	smsConflict SMSConflictStrategy
+	changes     map[string]AttributeChange
+	truncations []AttributeTruncation
+	preserved   []string
+	contactID   int
+}
+
+// getContactAttributes returns the current attributes of email, or nil when
+// Brevo has no such contact.
+func (b *BrevoService) getContactAttributes(email string) (map[string]any, error) {
+	contactURL := fmt.Sprintf("https://api.brevo.com/v3/contacts/%s", url.PathEscape(email))
+
+	resp, err := b.makeAPIRequest(opFetch, "GET", contactURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching contact %s: %w", email, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contact %s response body: %w", email, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch contact %s: status %d - %s", email, resp.StatusCode, string(body))
+	}
+
+	var contact BrevoContact
+	if err := json.Unmarshal(body, &contact); err != nil {
+		return nil, fmt.Errorf("failed to decode contact %s response: %w", email, err)
+	}
+
+	b.contactIDs.set(email, contact.ID)
+	return contact.Attributes, nil
+}
+
+// diffAttributes lists the sent attributes whose value differs from the one
+// Brevo held. Values are compared by their printed form because Brevo decodes
+// numbers as float64 while the payload carries them as strings.
+func diffAttributes(before map[string]any, sent map[string]any) map[string]AttributeChange {
+	changes := make(map[string]AttributeChange)
+
+	for key, newValue := range sent {
+		oldValue, exists := before[key]
+		if exists && fmt.Sprint(oldValue) == fmt.Sprint(newValue) {
+			continue
+		}
+
+		changes[key] = AttributeChange{Old: oldValue, New: newValue}
+	}
+
+	return changes
+}
@@ -0,0 +1,122 @@
+package brevo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDiffAttributes(t *testing.T) {
+	tests := []struct {
+		name   string
+		before map[string]any
+		sent   map[string]any
+		want   map[string]AttributeChange
+	}{
+		{
+			name:   "changed company name",
+			before: map[string]any{"COMPANY_NAME": "Old Ltd", "CITY": "Tbilisi"},
+			sent:   map[string]any{"COMPANY_NAME": "New Ltd", "CITY": "Tbilisi"},
+			want:   map[string]AttributeChange{"COMPANY_NAME": {Old: "Old Ltd", New: "New Ltd"}},
+		},
+		{
+			name:   "number decoded as float64 equals its string form",
+			before: map[string]any{"COMPANY_ID": float64(404123)},
+			sent:   map[string]any{"COMPANY_ID": "404123"},
+			want:   map[string]AttributeChange{},
+		},
+		{
+			name:   "newly set attribute has no old value",
+			before: map[string]any{},
+			sent:   map[string]any{"SMS": "+995555000000"},
+			want:   map[string]AttributeChange{"SMS": {Old: nil, New: "+995555000000"}},
+		},
+		{
+			name:   "attributes not sent are not reported",
+			before: map[string]any{"COMPANY_NAME": "Old Ltd", "LASTNAME": "Beridze"},
+			sent:   map[string]any{"COMPANY_NAME": "Old Ltd"},
+			want:   map[string]AttributeChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffAttributes(tt.before, tt.sent); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportContactRecordsChanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		existing    bool
+		wantUpdated bool
+		wantChanges map[string]AttributeChange
+		wantFetches int
+	}{
+		{
+			name:        "updated contact records the diff",
+			status:      http.StatusNoContent,
+			existing:    true,
+			wantUpdated: true,
+			wantChanges: map[string]AttributeChange{"COMPANY_NAME": {Old: "Old Ltd", New: "New Ltd"}},
+			wantFetches: 1,
+		},
+		{
+			name:   "new contact is not diffed",
+			status: http.StatusCreated,
+		},
+		{
+			name:        "contact believed existing but created has no changes",
+			status:      http.StatusCreated,
+			existing:    true,
+			wantFetches: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetches := 0
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method + " " + r.URL.Path {
+				case "GET /v3/contacts/a@example.com":
+					fetches++
+					fmt.Fprint(w, `{"id":3,"email":"a@example.com","attributes":{"COMPANY_NAME":"Old Ltd","COMPANY_ID":404123}}`)
+				case "POST /v3/contacts":
+					w.WriteHeader(tt.status)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			}))
+			service.config.TrackContactChanges = true
+
+			var results ProcessingResults
+			existing := map[string]bool{"a@example.com": tt.existing}
+			data := &CSVData{Email: "a@example.com", VendorName: "New Ltd", IdCode: "404123"}
+
+			if failed := service.importContact(2, data, existing, []int{10}, &results); failed >= 0 {
+				t.Fatalf("importContact() failed: %+v", results.Errors[failed])
+			}
+
+			if fetches != tt.wantFetches {
+				t.Errorf("attribute fetches = %d, want %d", fetches, tt.wantFetches)
+			}
+			if !tt.wantUpdated {
+				if len(results.AddedToCampaign) != 1 || results.AddedToCampaign[0].Changes != nil {
+					t.Errorf("added = %+v, want one contact without changes", results.AddedToCampaign)
+				}
+				return
+			}
+			if len(results.UpdatedContacts) != 1 {
+				t.Fatalf("updated = %d contacts, want 1", len(results.UpdatedContacts))
+			}
+			if got := results.UpdatedContacts[0].Changes; !reflect.DeepEqual(got, tt.wantChanges) {
+				t.Errorf("changes = %v, want %v", got, tt.wantChanges)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// contactIDs remembers the Brevo ID of each contact the service created or
+// fetched, keyed by lowercased email. A nil *contactIDs remembers nothing.
+type contactIDs struct {
+	mu      sync.Mutex
+	byEmail map[string]int
+}
+
+func newContactIDs() *contactIDs {
+	return &contactIDs{byEmail: make(map[string]int)}
+}
+
+func (c *contactIDs) get(email string) int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.byEmail[strings.ToLower(email)]
+}
+
+func (c *contactIDs) set(email string, id int) {
+	if c == nil || id <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byEmail[strings.ToLower(email)] = id
+}
+
+// rememberCreatedID records the ID Brevo returns in the body of a 201
+// create-contact response.
+func (b *BrevoService) rememberCreatedID(email string, statusCode int, body []byte) {
+	if statusCode != http.StatusCreated {
+		return
+	}
+
+	var created struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil || created.ID == 0 {
+		log.Printf("Warning: Could not read the ID of new contact %s from Brevo's response", email)
+		return
+	}
+
+	b.contactIDs.set(email, created.ID)
+}
+
+// updateContactByID updates the contact with Brevo ID id, so the update
+// cannot land on another contact when emails were changed or re-used. A
+// duplicate SMS is resolved like on the create path, with every retry also
+// sent by ID.
+func (b *BrevoService) updateContactByID(id int, email string, payload ContactPayload) (*http.Response, SMSConflictStrategy, error) {
+	send := func(payload ContactPayload) (*http.Response, []byte, error) {
+		return b.putContactByID(id, payload)
+	}
+
+	resp, body, err := send(payload)
+	if err != nil {
+		log.Printf("Exception occurred while updating contact %d (%s): %v", id, email, err)
+		return nil, "", err
+	}
+
+	log.Printf("Brevo API response for contact %d: %d - %s", id, resp.StatusCode, b.logBody(body))
+
+	if b.isDuplicateSMSError(resp, string(body)) {
+		return b.resolveSMSConflict(email, payload, resp, send)
+	}
+
+	if resp.StatusCode == http.StatusNoContent {
+		log.Printf("%s contact %s by ID %d", ContactActionUpdated, email, id)
+	}
+
+	return resp, "", nil
+}
+
+// putContactByID sends payload's attributes and lists to the contact with
+// Brevo ID id, returning the response with its body read.
+func (b *BrevoService) putContactByID(id int, payload ContactPayload) (*http.Response, []byte, error) {
+	contactURL := fmt.Sprintf("https://api.brevo.com/v3/contacts/%d?identifierType=contact_id", id)
+	update := map[string]any{}
+	if len(payload.Attributes) > 0 {
+		update["attributes"] = payload.Attributes
+	}
+	if len(payload.ListIds) > 0 {
+		update["listIds"] = payload.ListIds
+	}
+
+	resp, err := b.makeAPIRequest(opAdd, "PUT", contactURL, update)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	return resp, body, nil
+}
@@ -0,0 +1,118 @@
+package brevo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestUpdateContactByIDResolvesSMSConflict(t *testing.T) {
+	tests := []struct {
+		strategy     SMSConflictStrategy
+		wantStatus   int
+		wantRequests []string
+	}{
+		{
+			strategy:     SMSConflictDropSMS,
+			wantStatus:   http.StatusNoContent,
+			wantRequests: []string{"PUT /v3/contacts/7 SMS", "PUT /v3/contacts/7"},
+		},
+		{
+			strategy:     SMSConflictSkip,
+			wantStatus:   http.StatusBadRequest,
+			wantRequests: []string{"PUT /v3/contacts/7 SMS"},
+		},
+		{
+			strategy:     SMSConflictForceClear,
+			wantStatus:   http.StatusNoContent,
+			wantRequests: []string{"PUT /v3/contacts/7 SMS", "PUT /v3/contacts/+995555", "PUT /v3/contacts/7 SMS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []string
+			cleared := false
+
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				var payload struct {
+					Attributes map[string]any `json:"attributes"`
+				}
+				_ = json.Unmarshal(body, &payload)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				request := r.Method + " " + r.URL.Path
+				if sms, ok := payload.Attributes["SMS"]; ok && sms != "" {
+					request += " SMS"
+				}
+				requests = append(requests, request)
+
+				switch {
+				case strings.HasPrefix(r.URL.Path, "/v3/contacts/+"):
+					cleared = true
+					w.WriteHeader(http.StatusNoContent)
+				case strings.HasSuffix(request, " SMS") && !cleared:
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"code":"duplicate_parameter","message":"SMS is already associated with another Contact"}`))
+				default:
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			service.config.SMSConflictStrategy = tt.strategy
+
+			var open atomic.Int64
+			forward := service.httpClient.Transport
+			service.httpClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				resp, err := forward.RoundTrip(r)
+				if err == nil {
+					open.Add(1)
+					resp.Body = closeCounter{ReadCloser: resp.Body, open: &open}
+				}
+				return resp, err
+			})
+
+			payload := ContactPayload{
+				Email:      "a@example.com",
+				Attributes: map[string]any{"SMS": "+995555", "FIRSTNAME": "Ana"},
+				ListIds:    []int{10},
+			}
+			resp, strategy, err := service.updateContactByID(7, "a@example.com", payload)
+			if err != nil {
+				t.Fatalf("updateContactByID() error = %v", err)
+			}
+			if strategy != tt.strategy {
+				t.Errorf("updateContactByID() strategy = %q, want %q", strategy, tt.strategy)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("updateContactByID() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if strings.Join(requests, ", ") != strings.Join(tt.wantRequests, ", ") {
+				t.Errorf("requests = %v, want %v", requests, tt.wantRequests)
+			}
+
+			resp.Body.Close()
+			if got := open.Load(); got != 0 {
+				t.Errorf("%d response bodies left open", got)
+			}
+		})
+	}
+}
+
+// closeCounter decrements open when the body it wraps is closed.
+type closeCounter struct {
+	io.ReadCloser
+	open *atomic.Int64
+}
+
+func (c closeCounter) Close() error {
+	c.open.Add(-1)
+	return c.ReadCloser.Close()
+}
@@ -0,0 +1,80 @@
+package brevo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContactNames decides what happens when the Contacts column packs several
+// names into one cell.
+type ContactNames string
+
+const (
+	// ContactNamesFirst keeps the first name only, for greetings.
+	ContactNamesFirst ContactNames = "first"
+	// ContactNamesJoin keeps every name, separated by ", ".
+	ContactNamesJoin ContactNames = "join"
+)
+
+// contactNameSentinels are placeholders exports put in the Contacts column
+// when there is no contact person.
+var contactNameSentinels = map[string]bool{
+	"-":    true,
+	"n/a":  true,
+	"na":   true,
+	"none": true,
+	"null": true,
+}
+
+// ContactPersonConfig maps the CSV Contacts column, the vendor's contact
+// person, to a Brevo attribute.
+type ContactPersonConfig struct {
+	// Attribute receives the name, e.g. CONTACT_PERSON; empty disables it.
+	Attribute string
+	// Names handles cells with several names; empty uses
+	// ContactNamesFirst.
+	Names ContactNames
+}
+
+func parseContactNames(value string) (ContactNames, error) {
+	switch names := ContactNames(strings.ToLower(strings.TrimSpace(value))); names {
+	case "":
+		return ContactNamesFirst, nil
+	case ContactNamesFirst, ContactNamesJoin:
+		return names, nil
+	default:
+		return "", fmt.Errorf("unknown contact names mode '%s' (use first or join)", value)
+	}
+}
+
+// contactPersonName returns the contact name to store for value, or "" when
+// it holds no real name.
+func (c ContactPersonConfig) contactPersonName(value string) string {
+	var names []string
+	for _, name := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		name = strings.Join(strings.Fields(name), " ")
+		if name != "" && !contactNameSentinels[strings.ToLower(name)] {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return ""
+	}
+	if c.Names == ContactNamesJoin {
+		return strings.Join(names, ", ")
+	}
+	return names[0]
+}
+
+// addContactPerson stores the contact's contact person under the
+// configured attribute when there is one.
+func (b *BrevoService) addContactPerson(attributes map[string]any, contactData *CSVData) {
+	if b.config.ContactPerson.Attribute == "" {
+		return
+	}
+
+	if name := b.config.ContactPerson.contactPersonName(contactData.Contacts); name != "" {
+		attributes[b.config.ContactPerson.Attribute] = name
+	}
+}
@@ -0,0 +1,30 @@
+package brevo
+
+import "testing"
+
+func TestContactPersonName(t *testing.T) {
+	tests := []struct {
+		name  string
+		names ContactNames
+		value string
+		want  string
+	}{
+		{name: "single name is trimmed", value: "  Nino   Beridze ", want: "Nino Beridze"},
+		{name: "first of several", names: ContactNamesFirst, value: "Nino Beridze, Giorgi Kapanadze", want: "Nino Beridze"},
+		{name: "join several", names: ContactNamesJoin, value: "Nino Beridze; Giorgi Kapanadze,Ana", want: "Nino Beridze, Giorgi Kapanadze, Ana"},
+		{name: "sentinels are skipped", names: ContactNamesJoin, value: "N/A, -, Ana", want: "Ana"},
+		{name: "leading sentinel falls through to the first name", value: "none, Ana", want: "Ana"},
+		{name: "only sentinels", value: "null; -", want: ""},
+		{name: "Georgian names", names: ContactNamesJoin, value: "ნინო ბერიძე, გიორგი", want: "ნინო ბერიძე, გიორგი"},
+		{name: "empty", value: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := ContactPersonConfig{Attribute: "CONTACT_PERSON", Names: tt.names}
+			if got := c.contactPersonName(tt.value); got != tt.want {
+				t.Errorf("contactPersonName(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
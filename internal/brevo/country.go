@@ -0,0 +1,60 @@
+package brevo
+
+import (
+	"strings"
+)
+
+// countryCodes maps normalized country names, aliases and ISO codes to
+// ISO 3166-1 alpha-2. It covers the countries that appear in our exports;
+// extend it as new ones show up.
+var countryCodes = map[string]string{
+	"georgia": "GE", "საქართველო": "GE", "geo": "GE", "ge": "GE",
+	"armenia": "AM", "სომხეთი": "AM", "arm": "AM", "am": "AM",
+	"azerbaijan": "AZ", "აზერბაიჯანი": "AZ", "aze": "AZ", "az": "AZ",
+	"turkey": "TR", "türkiye": "TR", "turkiye": "TR", "თურქეთი": "TR", "tur": "TR", "tr": "TR",
+	"russia": "RU", "russian federation": "RU", "რუსეთი": "RU", "rus": "RU", "ru": "RU",
+	"ukraine": "UA", "უკრაინა": "UA", "ukr": "UA", "ua": "UA",
+	"belarus": "BY", "blr": "BY", "by": "BY",
+	"kazakhstan": "KZ", "ყაზახეთი": "KZ", "kaz": "KZ", "kz": "KZ",
+	"iran": "IR", "ირანი": "IR", "irn": "IR", "ir": "IR",
+	"germany": "DE", "გერმანია": "DE", "deu": "DE", "de": "DE",
+	"france": "FR", "საფრანგეთი": "FR", "fra": "FR", "fr": "FR",
+	"italy": "IT", "იტალია": "IT", "ita": "IT", "it": "IT",
+	"spain": "ES", "ესპანეთი": "ES", "esp": "ES", "es": "ES",
+	"netherlands": "NL", "the netherlands": "NL", "holland": "NL", "ნიდერლანდები": "NL", "nld": "NL", "nl": "NL",
+	"belgium": "BE", "bel": "BE", "be": "BE",
+	"austria": "AT", "aut": "AT", "at": "AT",
+	"switzerland": "CH", "შვეიცარია": "CH", "che": "CH", "ch": "CH",
+	"poland": "PL", "პოლონეთი": "PL", "pol": "PL", "pl": "PL",
+	"czech republic": "CZ", "czechia": "CZ", "cze": "CZ", "cz": "CZ",
+	"romania": "RO", "რუმინეთი": "RO", "rou": "RO", "ro": "RO",
+	"bulgaria": "BG", "ბულგარეთი": "BG", "bgr": "BG", "bg": "BG",
+	"greece": "GR", "საბერძნეთი": "GR", "grc": "GR", "gr": "GR",
+	"lithuania": "LT", "ltu": "LT", "lt": "LT",
+	"latvia": "LV", "lva": "LV", "lv": "LV",
+	"estonia": "EE", "est": "EE", "ee": "EE",
+	"sweden": "SE", "swe": "SE", "se": "SE",
+	"norway": "NO", "nor": "NO", "no": "NO",
+	"denmark": "DK", "dnk": "DK", "dk": "DK",
+	"finland": "FI", "fin": "FI", "fi": "FI",
+	"united kingdom": "GB", "uk": "GB", "great britain": "GB", "england": "GB", "დიდი ბრიტანეთი": "GB", "gbr": "GB", "gb": "GB",
+	"ireland": "IE", "irl": "IE", "ie": "IE",
+	"united states": "US", "united states of america": "US", "usa": "US", "us": "US", "აშშ": "US",
+	"canada": "CA", "can": "CA", "ca": "CA",
+	"china": "CN", "ჩინეთი": "CN", "chn": "CN", "cn": "CN",
+	"india": "IN", "ind": "IN", "in": "IN",
+	"japan": "JP", "jpn": "JP", "jp": "JP",
+	"south korea": "KR", "korea": "KR", "kor": "KR", "kr": "KR",
+	"israel": "IL", "isr": "IL", "il": "IL",
+	"united arab emirates": "AE", "uae": "AE", "are": "AE", "ae": "AE",
+}
+
+// normalizeCountry resolves free-text country input to an ISO 3166-1
+// alpha-2 code. It reports false when the value is not recognised.
+func normalizeCountry(value string) (string, bool) {
+	key := strings.ToLower(strings.Join(strings.Fields(value), " "))
+	key = strings.Trim(key, ".")
+
+	code, ok := countryCodes[key]
+	return code, ok
+}
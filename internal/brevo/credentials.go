@@ -0,0 +1,92 @@
+package brevo
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// APIKeyProvider returns the current Brevo API key from wherever secrets
+// are kept. It is consulted when Brevo answers 401.
+type APIKeyProvider func() (string, error)
+
+// credentials holds the API key behind an atomic pointer so each request
+// reads one consistent key while a rotation swaps it underneath.
+type credentials struct {
+	key      atomic.Pointer[string]
+	mu       sync.Mutex
+	provider APIKeyProvider
+}
+
+func newCredentials(apiKey string) *credentials {
+	c := &credentials{}
+	c.key.Store(&apiKey)
+	return c
+}
+
+func (c *credentials) get() string {
+	return *c.key.Load()
+}
+
+func (c *credentials) set(apiKey string) {
+	c.key.Store(&apiKey)
+}
+
+func (c *credentials) canRefresh() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.provider != nil
+}
+
+// refresh re-reads the key from the provider unless another request has
+// already rotated it away from staleKey. It reports whether a newer key is
+// now in place.
+func (c *credentials) refresh(staleKey string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.get() != staleKey {
+		return true, nil
+	}
+
+	if c.provider == nil {
+		return false, nil
+	}
+
+	key, err := c.provider()
+	if err != nil {
+		return false, err
+	}
+
+	if key == "" {
+		return false, fmt.Errorf("API key provider returned an empty key")
+	}
+
+	if key == staleKey {
+		return false, nil
+	}
+
+	c.set(key)
+	return true, nil
+}
+
+// SetAPIKey swaps the API key used for subsequent requests. It is safe to
+// call while other requests are in flight.
+func (b *BrevoService) SetAPIKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("API key must not be empty")
+	}
+
+	b.credentials.set(key)
+	return nil
+}
+
+// SetAPIKeyProvider registers a provider that is asked for a fresh key when
+// Brevo rejects the current one with 401.
+func (b *BrevoService) SetAPIKeyProvider(provider APIKeyProvider) {
+	b.credentials.mu.Lock()
+	defer b.credentials.mu.Unlock()
+
+	b.credentials.provider = provider
+}
@@ -0,0 +1,132 @@
+package brevo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestSetAPIKeyDuringConcurrentRequests(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string]int)
+
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.Header.Get("api-key")]++
+		mu.Unlock()
+		fmt.Fprint(w, `{"id":7,"status":"draft"}`)
+	}))
+
+	keys := []string{"test-key", "key-1", "key-2", "key-3"}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				if _, err := service.GetCampaignStatus(7); err != nil {
+					t.Errorf("GetCampaignStatus() error = %v", err)
+				}
+			}
+		}()
+	}
+	for _, key := range keys[1:] {
+		if err := service.SetAPIKey(key); err != nil {
+			t.Errorf("SetAPIKey(%q) error = %v", key, err)
+		}
+	}
+	wg.Wait()
+
+	total := 0
+	for key, count := range seen {
+		valid := false
+		for _, want := range keys {
+			valid = valid || key == want
+		}
+		if !valid {
+			t.Errorf("request sent with key %q, which was never set", key)
+		}
+		total += count
+	}
+	if total != 80 {
+		t.Errorf("server saw %d requests, want 80", total)
+	}
+	if got := service.credentials.get(); got != "key-3" {
+		t.Errorf("current key = %q, want key-3", got)
+	}
+}
+
+func TestSetAPIKeyRejectsEmptyKey(t *testing.T) {
+	service, _ := newTestService(t, http.NotFoundHandler())
+
+	if err := service.SetAPIKey(""); err == nil {
+		t.Errorf("SetAPIKey(\"\") succeeded, want an error")
+	}
+	if got := service.credentials.get(); got != "test-key" {
+		t.Errorf("current key = %q, want the original key", got)
+	}
+}
+
+func TestAPIKeyRefreshOn401(t *testing.T) {
+	tests := []struct {
+		name       string
+		provider   APIKeyProvider
+		wantStatus string
+		wantErr    bool
+		wantKey    string
+	}{
+		{
+			name:       "rotated key is retried",
+			provider:   func() (string, error) { return "rotated-key", nil },
+			wantStatus: "draft",
+			wantKey:    "rotated-key",
+		},
+		{
+			name:     "provider returns the same key",
+			provider: func() (string, error) { return "test-key", nil },
+			wantErr:  true,
+			wantKey:  "test-key",
+		},
+		{
+			name:     "provider fails",
+			provider: func() (string, error) { return "", errors.New("secret store unavailable") },
+			wantErr:  true,
+			wantKey:  "test-key",
+		},
+		{
+			name:    "no provider",
+			wantErr: true,
+			wantKey: "test-key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("api-key") != "rotated-key" {
+					http.Error(w, `{"code":"unauthorized"}`, http.StatusUnauthorized)
+					return
+				}
+				fmt.Fprint(w, `{"id":7,"status":"draft"}`)
+			}))
+			if tt.provider != nil {
+				service.SetAPIKeyProvider(tt.provider)
+			}
+
+			status, err := service.GetCampaignStatus(7)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetCampaignStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", status, tt.wantStatus)
+			}
+			if got := service.credentials.get(); got != tt.wantKey {
+				t.Errorf("current key = %q, want %q", got, tt.wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package brevo
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CSVOptions controls how raw CSV values are cleaned up before mapping.
+type CSVOptions struct {
+	// TrimSpace strips leading and trailing whitespace from every field,
+	// so " john@x.com " dedups against "john@x.com".
+	TrimSpace bool
+	// CollapseNameSpaces turns runs of internal whitespace in VendorName
+	// and Contacts into a single space.
+	CollapseNameSpaces bool
+	// LazyQuotes accepts stray quotes inside unquoted fields, such as
+	// `Tbilisi, 5 "Rustaveli" Ave`. Quoted multi-line fields parse either
+	// way. An unclosed quote is no longer an error in this mode and swallows
+	// the rest of the file into one field, so only enable it for exports
+	// known to contain bare quotes.
+	LazyQuotes bool
+	// Comment, when set, skips lines starting with it, such as the
+	// metadata lines some exports put before the header. Zero reads every
+	// line as a record.
+	Comment rune
+}
+
+// parseCSVComment reads a comment prefix, which must be a single character.
+func parseCSVComment(value string) (rune, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	runes := []rune(value)
+	if len(runes) != 1 {
+		return 0, fmt.Errorf("comment prefix '%s' must be a single character", value)
+	}
+
+	return runes[0], nil
+}
+
+// validate rejects options the CSV reader cannot work with.
+func (o CSVOptions) validate() error {
+	switch o.Comment {
+	case 0:
+		return nil
+	case ',':
+		return fmt.Errorf("comment prefix cannot be the ',' delimiter")
+	case '"':
+		return fmt.Errorf("comment prefix cannot be the '\"' quote character")
+	case '\r', '\n', utf8.RuneError:
+		return fmt.Errorf("comment prefix %q is not a valid character", o.Comment)
+	}
+
+	if o.TrimSpace && unicode.IsSpace(o.Comment) {
+		return fmt.Errorf("a whitespace comment prefix cannot be combined with trimming spaces")
+	}
+
+	return nil
+}
+
+func DefaultCSVOptions() CSVOptions {
+	return CSVOptions{
+		TrimSpace: true,
+	}
+}
+
+// normalize cleans data in place according to the options. Emails are
+// always lowercased so they match the keys of the existing-contacts map.
+func (o CSVOptions) normalize(data *CSVData) {
+	if o.TrimSpace {
+		fields := []*string{
+			&data.NAT, &data.STOP, &data.CATEGORY, &data.ID, &data.Contacts,
+			&data.Email, &data.Website, &data.VendorName, &data.Address,
+			&data.IdCode, &data.Phone, &data.Fax, &data.City, &data.Country,
+		}
+		for _, field := range fields {
+			*field = strings.TrimSpace(*field)
+		}
+	}
+
+	if o.CollapseNameSpaces {
+		data.VendorName = strings.Join(strings.Fields(data.VendorName), " ")
+		data.Contacts = strings.Join(strings.Fields(data.Contacts), " ")
+	}
+
+	data.Email = strings.ToLower(data.Email)
+}
+
+// readCSVRecords reads every record from r, turning quoting and column-count
+// failures into errors that point at the offending row.
+func readCSVRecords(r io.Reader, opts CSVOptions) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.LazyQuotes = opts.LazyQuotes
+	reader.Comment = opts.Comment
+
+	records, err := reader.ReadAll()
+	if err == nil {
+		return records, nil
+	}
+
+	var parseErr *csv.ParseError
+	if !errors.As(err, &parseErr) {
+		return nil, err
+	}
+
+	switch {
+	case errors.Is(parseErr.Err, csv.ErrQuote), errors.Is(parseErr.Err, csv.ErrBareQuote):
+		return nil, fmt.Errorf("unbalanced quote in record starting on line %d (line %d, column %d); close the quote or enable lazy quotes for bare quotes: %w",
+			parseErr.StartLine, parseErr.Line, parseErr.Column, err)
+	case errors.Is(parseErr.Err, csv.ErrFieldCount):
+		return nil, fmt.Errorf("record on line %d has a different number of columns than the header, often caused by an unquoted comma or newline: %w",
+			parseErr.StartLine, err)
+	default:
+		return nil, err
+	}
+}
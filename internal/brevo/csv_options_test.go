@@ -0,0 +1,246 @@
+package brevo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapCSVToObjectNormalizesFields(t *testing.T) {
+	header := []string{"email", "vendor_name", "contacts", "city"}
+
+	tests := []struct {
+		name string
+		opts CSVOptions
+		row  []string
+		want CSVData
+	}{
+		{
+			name: "padded fields are trimmed",
+			opts: DefaultCSVOptions(),
+			row:  []string{"  John@X.com ", " Acme  Ltd ", "\tNino  Beridze ", " Tbilisi "},
+			want: CSVData{Email: "john@x.com", VendorName: "Acme  Ltd", Contacts: "Nino  Beridze", City: "Tbilisi"},
+		},
+		{
+			name: "internal spaces collapsed in names",
+			opts: CSVOptions{TrimSpace: true, CollapseNameSpaces: true},
+			row:  []string{"john@x.com", " Acme \t Ltd ", "Nino   Beridze", "Kutaisi  West"},
+			want: CSVData{Email: "john@x.com", VendorName: "Acme Ltd", Contacts: "Nino Beridze", City: "Kutaisi  West"},
+		},
+		{
+			name: "trimming disabled still lowercases emails",
+			opts: CSVOptions{},
+			row:  []string{" John@X.com", " Acme ", "", ""},
+			want: CSVData{Email: " john@x.com", VendorName: " Acme "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := mapCSVToObject([][]string{header, tt.row}, HeaderDecoder{}, tt.opts)
+			if err != nil {
+				t.Fatalf("mapCSVToObject() error = %v", err)
+			}
+
+			got := data[0]
+			got.Extra = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mapCSVToObject() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaddedEmailsDedup(t *testing.T) {
+	service, mock := newRunService(t)
+	mock.existing = []BrevoContact{{Email: "John@X.com"}}
+
+	opts := service.config.Process
+	opts.Decoder = HeaderDecoder{}
+	opts.SkipCampaign = true
+	opts.BulkImport = true
+
+	csvPath := writeCSV(t, "email,vendor_name", "  JOHN@x.com ,Acme", " new@x.com,Beta", "john@X.com  ,Acme Ltd")
+	results, err := service.ProcessCSVAndSendCampaign(csvPath, opts)
+	if err != nil {
+		t.Fatalf("ProcessCSVAndSendCampaign() error = %v", err)
+	}
+
+	if len(results.UpdatedContacts) != 1 || results.UpdatedContacts[0].Email != "john@x.com" || results.UpdatedContacts[0].Row != 4 {
+		t.Errorf("updated = %+v, want the last john@x.com row matched to the existing contact", results.UpdatedContacts)
+	}
+	if len(results.AddedToCampaign) != 1 || results.AddedToCampaign[0].Email != "new@x.com" {
+		t.Errorf("added = %+v, want only new@x.com", results.AddedToCampaign)
+	}
+	if mock.imports != 1 {
+		t.Errorf("imports = %d, want 1", mock.imports)
+	}
+}
+
+func TestReadCSVRecordsQuoting(t *testing.T) {
+	header := strings.Join(csvColumns, ",")
+	row := func(address string) string {
+		return "1,0,IT,42,Nino,nino@example.com,,Acme," + address + ",404123,+995555000000,,Tbilisi,GE"
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		opts        CSVOptions
+		wantAddress string
+		wantErr     string
+	}{
+		{
+			name:        "quoted multi-line address is one field",
+			content:     header + "\n" + row("\"5 Rustaveli Ave\nFloor 3, Room 12\"") + "\n",
+			wantAddress: "5 Rustaveli Ave\nFloor 3, Room 12",
+		},
+		{
+			name:        "quoted multi-line address with lazy quotes",
+			content:     header + "\n" + row("\"5 Rustaveli Ave\nFloor 3\"") + "\n",
+			opts:        CSVOptions{LazyQuotes: true},
+			wantAddress: "5 Rustaveli Ave\nFloor 3",
+		},
+		{
+			name:        "bare quote accepted with lazy quotes",
+			content:     header + "\n" + row(`Tbilisi 5 "Rustaveli" Ave`) + "\n",
+			opts:        CSVOptions{LazyQuotes: true},
+			wantAddress: `Tbilisi 5 "Rustaveli" Ave`,
+		},
+		{
+			name:    "bare quote rejected by default",
+			content: header + "\n" + row(`Tbilisi 5 "Rustaveli" Ave`) + "\n",
+			wantErr: "unbalanced quote in record starting on line 2",
+		},
+		{
+			name:    "unclosed quote",
+			content: header + "\n" + row("\"5 Rustaveli Ave") + "\n" + row("Kutaisi") + "\n",
+			wantErr: "unbalanced quote in record starting on line 2",
+		},
+		{
+			name:    "unquoted comma shifts the columns",
+			content: header + "\n" + row("5 Rustaveli Ave, Floor 3") + "\n",
+			wantErr: "different number of columns than the header",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := readCSVRecords(strings.NewReader(tt.content), tt.opts)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readCSVRecords() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readCSVRecords() error = %v", err)
+			}
+
+			data, err := mapCSVToObject(records, nil, DefaultCSVOptions())
+			if err != nil {
+				t.Fatalf("mapCSVToObject() error = %v", err)
+			}
+			if len(data) != 1 {
+				t.Fatalf("got %d contacts, want 1", len(data))
+			}
+			if data[0].Address != tt.wantAddress || data[0].Email != "nino@example.com" || data[0].Country != "GE" {
+				t.Errorf("contact = %+v, want address %q with the columns after it intact", data[0], tt.wantAddress)
+			}
+		})
+	}
+}
+
+func TestReadCSVRecordsComments(t *testing.T) {
+	header := strings.Join(csvColumns, ",")
+	row := "1,0,IT,42,Nino,nino@example.com,,Acme,5 Rustaveli Ave,404123,+995555000000,,Tbilisi,GE"
+
+	tests := []struct {
+		name     string
+		content  string
+		opts     CSVOptions
+		wantRows int
+		wantErr  string
+	}{
+		{
+			name:     "metadata lines before the header are skipped",
+			content:  "# exported 2026-10-01\n# source: lottery\n" + header + "\n" + row + "\n",
+			opts:     CSVOptions{Comment: '#'},
+			wantRows: 1,
+		},
+		{
+			name:     "comment lines between records are skipped",
+			content:  header + "\n; batch 1\n" + row + "\n",
+			opts:     CSVOptions{Comment: ';'},
+			wantRows: 1,
+		},
+		{
+			name:    "metadata lines are records without a comment prefix",
+			content: "# exported 2026-10-01\n" + header + "\n" + row + "\n",
+			wantErr: "different number of columns than the header",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := readCSVRecords(strings.NewReader(tt.content), tt.opts)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readCSVRecords() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readCSVRecords() error = %v", err)
+			}
+
+			data, err := mapCSVToObject(records, nil, DefaultCSVOptions())
+			if err != nil {
+				t.Fatalf("mapCSVToObject() error = %v", err)
+			}
+			if len(data) != tt.wantRows || data[0].Email != "nino@example.com" {
+				t.Errorf("contacts = %+v, want %d row for nino@example.com", data, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestCSVOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		trim    bool
+		wantErr string
+	}{
+		{name: "no comment prefix", comment: ""},
+		{name: "hash", comment: "#"},
+		{name: "non-ASCII character", comment: "§"},
+		{name: "whitespace without trimming", comment: "\t"},
+		{name: "more than one character", comment: "//", wantErr: "must be a single character"},
+		{name: "delimiter", comment: ",", wantErr: "cannot be the ',' delimiter"},
+		{name: "quote", comment: `"`, wantErr: "quote character"},
+		{name: "newline", comment: "\n", wantErr: "is not a valid character"},
+		{name: "whitespace with trimming", comment: "\t", trim: true, wantErr: "cannot be combined with trimming spaces"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comment, err := parseCSVComment(tt.comment)
+			if err == nil {
+				err = CSVOptions{Comment: comment, TrimSpace: tt.trim}.validate()
+			}
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("error = %v, want none", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package brevo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+const DefaultCumulativeStatePath string = "cumulative_state.json"
+
+// cumulativeSentTimeout bounds the wait for a cumulative campaign to be
+// sent before the list is cleared, when VerifySentTimeout is not set.
+const cumulativeSentTimeout time.Duration = 30 * time.Minute
+
+// CumulativeList accumulates contacts from daily runs into one stable list
+// and sends its campaign once a week. Daily runs only import; the campaign
+// goes out from SendCumulativeCampaign on SendDay.
+type CumulativeList struct {
+	ListName string
+	SendDay  time.Weekday
+	// StatePath remembers the list and the last send so the campaign goes
+	// out once per send day. Empty uses DefaultCumulativeStatePath.
+	StatePath string
+	// ClearAfterSend empties the list once Brevo reports the campaign as
+	// sent, so the next week starts from scratch. A campaign held for
+	// confirmation is cleared after ConfirmAndSend sends it.
+	ClearAfterSend bool
+}
+
+// CumulativeSendResult is the outcome of SendCumulativeCampaign.
+type CumulativeSendResult struct {
+	ListID     int                  `json:"list_id,omitempty"`
+	Recipients int                  `json:"recipients"`
+	Skipped    string               `json:"skipped,omitempty"`
+	Campaign   CampaignResult       `json:"campaign"`
+	Send       SendCampaignResult   `json:"send"`
+	Pending    *PendingConfirmation `json:"pending_confirmation,omitempty"`
+	Cleared    int                  `json:"cleared,omitempty"`
+}
+
+type cumulativeState struct {
+	ListID     int    `json:"list_id"`
+	ListName   string `json:"list_name"`
+	LastSent   string `json:"last_sent,omitempty"`
+	CampaignID int    `json:"campaign_id,omitempty"`
+	// ClearPending is set while the list still holds the recipients of
+	// CampaignID and has to be cleared once that campaign is sent.
+	ClearPending bool `json:"clear_pending,omitempty"`
+}
+
+func (c CumulativeList) statePath() string {
+	if c.StatePath == "" {
+		return DefaultCumulativeStatePath
+	}
+	return c.StatePath
+}
+
+func (c CumulativeList) loadState() (cumulativeState, error) {
+	data, err := os.ReadFile(c.statePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return cumulativeState{}, nil
+	}
+	if err != nil {
+		return cumulativeState{}, fmt.Errorf("failed to read cumulative state '%s': %w", c.statePath(), err)
+	}
+
+	var state cumulativeState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return cumulativeState{}, fmt.Errorf("failed to decode cumulative state '%s': %w", c.statePath(), err)
+	}
+
+	// The list was renamed in the config, so the saved one no longer applies.
+	if state.ListName != c.ListName {
+		return cumulativeState{}, nil
+	}
+
+	return state, nil
+}
+
+func (c CumulativeList) saveState(state cumulativeState) error {
+	state.ListName = c.ListName
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cumulative state: %w", err)
+	}
+
+	if err := os.WriteFile(c.statePath(), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write cumulative state '%s': %w", c.statePath(), err)
+	}
+
+	return nil
+}
+
+// GetOrCreateContactList returns the list called listName in folderName,
+// creating the folder and the list when they do not exist yet.
+func (b *BrevoService) GetOrCreateContactList(listName string, folderName string) (ContactList, error) {
+	folderID, err := b.GetOrCreateFolder(folderName)
+	if err != nil {
+		return ContactList{}, fmt.Errorf("failed to get or create folder for contact lists: %w", err)
+	}
+
+	if folderID <= 0 {
+		return ContactList{}, fmt.Errorf("invalid folder ID %d for contact list creation", folderID)
+	}
+
+	return b.createList(listName, folderID)
+}
+
+// cumulativeList returns the accumulating list and records it in the state
+// file for the weekly send.
+func (b *BrevoService) cumulativeList(c CumulativeList, folderName string) (ContactList, error) {
+	list, err := b.GetOrCreateContactList(c.ListName, folderName)
+	if err != nil {
+		return ContactList{}, err
+	}
+
+	state, err := c.loadState()
+	if err != nil {
+		log.Printf("Warning: %v. Starting a new cumulative state.", err)
+	}
+	if state.ListID != list.ID {
+		state.ListID = list.ID
+		if err := c.saveState(state); err != nil {
+			log.Printf("Warning: Could not record cumulative list %d: %v", list.ID, err)
+		}
+	}
+
+	log.Printf("Accumulating contacts into list '%s' (ID: %d)", list.Name, list.ID)
+	return list, nil
+}
+
+// SendCumulativeCampaign sends the campaign for the cumulative list. Unless
+// force is set it only sends on the configured SendDay, and never more than
+// once per day.
+func (b *BrevoService) SendCumulativeCampaign(force bool) (CumulativeSendResult, error) {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	opts := b.config.Process
+	if opts.Cumulative == nil {
+		return CumulativeSendResult{}, fmt.Errorf("no cumulative list is configured")
+	}
+	c := *opts.Cumulative
+
+	now := time.Now()
+	if !force && now.Weekday() != c.SendDay {
+		return CumulativeSendResult{Skipped: fmt.Sprintf("not the send day (%s)", c.SendDay)}, nil
+	}
+
+	state, err := c.loadState()
+	if err != nil {
+		return CumulativeSendResult{}, err
+	}
+
+	today := now.Format("2006-01-02")
+	if state.LastSent == today {
+		log.Printf("Cumulative campaign %d already sent today. Skipping.", state.CampaignID)
+		return CumulativeSendResult{ListID: state.ListID, Skipped: "already sent today"}, nil
+	}
+
+	if c.ClearAfterSend && state.ClearPending {
+		b.clearSentCumulativeList(c, &state, 0)
+	}
+
+	if state.ListID <= 0 {
+		folderName, err := resolveFolderName(opts.FolderStrategy, FolderMeta{CSVName: c.ListName, Date: now})
+		if err != nil {
+			return CumulativeSendResult{}, fmt.Errorf("failed to resolve folder name: %w", err)
+		}
+		list, err := b.cumulativeList(c, folderName)
+		if err != nil {
+			return CumulativeSendResult{}, fmt.Errorf("failed to find cumulative list '%s': %w", c.ListName, err)
+		}
+		state.ListID = list.ID
+	}
+
+	list, err := b.GetList(state.ListID)
+	if err != nil {
+		return CumulativeSendResult{ListID: state.ListID}, fmt.Errorf("failed to load cumulative list %d: %w", state.ListID, err)
+	}
+
+	result := CumulativeSendResult{ListID: list.ID, Recipients: list.TotalSubscribers}
+
+	if err := b.checkRecipientCap(opts, list.ID, result.Recipients); err != nil {
+		return result, err
+	}
+
+	if skip := b.checkRecipientMinimum(opts, list.ID, result.Recipients); skip != nil {
+		log.Printf("Not sending the cumulative campaign for list %d: %s", list.ID, skip.Reason)
+		result.Skipped = skip.Reason
+		return result, nil
+	}
+
+	result.Campaign = b.CreateNewCampaign(list.ID, b.config.Campaign, CampaignMeta{
+		CSVName: list.Name,
+		Count:   result.Recipients,
+		RunID:   newRunID(now),
+		Source:  "cumulative",
+	})
+	if !result.Campaign.Success {
+		return result, fmt.Errorf("failed to create cumulative campaign: %s", result.Campaign.Error)
+	}
+
+	state.CampaignID = result.Campaign.CampaignID
+
+	if pending := b.pendingConfirmation(opts, result.Campaign.CampaignID, list.ID, result.Recipients); pending != nil {
+		log.Printf("Cumulative campaign %d has %d recipients, above the confirmation threshold of %d. Not sending until ConfirmAndSend is called.",
+			pending.CampaignID, pending.Recipients, pending.Threshold)
+		result.Pending = pending
+	} else {
+		result.Send = b.SendCampaignToContacts(result.Campaign.CampaignID)
+		if !result.Send.Success {
+			return result, fmt.Errorf("cumulative campaign %d was created but not sent (resume with ResumeSend): %s", result.Campaign.CampaignID, result.Send.Error)
+		}
+	}
+
+	state.LastSent = today
+	state.ClearPending = c.ClearAfterSend
+	if err := c.saveState(state); err != nil {
+		log.Printf("Warning: %v. The campaign may be sent again today.", err)
+	}
+
+	if c.ClearAfterSend && result.Pending == nil {
+		timeout := opts.VerifySentTimeout
+		if timeout <= 0 {
+			timeout = cumulativeSentTimeout
+		}
+		result.Cleared = b.clearSentCumulativeList(c, &state, timeout)
+	}
+
+	log.Printf("Cumulative campaign %d for list '%s' done with %d recipients", result.Campaign.CampaignID, list.Name, result.Recipients)
+	return result, nil
+}
+
+// clearSentCumulativeList empties the cumulative list once Brevo reports
+// state's campaign as sent, waiting up to timeout, so a queued send never
+// loses its recipients. It returns how many contacts were removed. A list
+// that could not be cleared stays pending in the state file, for
+// ConfirmAndSend or the next weekly send.
+func (b *BrevoService) clearSentCumulativeList(c CumulativeList, state *cumulativeState, timeout time.Duration) int {
+	status, err := b.PollCampaignStatus(state.CampaignID, CampaignStatusSent, timeout)
+	if err != nil {
+		log.Printf("Warning: Not clearing cumulative list %d yet: campaign %d is '%s': %v", state.ListID, state.CampaignID, status, err)
+		return 0
+	}
+
+	cleared, err := b.ClearList(state.ListID)
+	if err != nil {
+		log.Printf("Warning: Could not clear cumulative list %d after the send: %v", state.ListID, err)
+		return cleared
+	}
+
+	state.ClearPending = false
+	if err := c.saveState(*state); err != nil {
+		log.Printf("Warning: %v. The list may be cleared again.", err)
+	}
+	return cleared
+}
+
+// clearConfirmedCumulativeList clears the cumulative list after
+// ConfirmAndSend sent the campaign it was waiting on.
+func (b *BrevoService) clearConfirmedCumulativeList(campaignID int) {
+	opts := b.config.Process
+	if opts.Cumulative == nil || !opts.Cumulative.ClearAfterSend {
+		return
+	}
+	c := *opts.Cumulative
+
+	state, err := c.loadState()
+	if err != nil {
+		log.Printf("Warning: Could not check whether cumulative list needs clearing: %v", err)
+		return
+	}
+	if !state.ClearPending || state.CampaignID != campaignID {
+		return
+	}
+
+	timeout := opts.VerifySentTimeout
+	if timeout <= 0 {
+		timeout = cumulativeSentTimeout
+	}
+	b.clearSentCumulativeList(c, &state, timeout)
+}
+
+// SendCumulative is the standalone trigger for the weekly send. It sends
+// regardless of the day, but still only once per day.
+func SendCumulative() (CumulativeSendResult, error) {
+	service, err := NewBrevoService()
+	if err != nil {
+		return CumulativeSendResult{}, fmt.Errorf("failed to initialize Brevo service: %w", err)
+	}
+
+	return service.SendCumulativeCampaign(true)
+}
+
+// sendCumulativeIfDue sends the cumulative campaign after a daily run on
+// the send day.
+func (b *BrevoService) sendCumulativeIfDue() {
+	if b.config.Process.Cumulative == nil {
+		return
+	}
+
+	result, err := b.SendCumulativeCampaign(false)
+	if err != nil {
+		log.Printf("Cumulative campaign failed: %v", err)
+		return
+	}
+	if result.Skipped != "" {
+		log.Printf("Cumulative campaign not sent: %s", result.Skipped)
+	}
+}
+
+// parseWeekday reads a day name such as "friday" or "fri".
+func parseWeekday(value string) (time.Weekday, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if value == name || value == name[:3] {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday '%s'", value)
+}
@@ -0,0 +1,122 @@
+package brevo
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newCumulativeService returns a service whose cumulative list 10 holds two
+// contacts, sent through a runServer. It reports how many sends had
+// happened when the list was cleared, -1 while it was not.
+func newCumulativeService(t *testing.T) (*BrevoService, *runServer, *int) {
+	t.Helper()
+
+	mock := &runServer{t: t}
+	clearedAfter := -1
+	members := []string{"a@example.com", "b@example.com"}
+
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v3/contacts/lists/10":
+			fmt.Fprintf(w, `{"id":10,"name":"Weekly","folderId":1,"totalSubscribers":%d}`, len(members))
+		case "GET /v3/contacts/lists/10/contacts":
+			page := `{"contacts":[`
+			for i, email := range members {
+				if i > 0 {
+					page += ","
+				}
+				page += fmt.Sprintf(`{"id":%d,"email":%q}`, i+1, email)
+			}
+			fmt.Fprint(w, page+`],"count":`+fmt.Sprint(len(members))+`}`)
+		case "POST /v3/contacts/lists/10/contacts/remove":
+			mock.mu.Lock()
+			clearedAfter = mock.sends
+			mock.mu.Unlock()
+			fmt.Fprintf(w, `{"contacts":{"success":[1,2]}}`)
+			members = nil
+		default:
+			mock.ServeHTTP(w, r)
+		}
+	}))
+	service.config.Campaign.Subject = "Winners"
+	service.config.Campaign.InlineHTML = "<html><body><p>Hello</p></body></html>"
+
+	c := &CumulativeList{
+		ListName:       "Weekly",
+		SendDay:        time.Now().Weekday(),
+		StatePath:      filepath.Join(t.TempDir(), "cumulative.json"),
+		ClearAfterSend: true,
+	}
+	if err := c.saveState(cumulativeState{ListID: 10}); err != nil {
+		t.Fatal(err)
+	}
+	service.config.Process.Cumulative = c
+
+	return service, mock, &clearedAfter
+}
+
+func TestSendCumulativeCampaignClearsAfterSend(t *testing.T) {
+	service, mock, clearedAfter := newCumulativeService(t)
+
+	result, err := service.SendCumulativeCampaign(true)
+	if err != nil {
+		t.Fatalf("SendCumulativeCampaign() error = %v", err)
+	}
+
+	if mock.sends != 1 {
+		t.Errorf("sends = %d, want 1", mock.sends)
+	}
+	if *clearedAfter != 1 || result.Cleared != 2 {
+		t.Errorf("list cleared after %d sends with %d removed, want after the send with 2 removed", *clearedAfter, result.Cleared)
+	}
+
+	state, err := service.config.Process.Cumulative.loadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.ClearPending {
+		t.Error("state still has a pending clear after the list was cleared")
+	}
+}
+
+func TestSendCumulativeCampaignClearsAfterConfirmation(t *testing.T) {
+	service, mock, clearedAfter := newCumulativeService(t)
+	service.config.Process.ConfirmationThreshold = 1
+
+	result, err := service.SendCumulativeCampaign(true)
+	if err != nil {
+		t.Fatalf("SendCumulativeCampaign() error = %v", err)
+	}
+	if result.Pending == nil || mock.sends != 0 {
+		t.Fatalf("pending = %+v with %d sends, want the campaign held unsent", result.Pending, mock.sends)
+	}
+	if *clearedAfter != -1 {
+		t.Fatal("list cleared before the held campaign was sent")
+	}
+
+	state, err := service.config.Process.Cumulative.loadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.ClearPending || state.CampaignID != result.Campaign.CampaignID {
+		t.Fatalf("state = %+v, want a pending clear for campaign %d", state, result.Campaign.CampaignID)
+	}
+
+	if send := service.ConfirmAndSend(result.Campaign.CampaignID); !send.Success {
+		t.Fatalf("ConfirmAndSend() = %+v", send)
+	}
+	if *clearedAfter != 1 {
+		t.Errorf("list cleared after %d sends, want after the confirmed send", *clearedAfter)
+	}
+
+	state, err = service.config.Process.Cumulative.loadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.ClearPending {
+		t.Error("state still has a pending clear after ConfirmAndSend cleared the list")
+	}
+}
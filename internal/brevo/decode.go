@@ -0,0 +1,57 @@
+package brevo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// flexibleID decodes an id Brevo may send either as a JSON number or as a
+// numeric string.
+type flexibleID int
+
+func (id *flexibleID) UnmarshalJSON(data []byte) error {
+	raw := bytes.TrimSpace(data)
+
+	if len(raw) > 0 && raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return err
+		}
+		raw = []byte(s)
+	}
+
+	n, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		f, ferr := strconv.ParseFloat(string(raw), 64)
+		if ferr != nil || f != float64(int64(f)) {
+			return fmt.Errorf("id %s is not an integer", string(data))
+		}
+		n = int64(f)
+	}
+
+	*id = flexibleID(n)
+	return nil
+}
+
+// decodeCreatedID reads the positive "id" of a create response.
+func decodeCreatedID(body []byte) (int, error) {
+	var result struct {
+		ID *flexibleID `json:"id"`
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, fmt.Errorf("failed to decode response %s: %w", string(body), err)
+	}
+
+	if result.ID == nil {
+		return 0, fmt.Errorf("response has no id: %s", string(body))
+	}
+
+	if *result.ID <= 0 {
+		return 0, fmt.Errorf("response has non-positive id %d", int(*result.ID))
+	}
+
+	return int(*result.ID), nil
+}
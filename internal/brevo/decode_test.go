@@ -0,0 +1,76 @@
+package brevo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDecodeCreatedID(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    int
+		wantErr bool
+	}{
+		{name: "number", body: `{"id":42}`, want: 42},
+		{name: "string", body: `{"id":"42"}`, want: 42},
+		{name: "float number", body: `{"id":42.0}`, want: 42},
+		{name: "padded string", body: `{"id": "7" }`, want: 7},
+		{name: "fractional", body: `{"id":4.5}`, wantErr: true},
+		{name: "non-numeric string", body: `{"id":"abc"}`, wantErr: true},
+		{name: "missing", body: `{"name":"Winners"}`, wantErr: true},
+		{name: "zero", body: `{"id":0}`, wantErr: true},
+		{name: "negative string", body: `{"id":"-3"}`, wantErr: true},
+		{name: "not JSON", body: `<html>Bad Gateway</html>`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCreatedID([]byte(tt.body))
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeCreatedID(%s) error = %v, wantErr %v", tt.body, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("decodeCreatedID(%s) = %d, want %d", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateResponsesAcceptBothIDShapes(t *testing.T) {
+	shapes := []struct {
+		name string
+		body string
+	}{
+		{name: "numeric id", body: `{"id":31}`},
+		{name: "string id", body: `{"id":"31"}`},
+	}
+
+	for _, shape := range shapes {
+		t.Run(shape.name, func(t *testing.T) {
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(shape.body))
+			}))
+
+			folderID, err := service.CreateFolder("Winners")
+			if err != nil || folderID != 31 {
+				t.Errorf("CreateFolder() = %d, %v, want 31", folderID, err)
+			}
+
+			list, err := service.createList("Winners List", 1)
+			if err != nil || list.ID != 31 {
+				t.Errorf("createList() = %d, %v, want 31", list.ID, err)
+			}
+
+			campaign, _ := service.postCampaign(CampaignPayload{Name: "Winners"})
+			if !campaign.Success || campaign.CampaignID != 31 {
+				t.Errorf("postCampaign() = %+v, want campaign 31", campaign)
+			}
+		})
+	}
+}
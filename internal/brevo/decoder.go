@@ -0,0 +1,125 @@
+package brevo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RowDecoder turns one CSV record into a CSVData. header is the file's first
+// record, so decoders can locate columns by name instead of position.
+type RowDecoder interface {
+	Decode(header []string, row []string) (CSVData, error)
+}
+
+// csvColumns lists the CSVData columns in the order of the winners export.
+var csvColumns = []string{
+	"nat", "stop", "category", "id", "contacts", "email", "website",
+	"vendor_name", "address", "id_code", "phone", "fax", "city", "country",
+}
+
+// PositionalDecoder reads the fixed 14-column winners export by position.
+// It is the default decoder.
+type PositionalDecoder struct{}
+
+func (PositionalDecoder) Decode(header []string, row []string) (CSVData, error) {
+	if len(row) != len(csvColumns) {
+		return CSVData{}, fmt.Errorf("has %d columns, expected %d", len(row), len(csvColumns))
+	}
+
+	var data CSVData
+	for i, field := range data.columnFields() {
+		*field = row[i]
+	}
+
+	return data, nil
+}
+
+// HeaderDecoder finds each column by its header name, so exports may order
+// their columns freely and carry extra ones. Header names are matched
+// case-insensitively with spaces and dashes read as underscores, e.g.
+// "Vendor Name" matches vendor_name. Aliases maps additional header names
+// onto those column names, such as {"e-mail address": "email"}.
+//
+// Only the email column is required, so sparse sync files with just an email
+// and a couple of attributes work; absent columns stay empty. Set
+// RequireAllColumns to reject files missing any of the 14 export columns.
+// Columns beyond the export's are kept in CSVData.Extra.
+type HeaderDecoder struct {
+	Aliases           map[string]string
+	RequireAllColumns bool
+}
+
+func (d HeaderDecoder) Decode(header []string, row []string) (CSVData, error) {
+	aliases := make(map[string]string, len(d.Aliases))
+	for from, to := range d.Aliases {
+		aliases[normalizeHeaderName(from)] = normalizeHeaderName(to)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = normalizeHeaderName(name)
+		if alias, ok := aliases[name]; ok {
+			name = alias
+		}
+		if _, seen := index[name]; !seen {
+			index[name] = i
+		}
+	}
+
+	var data CSVData
+	fields := data.columnFields()
+
+	for i, column := range csvColumns {
+		pos, ok := index[column]
+		if !ok {
+			if d.RequireAllColumns || column == "email" {
+				return CSVData{}, fmt.Errorf("header has no '%s' column", column)
+			}
+			continue
+		}
+		if pos < len(row) {
+			*fields[i] = row[pos]
+		}
+	}
+
+	for name, pos := range index {
+		if slices.Contains(csvColumns, name) || pos >= len(row) || name == "" {
+			continue
+		}
+		if data.Extra == nil {
+			data.Extra = make(map[string]string)
+		}
+		data.Extra[name] = row[pos]
+	}
+
+	return data, nil
+}
+
+// columnFields returns pointers to d's fields in csvColumns order.
+func (d *CSVData) columnFields() []*string {
+	return []*string{
+		&d.NAT, &d.STOP, &d.CATEGORY, &d.ID, &d.Contacts, &d.Email, &d.Website,
+		&d.VendorName, &d.Address, &d.IdCode, &d.Phone, &d.Fax, &d.City, &d.Country,
+	}
+}
+
+// normalizeHeaderName also drops the byte order mark Excel puts in front of
+// the first header.
+func normalizeHeaderName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.TrimPrefix(name, "\ufeff")
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+// decoderByName resolves the CSV_DECODER setting.
+func decoderByName(name string) (RowDecoder, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "positional":
+		return PositionalDecoder{}, nil
+	case "header":
+		return HeaderDecoder{}, nil
+	default:
+		return nil, fmt.Errorf("unknown CSV decoder '%s' (use positional or header)", name)
+	}
+}
@@ -0,0 +1,105 @@
+package brevo
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// DeprecationNotice is what Brevo announced about an endpoint through the
+// Deprecation and Sunset response headers.
+type DeprecationNotice struct {
+	Endpoint    string    `json:"endpoint"`
+	Deprecation string    `json:"deprecation,omitempty"`
+	Sunset      string    `json:"sunset,omitempty"`
+	Link        string    `json:"link,omitempty"`
+	SeenAt      time.Time `json:"seen_at"`
+}
+
+// DeprecationHandler is called the first time a notice is seen for an
+// endpoint, and again whenever the notice changes.
+type DeprecationHandler func(DeprecationNotice)
+
+// deprecationTracker is shared by copies of a BrevoService.
+type deprecationTracker struct {
+	mu      sync.Mutex
+	seen    map[string]DeprecationNotice
+	handler DeprecationHandler
+}
+
+// WithDeprecationHandler registers handler for Brevo deprecation notices, for
+// example to alert when an endpoint the service relies on is going away.
+func WithDeprecationHandler(handler DeprecationHandler) Option {
+	return func(b *BrevoService) {
+		b.deprecations.handler = handler
+	}
+}
+
+// Deprecations returns the last notice seen for each endpoint.
+func (b *BrevoService) Deprecations() map[string]DeprecationNotice {
+	b.deprecations.mu.Lock()
+	defer b.deprecations.mu.Unlock()
+
+	notices := make(map[string]DeprecationNotice, len(b.deprecations.seen))
+	for endpoint, notice := range b.deprecations.seen {
+		notices[endpoint] = notice
+	}
+	return notices
+}
+
+var (
+	numericPathSegment = regexp.MustCompile(`/\d+(/|$)`)
+	emailPathSegment   = regexp.MustCompile(`/[^/]*(@|%40)[^/]*`)
+)
+
+// endpointKey groups requests by method and path, with ids replaced, so
+// every page, contact or object of an endpoint shares one entry.
+func endpointKey(method, rawURL string) string {
+	path := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		path = u.Path
+	}
+
+	path = emailPathSegment.ReplaceAllString(path, "/{email}")
+	for numericPathSegment.MatchString(path) {
+		path = numericPathSegment.ReplaceAllString(path, "/{id}$1")
+	}
+
+	return method + " " + path
+}
+
+func (t *deprecationTracker) observe(method, rawURL string, header http.Header) {
+	deprecation := header.Get("Deprecation")
+	sunset := header.Get("Sunset")
+	if deprecation == "" && sunset == "" {
+		return
+	}
+
+	notice := DeprecationNotice{
+		Endpoint:    endpointKey(method, rawURL),
+		Deprecation: deprecation,
+		Sunset:      sunset,
+		Link:        header.Get("Link"),
+		SeenAt:      time.Now(),
+	}
+
+	t.mu.Lock()
+	previous, known := t.seen[notice.Endpoint]
+	t.seen[notice.Endpoint] = notice
+	handler := t.handler
+	t.mu.Unlock()
+
+	if known && previous.Deprecation == notice.Deprecation && previous.Sunset == notice.Sunset {
+		return
+	}
+
+	log.Printf("Warning: Brevo marked %s as deprecated (deprecation: %q, sunset: %q, link: %q)",
+		notice.Endpoint, notice.Deprecation, notice.Sunset, notice.Link)
+
+	if handler != nil {
+		handler(notice)
+	}
+}
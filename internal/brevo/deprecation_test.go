@@ -0,0 +1,107 @@
+package brevo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestEndpointKey(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   string
+	}{
+		{"GET", "https://api.brevo.com/v3/contacts?limit=1000&offset=2000", "GET /v3/contacts"},
+		{"GET", "https://api.brevo.com/v3/contacts/lists/12/contacts?limit=500", "GET /v3/contacts/lists/{id}/contacts"},
+		{"POST", "https://api.brevo.com/v3/emailCampaigns/7/sendNow", "POST /v3/emailCampaigns/{id}/sendNow"},
+		{"GET", "https://api.brevo.com/v3/contacts/a%40example.com", "GET /v3/contacts/{email}"},
+		{"GET", "https://api.brevo.com/v3/processes/5", "GET /v3/processes/{id}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := endpointKey(tt.method, tt.url); got != tt.want {
+				t.Errorf("endpointKey(%s, %s) = %q, want %q", tt.method, tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeprecationHeaders(t *testing.T) {
+	const sunset = "Sat, 01 Nov 2025 00:00:00 GMT"
+
+	tests := []struct {
+		name       string
+		headers    []map[string]string
+		wantCalls  int
+		wantNotice *DeprecationNotice
+		wantLink   string
+	}{
+		{
+			name:      "no headers",
+			headers:   []map[string]string{{}, {}},
+			wantCalls: 0,
+		},
+		{
+			name:       "sunset header reported once",
+			headers:    []map[string]string{{"Sunset": sunset, "Link": "<https://developers.brevo.com/changelog>"}, {"Sunset": sunset}},
+			wantCalls:  1,
+			wantNotice: &DeprecationNotice{Endpoint: "GET /v3/emailCampaigns/{id}", Sunset: sunset},
+			wantLink:   "<https://developers.brevo.com/changelog>",
+		},
+		{
+			name:       "changed notice reported again",
+			headers:    []map[string]string{{"Deprecation": "true"}, {"Deprecation": "true", "Sunset": sunset}},
+			wantCalls:  2,
+			wantNotice: &DeprecationNotice{Endpoint: "GET /v3/emailCampaigns/{id}", Deprecation: "true", Sunset: sunset},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := 0
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tt.headers[request] {
+					w.Header().Set(key, value)
+				}
+				request++
+				fmt.Fprint(w, `{"id":7,"status":"draft"}`)
+			}))
+
+			var calls []DeprecationNotice
+			WithDeprecationHandler(func(notice DeprecationNotice) {
+				calls = append(calls, notice)
+			})(service)
+
+			for i := range tt.headers {
+				if _, err := service.GetCampaignStatus(7 + i); err != nil {
+					t.Fatalf("GetCampaignStatus() error = %v", err)
+				}
+			}
+
+			if len(calls) != tt.wantCalls {
+				t.Fatalf("handler called %d times, want %d", len(calls), tt.wantCalls)
+			}
+
+			notices := service.Deprecations()
+			if tt.wantNotice == nil {
+				if len(notices) != 0 {
+					t.Errorf("Deprecations() = %v, want none", notices)
+				}
+				return
+			}
+
+			notice, ok := notices[tt.wantNotice.Endpoint]
+			if !ok {
+				t.Fatalf("Deprecations() = %v, want an entry for %s", notices, tt.wantNotice.Endpoint)
+			}
+			if notice.Deprecation != tt.wantNotice.Deprecation || notice.Sunset != tt.wantNotice.Sunset || notice.SeenAt.IsZero() {
+				t.Errorf("last notice = %+v, want %+v", notice, *tt.wantNotice)
+			}
+			if calls[0].Link != tt.wantLink {
+				t.Errorf("first notice Link = %q, want %q", calls[0].Link, tt.wantLink)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package brevo
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"net/http"
+	"os"
+)
+
+const DefaultDigestMaxErrors int = 10
+
+// DigestConfig emails a run summary to operators through Brevo's
+// transactional email after every run.
+type DigestConfig struct {
+	Recipients []Sender
+	// TemplateFile replaces the built-in html/template. It renders
+	// DigestData.
+	TemplateFile string
+	// MaxErrors caps how many errors the digest lists.
+	MaxErrors int
+}
+
+// DigestData is what digest templates render.
+type DigestData struct {
+	WebhookData
+	CSVPath    string
+	Duration   string
+	TopErrors  []ErrorResult
+	MoreErrors int
+}
+
+const defaultDigestTemplate = `<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; font-size: 14px; color: #222;">
+  <h2 style="margin: 0 0 12px;">{{.Summary}}</h2>
+  <p style="margin: 0 0 12px;">File: {{.CSVPath}}<br>Duration: {{.Duration}}</p>
+  <table cellpadding="6" style="border-collapse: collapse; margin-bottom: 12px;">
+    <tr><td>Added</td><td><b>{{.Added}}</b></td></tr>
+    <tr><td>Updated</td><td><b>{{.Updated}}</b></td></tr>
+    <tr><td>Skipped</td><td><b>{{.Skipped}}</b></td></tr>
+    <tr><td>Errors</td><td><b>{{.Errors}}</b></td></tr>
+  </table>
+  {{if .PreviewLink}}<p><a href="{{.PreviewLink}}">Open campaign {{.CampaignID}}</a></p>{{end}}
+  {{if .TopErrors}}
+  <h3 style="margin: 12px 0 6px;">Errors</h3>
+  <ul>
+    {{range .TopErrors}}<li>{{if .Row}}Row {{.Row}}: {{end}}{{if .Email}}{{.Email}}: {{end}}{{.Error}}{{if .Details}} ({{.Details}}){{end}}</li>
+    {{end}}
+  </ul>
+  {{if .MoreErrors}}<p>…and {{.MoreErrors}} more. See the run report for the full list.</p>{{end}}
+  {{end}}
+</body>
+</html>`
+
+type digestEmail struct {
+	Sender      Sender   `json:"sender"`
+	To          []Sender `json:"to"`
+	Subject     string   `json:"subject"`
+	HTMLContent string   `json:"htmlContent"`
+}
+
+func newDigestData(report RunReport, cfg DigestConfig, previewURL string) DigestData {
+	data := DigestData{
+		WebhookData: newWebhookData(report, previewURL),
+		CSVPath:     report.CSVPath,
+		Duration:    report.Duration,
+	}
+
+	limit := cfg.MaxErrors
+	if limit <= 0 {
+		limit = DefaultDigestMaxErrors
+	}
+
+	errors := report.Results.Errors
+	if len(errors) > limit {
+		data.MoreErrors = len(errors) - limit
+		errors = errors[:limit]
+	}
+	data.TopErrors = errors
+
+	return data
+}
+
+func renderDigest(cfg DigestConfig, data DigestData) (string, error) {
+	source := defaultDigestTemplate
+	if cfg.TemplateFile != "" {
+		content, err := os.ReadFile(cfg.TemplateFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read digest template '%s': %w", cfg.TemplateFile, err)
+		}
+		source = string(content)
+	}
+
+	tmpl, err := template.New("digest").Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("invalid digest template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render digest template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// sendDigest emails the run summary to the digest recipients. Failures are
+// logged and never affect the run.
+func (b *BrevoService) sendDigest(report RunReport) {
+	cfg := b.config.Digest
+	if len(cfg.Recipients) == 0 {
+		return
+	}
+
+	data := newDigestData(report, cfg, b.config.Webhook.PreviewURL)
+	htmlContent, err := renderDigest(cfg, data)
+	if err != nil {
+		log.Printf("Failed to build run digest: %v", err)
+		return
+	}
+
+	email := digestEmail{
+		Sender:      Sender{Name: b.config.SenderName, Email: b.config.SenderEmail},
+		To:          cfg.Recipients,
+		Subject:     data.Summary,
+		HTMLContent: htmlContent,
+	}
+
+	resp, err := b.makeAPIRequest(opCampaign, "POST", "https://api.brevo.com/v3/smtp/email", email)
+	if err != nil {
+		log.Printf("Failed to send run digest: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		log.Printf("Run digest returned %d - %s", resp.StatusCode, b.logBody(body))
+		return
+	}
+
+	log.Printf("Run digest sent to %d recipients", len(cfg.Recipients))
+}
@@ -0,0 +1,53 @@
+package brevo
+
+import (
+	"strings"
+)
+
+// ExclusionDomainNotAllowlisted skips contacts outside DomainAllowlist.
+const ExclusionDomainNotAllowlisted string = "domain not allowlisted"
+
+// parseDomainAllowlist parses a comma-separated list of email domains,
+// lowercased and without a leading "@".
+func parseDomainAllowlist(value string) []string {
+	var domains []string
+	for _, domain := range strings.Split(value, ",") {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+		if domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// emailDomain returns the lowercased domain of email, or "" when it has
+// none.
+func emailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[at+1:]))
+}
+
+// outsideAllowlist returns the emails in csvData whose domain is not in
+// allowlist. An empty allowlist allows everything.
+func outsideAllowlist(csvData []CSVData, allowlist []string) map[string]bool {
+	if len(allowlist) == 0 {
+		return nil
+	}
+
+	allowed := make(map[string]bool, len(allowlist))
+	for _, domain := range allowlist {
+		allowed[domain] = true
+	}
+
+	emails := make(map[string]bool)
+	for _, data := range csvData {
+		if !allowed[emailDomain(data.Email)] {
+			emails[strings.ToLower(data.Email)] = true
+		}
+	}
+
+	return emails
+}
@@ -0,0 +1,153 @@
+package brevo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// DuplicateNamePolicy decides which folder or list to use when an account
+// holds several with the same name, usually left behind by past races.
+type DuplicateNamePolicy string
+
+const (
+	// DuplicateLowestID uses the oldest, lowest-ID match.
+	DuplicateLowestID DuplicateNamePolicy = "lowest"
+	// DuplicateError refuses to pick and fails with ErrDuplicateName.
+	DuplicateError DuplicateNamePolicy = "error"
+	// DuplicateMerge uses the lowest-ID match and moves the lists of the
+	// other folders, or the contacts of the other lists, into it. Contacts
+	// leave a duplicate list only once they are in the kept one. The
+	// emptied duplicates are left in place for an operator to delete.
+	DuplicateMerge DuplicateNamePolicy = "merge"
+)
+
+// ErrDuplicateName is returned, wrapped, when DuplicateError is set and a
+// name matches more than one folder or list.
+var ErrDuplicateName = errors.New("name matches more than one object")
+
+func parseDuplicateNamePolicy(value string) (DuplicateNamePolicy, error) {
+	switch policy := DuplicateNamePolicy(strings.ToLower(strings.TrimSpace(value))); policy {
+	case "":
+		return DuplicateLowestID, nil
+	case DuplicateLowestID, DuplicateError, DuplicateMerge:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown duplicate name policy '%s' (expected lowest, error or merge)", value)
+	}
+}
+
+// resolveDuplicateFolders picks one of the folders ids named name.
+func (b *BrevoService) resolveDuplicateFolders(name string, ids []int) (int, error) {
+	keep, err := b.pickDuplicate("folder", name, ids)
+	if err != nil || b.config.DuplicateNames != DuplicateMerge {
+		return keep, err
+	}
+
+	for _, id := range ids {
+		if id == keep {
+			continue
+		}
+
+		lists, err := b.GetFolderLists(id)
+		if err != nil {
+			return 0, fmt.Errorf("failed to merge folder %d into %d: %w", id, keep, err)
+		}
+
+		for _, list := range lists {
+			if err := b.moveList(list.ID, keep); err != nil {
+				return 0, fmt.Errorf("failed to merge folder %d into %d: %w", id, keep, err)
+			}
+		}
+
+		log.Printf("Moved %d lists from duplicate folder %d into folder %d", len(lists), id, keep)
+	}
+
+	return keep, nil
+}
+
+// resolveDuplicateLists picks one of the lists ids named name.
+func (b *BrevoService) resolveDuplicateLists(name string, ids []int) (int, error) {
+	keep, err := b.pickDuplicate("list", name, ids)
+	if err != nil || b.config.DuplicateNames != DuplicateMerge {
+		return keep, err
+	}
+
+	for _, id := range ids {
+		if id == keep {
+			continue
+		}
+
+		members, err := b.GetExistingContactsInList(id)
+		if err != nil {
+			return 0, fmt.Errorf("failed to merge list %d into %d: %w", id, keep, err)
+		}
+
+		emails := sortedKeys(members)
+		if _, err := b.addContactsToList(keep, emails); err != nil {
+			return 0, fmt.Errorf("failed to merge list %d into %d: %w", id, keep, err)
+		}
+
+		kept, err := b.GetExistingContactsInList(keep)
+		if err != nil {
+			return 0, fmt.Errorf("failed to merge list %d into %d: %w", id, keep, err)
+		}
+
+		moved := make([]string, 0, len(emails))
+		for _, email := range emails {
+			if kept[email] {
+				moved = append(moved, email)
+			}
+		}
+
+		removed := 0
+		for start := 0; start < len(moved); start += listAddBatchSize {
+			end := min(start+listAddBatchSize, len(moved))
+			count, err := b.removeContactsFromList(id, moved[start:end])
+			if err != nil {
+				return 0, fmt.Errorf("failed to merge list %d into %d: %w", id, keep, err)
+			}
+			removed += count
+		}
+
+		log.Printf("Moved %d of %d contacts from duplicate list %d into list %d", removed, len(emails), id, keep)
+	}
+
+	return keep, nil
+}
+
+// pickDuplicate applies the policy's choice between ids, logging the
+// ambiguity either way.
+func (b *BrevoService) pickDuplicate(kind, name string, ids []int) (int, error) {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+
+	if b.config.DuplicateNames == DuplicateError {
+		return 0, fmt.Errorf("%w: %s '%s' has IDs %v", ErrDuplicateName, kind, name, sorted)
+	}
+
+	log.Printf("Warning: %d %ss are named '%s' (IDs %v). Using %d.", len(sorted), kind, name, sorted, sorted[0])
+	return sorted[0], nil
+}
+
+// moveList puts listID into folderID.
+func (b *BrevoService) moveList(listID, folderID int) error {
+	url := fmt.Sprintf("https://api.brevo.com/v3/contacts/lists/%d", listID)
+
+	resp, err := b.makeAPIRequest(opAdd, "PUT", url, map[string]int{"folderId": folderID})
+	if err != nil {
+		return fmt.Errorf("exception moving list %d: %w", listID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to move list %d to folder %d: status %d - %s", listID, folderID, resp.StatusCode, string(body))
+	}
+
+	return nil
+}
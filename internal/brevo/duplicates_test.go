@@ -0,0 +1,71 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestResolveDuplicateLists(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      DuplicateNamePolicy
+		wantKeep    int
+		wantErr     bool
+		wantRemoved []string
+	}{
+		{name: "lowest keeps the oldest list", policy: DuplicateLowestID, wantKeep: 4},
+		{name: "error refuses to pick", policy: DuplicateError, wantErr: true},
+		{name: "merge moves only the contacts the kept list took", policy: DuplicateMerge, wantKeep: 4, wantRemoved: []string{"a@example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept := map[string]bool{}
+			var removed []string
+
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var payload struct {
+					Emails []string `json:"emails"`
+				}
+				json.NewDecoder(r.Body).Decode(&payload)
+
+				switch r.URL.Path {
+				case "/v3/contacts/lists/9/contacts":
+					fmt.Fprint(w, `{"contacts":[{"email":"a@example.com"},{"email":"b@example.com"}],"count":2}`)
+				case "/v3/contacts/lists/4/contacts/add":
+					kept["a@example.com"] = true
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"contacts":{"success":["a@example.com"],"failure":["b@example.com"]}}`)
+				case "/v3/contacts/lists/4/contacts":
+					var contacts []BrevoContact
+					for email := range kept {
+						contacts = append(contacts, BrevoContact{Email: email})
+					}
+					json.NewEncoder(w).Encode(ContactsResponse{Contacts: contacts, Count: len(contacts)})
+				case "/v3/contacts/lists/9/contacts/remove":
+					removed = append(removed, payload.Emails...)
+					w.WriteHeader(http.StatusCreated)
+					json.NewEncoder(w).Encode(map[string]any{"contacts": map[string][]string{"success": payload.Emails}})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			service.config.DuplicateNames = tt.policy
+
+			keep, err := service.resolveDuplicateLists("Winners", []int{9, 4})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveDuplicateLists() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if keep != tt.wantKeep {
+				t.Errorf("keep = %d, want %d", keep, tt.wantKeep)
+			}
+			if !slices.Equal(removed, tt.wantRemoved) {
+				t.Errorf("removed = %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
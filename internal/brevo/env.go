@@ -0,0 +1,68 @@
+package brevo
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Warning: invalid duration %s=%q. Using default %s.", key, value, fallback)
+		return fallback
+	}
+
+	return d
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Warning: invalid boolean %s=%q. Using default %v.", key, value, fallback)
+		return fallback
+	}
+
+	return b
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 {
+		log.Printf("Warning: invalid number %s=%q. Using default %v.", key, value, fallback)
+		return fallback
+	}
+
+	return f
+}
+
+func getEnvInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Warning: invalid integer %s=%q. Using default %d.", key, value, fallback)
+		return fallback
+	}
+
+	return n
+}
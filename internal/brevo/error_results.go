@@ -0,0 +1,139 @@
+package brevo
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+// ErrorCategory groups errors for reporting.
+type ErrorCategory string
+
+const (
+	// ErrorCategoryValidation covers bad input: missing emails, invalid
+	// IDs, configuration and content checks.
+	ErrorCategoryValidation ErrorCategory = "validation"
+	// ErrorCategoryNetwork covers requests that got no response in time.
+	ErrorCategoryNetwork ErrorCategory = "network"
+	// ErrorCategoryAPI covers everything Brevo answered with a failure.
+	ErrorCategoryAPI ErrorCategory = "api"
+	// ErrorCategoryDuplicate covers objects that already exist.
+	ErrorCategoryDuplicate ErrorCategory = "duplicate"
+)
+
+// ErrMissingEmail is recorded for CSV rows without an email address.
+var ErrMissingEmail = errors.New("missing email")
+
+// apiStatusError is a failure Brevo reported with StatusCode, or that never
+// got a response when StatusCode is 0.
+type apiStatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiStatusError) Error() string {
+	return e.Message
+}
+
+// Errors collects the errors of a run. It marshals as a plain list of
+// ErrorResult.
+type Errors []ErrorResult
+
+// Add records err, classifying it and tagging it retryable when it was
+// transient.
+func (e *Errors) Add(email string, err error, details string) {
+	e.addRow(0, email, err, details)
+}
+
+// addRow is Add for the contact on CSV row. It returns the entry's index so
+// callers can adjust it; pointers into the slice go stale on the next add.
+func (e *Errors) addRow(row int, email string, err error, details string) int {
+	category := classifyError(err)
+	*e = append(*e, ErrorResult{
+		Row:       row,
+		Email:     email,
+		Error:     err.Error(),
+		Details:   details,
+		Category:  category,
+		Retryable: isRetryableError(category, err),
+		Attempts:  1,
+	})
+	return len(*e) - 1
+}
+
+// Count returns how many errors were recorded.
+func (e Errors) Count() int {
+	return len(e)
+}
+
+// ByCategory counts the errors per category. Entries recorded without one
+// are classified from their message.
+func (e Errors) ByCategory() map[ErrorCategory]int {
+	if len(e) == 0 {
+		return nil
+	}
+
+	counts := make(map[ErrorCategory]int)
+	for _, result := range e {
+		category := result.Category
+		if category == "" {
+			category = classifyMessage(result.Error)
+		}
+		counts[category]++
+	}
+	return counts
+}
+
+func classifyError(err error) ErrorCategory {
+	var statusErr *apiStatusError
+	var notFound *NotFoundError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, ErrDuplicateName):
+		return ErrorCategoryDuplicate
+	case errors.Is(err, ErrMissingEmail), errors.Is(err, ErrEmptyCSV), errors.Is(err, ErrHTMLValidation),
+		errors.Is(err, ErrScheduleWindow), errors.As(err, &notFound):
+		return ErrorCategoryValidation
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return ErrorCategoryNetwork
+	case errors.As(err, &statusErr) && statusErr.StatusCode == 0:
+		return ErrorCategoryNetwork
+	}
+
+	return classifyMessage(err.Error())
+}
+
+// classifyMessage classifies an error from its text, for errors that only
+// carry Brevo's message.
+func classifyMessage(message string) ErrorCategory {
+	lower := strings.ToLower(message)
+
+	switch {
+	case strings.Contains(lower, "duplicate") || strings.Contains(lower, "already exist"):
+		return ErrorCategoryDuplicate
+	case strings.Contains(lower, "invalid"):
+		return ErrorCategoryValidation
+	case strings.Contains(lower, "timed out") || strings.Contains(lower, "timeout") ||
+		strings.Contains(lower, "connection") || strings.Contains(lower, "no such host"):
+		return ErrorCategoryNetwork
+	default:
+		return ErrorCategoryAPI
+	}
+}
+
+// isRetryableError reports whether an error of category is worth retrying.
+// Brevo failures with a status are retried only on 429 and 5xx.
+func isRetryableError(category ErrorCategory, err error) bool {
+	switch category {
+	case ErrorCategoryValidation, ErrorCategoryDuplicate:
+		return false
+	}
+
+	var statusErr *apiStatusError
+	if errors.As(err, &statusErr) {
+		return isRetryableStatus(statusErr.StatusCode)
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package brevo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+)
+
+func TestErrorsAddClassifies(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantCategory  ErrorCategory
+		wantRetryable bool
+	}{
+		{"missing email", ErrMissingEmail, ErrorCategoryValidation, false},
+		{"not found", &NotFoundError{Resource: "list", ID: 3}, ErrorCategoryValidation, false},
+		{"wrapped html validation", fmt.Errorf("campaign: %w", ErrHTMLValidation), ErrorCategoryValidation, false},
+		{"duplicate name", ErrDuplicateName, ErrorCategoryDuplicate, false},
+		{"duplicate message", errors.New("Contact already exist"), ErrorCategoryDuplicate, false},
+		{"deadline", context.DeadlineExceeded, ErrorCategoryNetwork, true},
+		{"dns failure", &net.DNSError{Err: "no such host", Name: "api.brevo.com"}, ErrorCategoryNetwork, true},
+		{"no response", &apiStatusError{Message: "connection reset"}, ErrorCategoryNetwork, true},
+		{"rate limited", &apiStatusError{StatusCode: 429, Message: "too many requests"}, ErrorCategoryAPI, true},
+		{"server error", &apiStatusError{StatusCode: 502, Message: "bad gateway"}, ErrorCategoryAPI, true},
+		{"bad request", &apiStatusError{StatusCode: 400, Message: "unexpected status 400"}, ErrorCategoryAPI, false},
+		{"invalid parameter", &apiStatusError{StatusCode: 400, Message: "invalid_parameter"}, ErrorCategoryValidation, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs Errors
+			errs.Add("a@example.com", tt.err, "Details")
+			got := errs[0]
+
+			if got.Category != tt.wantCategory {
+				t.Errorf("Category = %q, want %q", got.Category, tt.wantCategory)
+			}
+			if got.Retryable != tt.wantRetryable {
+				t.Errorf("Retryable = %v, want %v", got.Retryable, tt.wantRetryable)
+			}
+			if got.Attempts != 1 || errs.Count() != 1 {
+				t.Errorf("Attempts = %d, Count() = %d, want 1 and 1", got.Attempts, errs.Count())
+			}
+		})
+	}
+}
+
+func TestErrorsByCategory(t *testing.T) {
+	var errs Errors
+	if got := errs.ByCategory(); got != nil {
+		t.Errorf("ByCategory() of no errors = %v, want nil", got)
+	}
+
+	errs.Add("a@example.com", ErrMissingEmail, "")
+	errs.Add("b@example.com", ErrMissingEmail, "")
+	errs.Add("c@example.com", context.DeadlineExceeded, "")
+	errs = append(errs, ErrorResult{Error: "request timed out"})
+
+	got := errs.ByCategory()
+	want := map[ErrorCategory]int{ErrorCategoryValidation: 2, ErrorCategoryNetwork: 2}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ByCategory() = %v, want %v", got, want)
+	}
+}
+
+func TestErrorsAddRowIndexOutlivesLaterAdds(t *testing.T) {
+	var errs Errors
+	first := errs.addRow(2, "a@example.com", ErrMissingEmail, "")
+	for i := range 10 {
+		errs.addRow(i+3, "b@example.com", ErrMissingEmail, "")
+	}
+	errs[first].Attempts = 3
+
+	if errs[first].Email != "a@example.com" || errs[first].Attempts != 3 {
+		t.Errorf("Errors[%d] = %+v, want the first entry with 3 attempts", first, errs[first])
+	}
+}
@@ -0,0 +1,138 @@
+package brevo
+
+import (
+	"log"
+	"slices"
+	"strings"
+)
+
+// Reasons a contact is left out of the run's list.
+const (
+	ExclusionBlacklisted      string = "blacklisted"
+	ExclusionListUnsubscribed string = "list-unsubscribed"
+)
+
+// contactStates collects the opt-out state of existing contacts while they
+// are fetched, and their attributes when keepAttributes was set. A nil
+// *contactStates ignores everything.
+type contactStates struct {
+	blacklisted      map[string]bool
+	listUnsubscribed map[string][]int
+	attributes       map[string]map[string]any
+}
+
+func newContactStates(opts ProcessOptions, keepAttributes bool) *contactStates {
+	if !opts.SkipBlacklisted && !opts.SkipListUnsubscribed && !keepAttributes {
+		return nil
+	}
+
+	states := &contactStates{
+		blacklisted:      make(map[string]bool),
+		listUnsubscribed: make(map[string][]int),
+	}
+	if keepAttributes {
+		states.attributes = make(map[string]map[string]any)
+	}
+	return states
+}
+
+func (s *contactStates) observe(contact BrevoContact) {
+	if s == nil || contact.Email == "" {
+		return
+	}
+
+	email := strings.ToLower(contact.Email)
+	if contact.EmailBlacklisted {
+		s.blacklisted[email] = true
+	}
+	if len(contact.ListUnsubscribed) > 0 {
+		s.listUnsubscribed[email] = contact.ListUnsubscribed
+	}
+	if s.attributes != nil {
+		s.attributes[email] = contact.Attributes
+	}
+}
+
+// attributesOf returns the attributes fetched for email, and false when
+// none were recorded for it.
+func (s *contactStates) attributesOf(email string) (map[string]any, bool) {
+	if s == nil || s.attributes == nil {
+		return nil, false
+	}
+
+	attributes, ok := s.attributes[strings.ToLower(email)]
+	return attributes, ok
+}
+
+// unsubscribedFrom returns the contacts that unsubscribed from listID.
+func (s *contactStates) unsubscribedFrom(listID int) map[string]bool {
+	if s == nil {
+		return nil
+	}
+
+	emails := make(map[string]bool)
+	for email, lists := range s.listUnsubscribed {
+		if slices.Contains(lists, listID) {
+			emails[email] = true
+		}
+	}
+
+	return emails
+}
+
+// exclude marks every email in emails as skipped for reason. Emails already
+// excluded keep their first reason.
+func (r *ProcessingResults) exclude(emails map[string]bool, reason string) {
+	if len(emails) == 0 {
+		return
+	}
+
+	if r.excluded == nil {
+		r.excluded = make(map[string]string, len(emails))
+	}
+
+	for email := range emails {
+		email = strings.ToLower(email)
+		if _, ok := r.excluded[email]; !ok {
+			r.excluded[email] = reason
+		}
+	}
+}
+
+// isExcluded reports whether email is left out of the run.
+func (r *ProcessingResults) isExcluded(email string) bool {
+	_, ok := r.excluded[strings.ToLower(email)]
+	return ok
+}
+
+// skipExcluded records data as skipped when its email was excluded and
+// reports whether it did.
+func (r *ProcessingResults) skipExcluded(row int, data *CSVData) bool {
+	reason, ok := r.excluded[strings.ToLower(data.Email)]
+	if !ok {
+		return false
+	}
+
+	log.Printf("[-] Skipping %s: %s", data.Email, reason)
+
+	r.Skipped = append(r.Skipped, ContactResult{
+		Row:    row,
+		Email:  data.Email,
+		Data:   data,
+		Reason: reason,
+	})
+
+	if r.Excluded == nil {
+		r.Excluded = make(map[string]int)
+	}
+	r.Excluded[reason]++
+
+	if reason == ExclusionDomainNotAllowlisted {
+		if r.SkippedDomains == nil {
+			r.SkippedDomains = make(map[string]int)
+		}
+		r.SkippedDomains[emailDomain(data.Email)]++
+	}
+
+	return true
+}
@@ -0,0 +1,164 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// Brevo's contact filter only supports equals(ATTRIBUTE, value).
+	contactFilterPattern = regexp.MustCompile(`^equals\(\s*[A-Za-z][A-Za-z0-9_]*\s*,\s*("[^"]*"|[^,()"\s]+)\s*\)$`)
+	segmentFilterPattern = regexp.MustCompile(`^segment:(\d+)$`)
+)
+
+// ValidateContactFilter accepts either "segment:<id>" for an existing Brevo
+// segment or an attribute filter such as equals(TENDER_CODE,"X").
+func ValidateContactFilter(filter string) error {
+	filter = strings.TrimSpace(filter)
+
+	if segmentFilterPattern.MatchString(filter) || contactFilterPattern.MatchString(filter) {
+		return nil
+	}
+
+	return fmt.Errorf("invalid filter '%s': expected segment:<id> or equals(ATTRIBUTE,\"value\")", filter)
+}
+
+// CreateCampaignFromFilter builds a campaign for an existing cohort instead
+// of a CSV. A segment filter targets the segment directly; an attribute
+// filter collects the matching contacts into a fresh list first. Both are
+// held to MaxRecipients.
+func (b *BrevoService) CreateCampaignFromFilter(filter string, cfg CampaignConfig) CampaignResult {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	filter = strings.TrimSpace(filter)
+
+	if err := ValidateContactFilter(filter); err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      err.Error(),
+			StatusCode: 0,
+		}
+	}
+
+	if match := segmentFilterPattern.FindStringSubmatch(filter); match != nil {
+		segmentID, _ := strconv.Atoi(match[1])
+		if err := b.checkSegmentRecipientCap(segmentID); err != nil {
+			return CampaignResult{
+				Success:    false,
+				Error:      err.Error(),
+				StatusCode: 0,
+			}
+		}
+
+		log.Printf("Creating campaign for segment %d", segmentID)
+		return b.createCampaign(map[string][]int{"segmentIds": {segmentID}}, cfg, CampaignMeta{CSVName: filter}, time.Time{})
+	}
+
+	emails, err := b.getContactEmailsByFilter(filter)
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to fetch contacts for filter: %v", err),
+			StatusCode: 0,
+		}
+	}
+
+	if len(emails) == 0 {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("No contacts match filter '%s'", filter),
+			StatusCode: 0,
+		}
+	}
+
+	if err := enforceRecipientCap(b.config.Process, len(emails)); err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      err.Error(),
+			StatusCode: 0,
+		}
+	}
+
+	listID, err := b.CreateNewContactList(filter)
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to create contact list: %v", err),
+			StatusCode: 0,
+		}
+	}
+
+	added, err := b.addContactsToList(listID, emails)
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to populate contact list %d: %v", listID, err),
+			StatusCode: 0,
+		}
+	}
+
+	log.Printf("Added %d of %d contacts matching '%s' to list %d", added, len(emails), filter, listID)
+
+	return b.CreateNewCampaign(listID, cfg, CampaignMeta{CSVName: filter, Count: added})
+}
+
+// checkSegmentRecipientCap enforces MaxRecipients for a campaign to
+// segmentID. A segment that cannot be counted is refused while a cap is set.
+func (b *BrevoService) checkSegmentRecipientCap(segmentID int) error {
+	opts := b.config.Process
+	if opts.MaxRecipients <= 0 {
+		return nil
+	}
+
+	count, err := b.getCount(fmt.Sprintf("https://api.brevo.com/v3/contacts?limit=1&offset=0&segmentId=%d", segmentID))
+	if err != nil {
+		return fmt.Errorf("failed to count the recipients of segment %d: %w", segmentID, err)
+	}
+
+	return enforceRecipientCap(opts, count)
+}
+
+func (b *BrevoService) getContactEmailsByFilter(filter string) ([]string, error) {
+	contacts, err := paginate(b.context(), 1000, func(limit, offset int) ([]BrevoContact, int, error) {
+		reqURL := fmt.Sprintf("https://api.brevo.com/v3/contacts?limit=%d&offset=%d&filter=%s", limit, offset, url.QueryEscape(filter))
+
+		resp, err := b.makeAPIRequest(opFetch, "GET", reqURL, nil)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error fetching filtered contacts at offset %d: %w", offset, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return nil, 0, fmt.Errorf("API error at offset %d: %d - %s", offset, resp.StatusCode, string(body))
+		}
+
+		var contactsResp ContactsResponse
+		if err := json.NewDecoder(resp.Body).Decode(&contactsResp); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		return contactsResp.Contacts, contactsResp.Count, nil
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := []string{}
+	for _, contact := range contacts {
+		if contact.Email != "" {
+			emails = append(emails, contact.Email)
+		}
+	}
+
+	return emails, nil
+}
@@ -0,0 +1,45 @@
+package brevo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCreateCampaignFromFilterRecipientCap(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+	}{
+		{name: "segment", filter: "segment:4"},
+		{name: "attribute filter", filter: `equals(TENDER_CODE,"X")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writes []string
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					writes = append(writes, r.Method+" "+r.URL.Path)
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprint(w, `{"id":10}`)
+					return
+				}
+				if r.URL.Path != "/v3/contacts" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				fmt.Fprint(w, `{"contacts":[{"id":1,"email":"a@example.com"},{"id":2,"email":"b@example.com"}],"count":2}`)
+			}))
+			service.config.Process.MaxRecipients = 1
+
+			result := service.CreateCampaignFromFilter(tt.filter, DefaultCampaignConfig())
+			if result.Success || !strings.Contains(result.Error, ErrRecipientCapExceeded.Error()) {
+				t.Errorf("CreateCampaignFromFilter() = %+v, want it refused over the recipient cap", result)
+			}
+			if len(writes) > 0 {
+				t.Errorf("Brevo received %v, want nothing created over the cap", writes)
+			}
+		})
+	}
+}
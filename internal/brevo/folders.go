@@ -0,0 +1,86 @@
+package brevo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const DefaultFolderName string = "Winners"
+
+// FolderMeta is the run metadata a FolderStrategy can derive a name from.
+type FolderMeta struct {
+	CSVName  string
+	Date     time.Time
+	Category string
+}
+
+// FolderStrategy picks the Brevo folder a run's list is created in. Brevo
+// folders are flat, so a "Winners/2024-06" style name is a single folder
+// whose name merely reads as a path.
+type FolderStrategy func(meta FolderMeta) string
+
+func FixedFolderStrategy(name string) FolderStrategy {
+	return func(FolderMeta) string {
+		return name
+	}
+}
+
+// MonthlyFolderStrategy groups lists by month, e.g. "Winners/2024-06".
+func MonthlyFolderStrategy(meta FolderMeta) string {
+	return DefaultFolderName + "/" + meta.Date.Format("2006-01")
+}
+
+// CategoryFolderStrategy groups lists by CSV category, falling back to the
+// default folder when the run has none.
+func CategoryFolderStrategy(meta FolderMeta) string {
+	if meta.Category == "" {
+		return DefaultFolderName
+	}
+	return DefaultFolderName + "/" + meta.Category
+}
+
+func folderStrategyByName(name string) (FolderStrategy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "fixed":
+		return nil, nil
+	case "monthly":
+		return MonthlyFolderStrategy, nil
+	case "category":
+		return CategoryFolderStrategy, nil
+	default:
+		return nil, fmt.Errorf("unknown folder strategy '%s' (expected fixed, monthly or category)", name)
+	}
+}
+
+// normalizeFolderName tidies a derived name segment by segment and rejects
+// names with empty segments such as "Winners//x".
+func normalizeFolderName(name string) (string, error) {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = strings.Join(strings.Fields(segment), " ")
+		if segments[i] == "" {
+			return "", fmt.Errorf("invalid folder name '%s': empty segment", name)
+		}
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// resolveFolderName applies the configured strategy, defaulting to the
+// single Winners folder.
+func resolveFolderName(strategy FolderStrategy, meta FolderMeta) (string, error) {
+	if strategy == nil {
+		return DefaultFolderName, nil
+	}
+	return normalizeFolderName(strategy(meta))
+}
+
+// firstCategory returns the first non-empty CATEGORY in csvData.
+func firstCategory(csvData []CSVData) string {
+	for _, data := range csvData {
+		if data.CATEGORY != "" {
+			return data.CATEGORY
+		}
+	}
+	return ""
+}
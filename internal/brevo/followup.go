@@ -0,0 +1,153 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// exportTimeout bounds the wait for Brevo to prepare a recipients export.
+const exportTimeout = 5 * time.Minute
+
+// CreateFollowupCampaign creates, but does not send, a campaign for the
+// contacts who did not open originalCampaignID. The non-openers are put in a
+// new list in the Winners folder.
+func (b *BrevoService) CreateFollowupCampaign(originalCampaignID int, cfg CampaignConfig) CampaignResult {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	original, err := b.getCampaign(originalCampaignID)
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to load original campaign: %v", err),
+			StatusCode: 0,
+		}
+	}
+
+	if original.Status != "sent" {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Campaign %d is '%s'; follow-ups need a sent campaign with opening stats", originalCampaignID, original.Status),
+			StatusCode: 0,
+		}
+	}
+
+	emails, err := b.exportRecipients(originalCampaignID, "nonOpeners")
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to fetch non-openers: %v", err),
+			StatusCode: 0,
+		}
+	}
+
+	if len(emails) == 0 {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Every recipient of campaign %d opened it; no follow-up needed", originalCampaignID),
+			StatusCode: 0,
+		}
+	}
+
+	folderID, err := b.GetOrCreateFolder(DefaultFolderName)
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to get folder for follow-up list: %v", err),
+			StatusCode: 0,
+		}
+	}
+
+	list, err := b.createList(fmt.Sprintf("Follow-up - %s", original.Name), folderID)
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to create follow-up list: %v", err),
+			StatusCode: 0,
+		}
+	}
+
+	added, err := b.addContactsToList(list.ID, emails)
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to fill follow-up list %d: %v", list.ID, err),
+			StatusCode: 0,
+		}
+	}
+
+	log.Printf("Follow-up list %d holds %d of %d non-openers of campaign %d", list.ID, added, len(emails), originalCampaignID)
+
+	return b.CreateNewCampaign(list.ID, cfg, CampaignMeta{CSVName: original.Name, Count: added})
+}
+
+// exportRecipients asks Brevo to export the campaign's recipients of
+// recipientsType (e.g. "nonOpeners" or "hardBounces"), waits for the export
+// process and reads the emails from the resulting file.
+func (b *BrevoService) exportRecipients(campaignID int, recipientsType string) ([]string, error) {
+	url := fmt.Sprintf("https://api.brevo.com/v3/emailCampaigns/%d/exportRecipients", campaignID)
+	payload := map[string]string{"recipientsType": recipientsType}
+
+	resp, err := b.makeAPIRequest(opCampaign, "POST", url, payload)
+	if err != nil {
+		return nil, fmt.Errorf("exception requesting recipients export: %w", err)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("failed to export recipients: status %d - %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		ProcessID int `json:"processId"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil || result.ProcessID <= 0 {
+		return nil, fmt.Errorf("invalid or missing processId in export response: %s", string(body))
+	}
+
+	status, err := b.PollImportProcess(result.ProcessID, exportTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.ExportURL == "" {
+		return nil, fmt.Errorf("export process %d finished without an export URL", result.ProcessID)
+	}
+
+	return b.downloadEmails(status.ExportURL)
+}
+
+// downloadEmails fetches an export file. Export URLs are pre-signed, so the
+// request goes out without the API key.
+func (b *BrevoService) downloadEmails(exportURL string) ([]string, error) {
+	resp, err := b.downloadProcessFile(exportURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download export: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download export: status %d", resp.StatusCode)
+	}
+
+	found, err := readEmails(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export: %w", err)
+	}
+
+	emails := make([]string, 0, len(found))
+	for email := range found {
+		emails = append(emails, email)
+	}
+
+	return emails, nil
+}
@@ -0,0 +1,229 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+const smtpEventsPageSize int = 500
+
+const (
+	EmailEventSent      string = "sent"
+	EmailEventDelivered string = "delivered"
+	EmailEventOpened    string = "opened"
+	EmailEventClicked   string = "clicked"
+	EmailEventBounced   string = "bounced"
+)
+
+// EmailEvent is one thing that happened to an email sent to a contact,
+// from a campaign or a transactional send.
+type EmailEvent struct {
+	Type       string    `json:"type"`
+	Source     string    `json:"source"`
+	CampaignID int       `json:"campaign_id,omitempty"`
+	MessageID  string    `json:"message_id,omitempty"`
+	Subject    string    `json:"subject,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+type campaignStatEvent struct {
+	CampaignID int    `json:"campaignId"`
+	EventTime  string `json:"eventTime"`
+}
+
+type contactCampaignStats struct {
+	MessagesSent []campaignStatEvent `json:"messagesSent"`
+	Delivered    []campaignStatEvent `json:"delivered"`
+	Opened       []campaignStatEvent `json:"opened"`
+	Clicked      []campaignStatEvent `json:"clicked"`
+	HardBounces  []campaignStatEvent `json:"hardBounces"`
+	SoftBounces  []campaignStatEvent `json:"softBounces"`
+}
+
+type smtpEvent struct {
+	Email     string `json:"email"`
+	Date      string `json:"date"`
+	Subject   string `json:"subject"`
+	MessageID string `json:"messageId"`
+	Event     string `json:"event"`
+	Reason    string `json:"reason"`
+}
+
+type smtpEventsResponse struct {
+	Events []smtpEvent `json:"events"`
+}
+
+// Transactional event names mapped onto our event types.
+var smtpEventTypes = map[string]string{
+	"requests":      EmailEventSent,
+	"delivered":     EmailEventDelivered,
+	"opened":        EmailEventOpened,
+	"loadedByProxy": EmailEventOpened,
+	"clicks":        EmailEventClicked,
+	"hardBounces":   EmailEventBounced,
+	"softBounces":   EmailEventBounced,
+	"bounces":       EmailEventBounced,
+}
+
+// GetContactEmailHistory returns every campaign and transactional email
+// event recorded for email, oldest first.
+func (b *BrevoService) GetContactEmailHistory(email string) ([]EmailEvent, error) {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	events, err := b.getContactCampaignEvents(email)
+	if err != nil {
+		return nil, err
+	}
+
+	transactional, err := b.getContactTransactionalEvents(email)
+	if err != nil {
+		return nil, err
+	}
+
+	events = append(events, transactional...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+
+	log.Printf("Found %d email events for %s", len(events), email)
+	return events, nil
+}
+
+func (b *BrevoService) getContactCampaignEvents(email string) ([]EmailEvent, error) {
+	reqURL := fmt.Sprintf("https://api.brevo.com/v3/contacts/%s/campaignStats", url.PathEscape(email))
+
+	body, statusCode, err := b.getWithRateLimitRetry(reqURL)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching campaign stats for %s: %w", email, err)
+	}
+
+	if statusCode == http.StatusNotFound {
+		return []EmailEvent{}, nil
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch campaign stats for %s: status %d - %s", email, statusCode, string(body))
+	}
+
+	var stats contactCampaignStats
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return nil, fmt.Errorf("failed to decode campaign stats for %s: %w", email, err)
+	}
+
+	events := []EmailEvent{}
+	groups := []struct {
+		eventType string
+		entries   []campaignStatEvent
+	}{
+		{EmailEventSent, stats.MessagesSent},
+		{EmailEventDelivered, stats.Delivered},
+		{EmailEventOpened, stats.Opened},
+		{EmailEventClicked, stats.Clicked},
+		{EmailEventBounced, stats.HardBounces},
+		{EmailEventBounced, stats.SoftBounces},
+	}
+
+	for _, group := range groups {
+		for _, entry := range group.entries {
+			events = append(events, EmailEvent{
+				Type:       group.eventType,
+				Source:     "campaign",
+				CampaignID: entry.CampaignID,
+				Timestamp:  parseBrevoTime(entry.EventTime),
+			})
+		}
+	}
+
+	return events, nil
+}
+
+func (b *BrevoService) getContactTransactionalEvents(email string) ([]EmailEvent, error) {
+	events := []EmailEvent{}
+
+	err := forEachPage(b.context(), smtpEventsPageSize, 0, func(limit, offset int) ([]smtpEvent, int, error) {
+		reqURL := fmt.Sprintf("https://api.brevo.com/v3/smtp/statistics/events?email=%s&limit=%d&offset=%d&sort=asc",
+			url.QueryEscape(email), limit, offset)
+
+		body, statusCode, err := b.getWithRateLimitRetry(reqURL)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error fetching transactional events for %s: %w", email, err)
+		}
+
+		if statusCode != http.StatusOK {
+			return nil, 0, fmt.Errorf("failed to fetch transactional events for %s: status %d - %s", email, statusCode, string(body))
+		}
+
+		var eventsResp smtpEventsResponse
+		if err := json.Unmarshal(body, &eventsResp); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode transactional events for %s: %w", email, err)
+		}
+
+		return eventsResp.Events, 0, nil
+	}, func(page []smtpEvent, offset int) error {
+		for _, event := range page {
+			eventType, ok := smtpEventTypes[event.Event]
+			if !ok {
+				continue
+			}
+
+			events = append(events, EmailEvent{
+				Type:      eventType,
+				Source:    "transactional",
+				MessageID: event.MessageID,
+				Subject:   event.Subject,
+				Reason:    event.Reason,
+				Timestamp: parseBrevoTime(event.Date),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// getWithRateLimitRetry GETs reqURL, backing off per the retry policy while
+// Brevo answers 429.
+func (b *BrevoService) getWithRateLimitRetry(reqURL string) ([]byte, int, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := b.makeAPIRequest(opFetch, "GET", reqURL, nil)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= b.config.Retry.MaxAttempts {
+			return body, resp.StatusCode, nil
+		}
+
+		log.Printf("Rate limited fetching %s. Retrying in %s...", reqURL, b.config.Retry.backoff(attempt))
+		if !b.wait(attempt) {
+			return body, resp.StatusCode, b.context().Err()
+		}
+	}
+}
+
+// parseBrevoTime parses Brevo's RFC 3339 timestamps, returning the zero time
+// for values it cannot read.
+func parseBrevoTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,368 @@
+package brevo
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	styleBlockPattern  = regexp.MustCompile(`(?is)<style\b[^>]*>(.*?)</style\s*>`)
+	cssCommentPattern  = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	startTagPattern    = regexp.MustCompile(`(?s)<([a-zA-Z][a-zA-Z0-9]*)((?:"[^"]*"|'[^']*'|[^'">])*)>`)
+	anyTagPattern      = regexp.MustCompile(`(?s)<(/?)([a-zA-Z][a-zA-Z0-9]*)((?:"[^"]*"|'[^']*'|[^'">])*)>`)
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// Only single compound selectors such as "td", ".button", "p.note" or
+	// "#footer" are inlined. Anything else stays in a <style> block.
+	simpleSelectorPattern = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9]*)?((?:\.[\w-]+)*)(#[\w-]+)?$`)
+
+	rawTextTags = []string{"pre", "textarea", "script", "style"}
+)
+
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+// blockElements never render the whitespace next to them, so minifyHTML
+// drops it there instead of keeping a space.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "body": true, "br": true,
+	"center": true, "div": true, "footer": true, "form": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "head": true, "header": true, "hr": true, "html": true,
+	"li": true, "link": true, "meta": true, "nav": true, "ol": true, "p": true, "section": true,
+	"table": true, "tbody": true, "td": true, "tfoot": true, "th": true, "thead": true,
+	"title": true, "tr": true, "ul": true,
+}
+
+// Tags no style is ever inlined into.
+var nonVisualTags = map[string]bool{
+	"html": true, "head": true, "title": true, "meta": true, "link": true, "style": true, "script": true, "base": true,
+}
+
+type cssRule struct {
+	tag         string
+	classes     []string
+	id          string
+	specificity int
+	order       int
+	decls       string
+}
+
+// processCampaignHTML applies the optional CSS inlining and minification of
+// cfg and checks that the result is still well-formed.
+func processCampaignHTML(htmlContent string, cfg CampaignConfig) (string, error) {
+	if !cfg.InlineCSS && !cfg.MinifyHTML {
+		return htmlContent, nil
+	}
+
+	processed := htmlContent
+	if cfg.InlineCSS {
+		processed = inlineCSS(processed)
+	}
+	if cfg.MinifyHTML {
+		processed = minifyHTML(processed)
+	}
+
+	// Templates that were never balanced are not blamed on processing.
+	if err := checkTagBalance(processed); err != nil && checkTagBalance(htmlContent) == nil {
+		return "", fmt.Errorf("processed HTML is no longer well-formed: %w", err)
+	}
+
+	return processed, nil
+}
+
+// inlineCSS moves rules with simple selectors from <style> blocks into the
+// style attribute of every matching element. Existing inline styles take
+// precedence. Rules it cannot inline, such as @media blocks, pseudo-classes
+// and descendant selectors, are kept in a single <style> block.
+func inlineCSS(htmlContent string) string {
+	blocks := styleBlockPattern.FindAllStringSubmatchIndex(htmlContent, -1)
+	if len(blocks) == 0 {
+		return htmlContent
+	}
+
+	var rules []cssRule
+	var kept []string
+	for _, block := range blocks {
+		css := cssCommentPattern.ReplaceAllString(htmlContent[block[2]:block[3]], "")
+		blockRules, blockKept := parseCSS(css, len(rules))
+		rules = append(rules, blockRules...)
+		kept = append(kept, blockKept...)
+	}
+
+	// Rebuild without the style blocks, keeping the leftovers where the
+	// first block was.
+	var out strings.Builder
+	last := 0
+	for i, block := range blocks {
+		out.WriteString(htmlContent[last:block[0]])
+		if i == 0 && len(kept) > 0 {
+			out.WriteString("<style>" + strings.Join(kept, "\n") + "</style>")
+		}
+		last = block[1]
+	}
+	out.WriteString(htmlContent[last:])
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].specificity != rules[j].specificity {
+			return rules[i].specificity < rules[j].specificity
+		}
+		return rules[i].order < rules[j].order
+	})
+
+	return startTagPattern.ReplaceAllStringFunc(out.String(), func(tag string) string {
+		return applyRules(tag, rules)
+	})
+}
+
+// parseCSS splits css into inlinable rules and the source of rules that must
+// stay in a stylesheet.
+func parseCSS(css string, order int) ([]cssRule, []string) {
+	var rules []cssRule
+	var kept []string
+
+	for rest := strings.TrimSpace(css); rest != ""; rest = strings.TrimSpace(rest) {
+		open := strings.Index(rest, "{")
+		if open < 0 {
+			break
+		}
+
+		if strings.HasPrefix(rest, "@") {
+			end := matchingBrace(rest, open)
+			kept = append(kept, rest[:end])
+			rest = rest[end:]
+			continue
+		}
+
+		end := strings.Index(rest[open:], "}")
+		if end < 0 {
+			kept = append(kept, rest)
+			break
+		}
+		end += open
+
+		selectors := rest[:open]
+		decls := strings.TrimSpace(rest[open+1 : end])
+		rest = rest[end+1:]
+
+		for _, selector := range strings.Split(selectors, ",") {
+			selector = strings.TrimSpace(selector)
+			match := simpleSelectorPattern.FindStringSubmatch(selector)
+			if selector == "" || match == nil {
+				kept = append(kept, fmt.Sprintf("%s{%s}", selector, decls))
+				continue
+			}
+
+			rule := cssRule{tag: strings.ToLower(match[1]), id: strings.TrimPrefix(match[3], "#"), order: order, decls: decls}
+			if match[2] != "" {
+				rule.classes = strings.Split(strings.TrimPrefix(match[2], "."), ".")
+			}
+			rule.specificity = len(rule.classes) * 10
+			if rule.id != "" {
+				rule.specificity += 100
+			}
+			if rule.tag != "" {
+				rule.specificity++
+			}
+
+			rules = append(rules, rule)
+			order++
+		}
+	}
+
+	return rules, kept
+}
+
+// matchingBrace returns the index just past the brace closing the one at
+// open.
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(s)
+}
+
+func applyRules(tag string, rules []cssRule) string {
+	match := startTagPattern.FindStringSubmatch(tag)
+	name := strings.ToLower(match[1])
+	attrs := match[2]
+	if nonVisualTags[name] {
+		return tag
+	}
+
+	classes := strings.Fields(attributeValue(attrs, "class"))
+	id := attributeValue(attrs, "id")
+
+	var decls []string
+	for _, rule := range rules {
+		if rule.matches(name, classes, id) && rule.decls != "" {
+			decls = append(decls, strings.TrimSuffix(rule.decls, ";"))
+		}
+	}
+	if len(decls) == 0 {
+		return tag
+	}
+
+	style := strings.Join(decls, ";")
+	if existing := attributeValue(attrs, "style"); existing != "" {
+		style += ";" + strings.TrimSuffix(existing, ";")
+	}
+	style = strings.ReplaceAll(style, `"`, "'")
+
+	selfClosing := strings.HasSuffix(strings.TrimSpace(attrs), "/")
+	attrs = strings.TrimSuffix(strings.TrimSpace(attrs), "/")
+	attrs = attributePattern("style").ReplaceAllString(attrs, "")
+	attrs = strings.TrimSpace(attrs)
+
+	var out strings.Builder
+	out.WriteString("<" + match[1])
+	if attrs != "" {
+		out.WriteString(" " + attrs)
+	}
+	out.WriteString(` style="` + style + `"`)
+	if selfClosing {
+		out.WriteString(" /")
+	}
+	out.WriteString(">")
+	return out.String()
+}
+
+func (r cssRule) matches(tag string, classes []string, id string) bool {
+	if r.tag != "" && r.tag != tag {
+		return false
+	}
+	if r.id != "" && r.id != id {
+		return false
+	}
+	for _, want := range r.classes {
+		found := false
+		for _, class := range classes {
+			if class == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func attributePattern(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)(?:^|\s)` + name + `\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+}
+
+func attributeValue(attrs string, name string) string {
+	match := attributePattern(name).FindStringSubmatch(attrs)
+	if match == nil {
+		return ""
+	}
+	return match[1] + match[2] + match[3]
+}
+
+// minifyHTML strips comments, except Outlook conditional comments, and
+// collapses whitespace, leaving pre, textarea, script and style content
+// untouched.
+func minifyHTML(htmlContent string) string {
+	var protected []string
+	for _, tag := range rawTextTags {
+		pattern := regexp.MustCompile(`(?is)<` + tag + `\b.*?</` + tag + `\s*>`)
+		htmlContent = pattern.ReplaceAllStringFunc(htmlContent, func(block string) string {
+			protected = append(protected, block)
+			return fmt.Sprintf("\x00%d\x00", len(protected)-1)
+		})
+	}
+
+	htmlContent = htmlCommentPattern.ReplaceAllStringFunc(htmlContent, func(comment string) string {
+		if strings.HasPrefix(comment, "<!--[if") || strings.HasPrefix(comment, "<![endif]") || strings.Contains(comment, "<![endif]") {
+			return comment
+		}
+		return ""
+	})
+
+	htmlContent = collapseTagWhitespace(htmlContent)
+	htmlContent = regexp.MustCompile(`\s+`).ReplaceAllString(htmlContent, " ")
+	htmlContent = strings.TrimSpace(htmlContent)
+
+	for i, block := range protected {
+		htmlContent = strings.Replace(htmlContent, fmt.Sprintf("\x00%d\x00", i), block, 1)
+	}
+
+	return htmlContent
+}
+
+// collapseTagWhitespace drops the whitespace between two tags when either
+// is block-level. Between inline elements, such as images or links laid
+// out on separate lines, it is a visible gap and stays for the caller to
+// collapse to one space.
+func collapseTagWhitespace(htmlContent string) string {
+	tags := anyTagPattern.FindAllStringSubmatchIndex(htmlContent, -1)
+
+	var out strings.Builder
+	last := 0
+	for i := 1; i < len(tags); i++ {
+		gapStart, gapEnd := tags[i-1][1], tags[i][0]
+		if gapStart >= gapEnd || strings.TrimSpace(htmlContent[gapStart:gapEnd]) != "" {
+			continue
+		}
+
+		before := strings.ToLower(htmlContent[tags[i-1][4]:tags[i-1][5]])
+		after := strings.ToLower(htmlContent[tags[i][4]:tags[i][5]])
+		if !blockElements[before] && !blockElements[after] {
+			continue
+		}
+
+		out.WriteString(htmlContent[last:gapStart])
+		last = gapEnd
+	}
+	out.WriteString(htmlContent[last:])
+
+	return out.String()
+}
+
+// checkTagBalance reports the first closing tag that does not match the
+// element it should close, or an element left open at the end.
+func checkTagBalance(htmlContent string) error {
+	for _, tag := range rawTextTags[2:] {
+		pattern := regexp.MustCompile(`(?is)(<` + tag + `\b[^>]*>).*?(</` + tag + `\s*>)`)
+		htmlContent = pattern.ReplaceAllString(htmlContent, "$1$2")
+	}
+	htmlContent = htmlCommentPattern.ReplaceAllString(htmlContent, "")
+
+	var stack []string
+	for _, match := range anyTagPattern.FindAllStringSubmatch(htmlContent, -1) {
+		closing := match[1] == "/"
+		name := strings.ToLower(match[2])
+
+		if voidElements[name] || strings.HasSuffix(strings.TrimSpace(match[3]), "/") {
+			continue
+		}
+
+		if !closing {
+			stack = append(stack, name)
+			continue
+		}
+
+		if len(stack) == 0 || stack[len(stack)-1] != name {
+			return fmt.Errorf("unexpected </%s>", name)
+		}
+		stack = stack[:len(stack)-1]
+	}
+
+	if len(stack) > 0 {
+		return fmt.Errorf("<%s> is never closed", stack[len(stack)-1])
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package brevo
+
+import "testing"
+
+func TestMinifyHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "indentation between block tags is dropped",
+			html: "<table>\n  <tr>\n    <td>Hi</td>\n  </tr>\n</table>",
+			want: "<table><tr><td>Hi</td></tr></table>",
+		},
+		{
+			name: "line break between inline images keeps a space",
+			html: "<p>\n  <img src=\"a.png\">\n  <img src=\"b.png\">\n</p>",
+			want: "<p><img src=\"a.png\"> <img src=\"b.png\"></p>",
+		},
+		{
+			name: "words split across lines inside links keep their space",
+			html: "<a href=\"#\">Read</a>\n<a href=\"#\">more</a>",
+			want: "<a href=\"#\">Read</a> <a href=\"#\">more</a>",
+		},
+		{
+			name: "comments go, conditional comments stay",
+			html: "<div><!-- note --><!--[if mso]><table><![endif]--></div>",
+			want: "<div><!--[if mso]><table><![endif]--></div>",
+		},
+		{
+			name: "pre content is untouched",
+			html: "<div><pre>  a\n   b</pre></div>",
+			want: "<div><pre>  a\n   b</pre></div>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := minifyHTML(tt.html); got != tt.want {
+				t.Errorf("minifyHTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
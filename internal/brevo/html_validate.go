@@ -0,0 +1,115 @@
+package brevo
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+)
+
+// ErrHTMLValidation is returned, wrapped, when strict HTML validation finds
+// errors in a campaign body.
+var ErrHTMLValidation = errors.New("campaign HTML failed validation")
+
+const (
+	IssueWarning string = "warning"
+	IssueError   string = "error"
+)
+
+// Issue is one problem ValidateCampaignHTML found.
+type Issue struct {
+	Severity string `json:"severity"`
+	Check    string `json:"check"`
+	Message  string `json:"message"`
+}
+
+var (
+	placeholderPattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+	// Brevo fills these tags itself when it sends.
+	brevoTagPattern = regexp.MustCompile(`^(contact|params)\.[A-Za-z0-9_]+$|^(unsubscribe|mirror|update_profile)$`)
+	insecureLink    = regexp.MustCompile(`(?i)\b(href|src)\s*=\s*["']?(http://[^"'\s>]+)`)
+	imgTagPattern   = regexp.MustCompile(`(?is)<img\b[^>]*>`)
+	altAttrPattern  = regexp.MustCompile(`(?i)\salt\s*=\s*("[^"]*\S[^"]*"|'[^']*\S[^']*'|[^\s"'>]+)`)
+	unsubscribeTag  = regexp.MustCompile(`\{\{\s*unsubscribe\s*\}\}`)
+)
+
+// ValidateCampaignHTML runs lightweight checks on a campaign body: unresolved
+// template placeholders and a missing unsubscribe tag are errors, plain
+// http:// links and images without alt text are warnings.
+func ValidateCampaignHTML(html string) []Issue {
+	var issues []Issue
+
+	seen := make(map[string]bool)
+	for _, match := range placeholderPattern.FindAllStringSubmatch(html, -1) {
+		if brevoTagPattern.MatchString(match[1]) || seen[match[0]] {
+			continue
+		}
+		seen[match[0]] = true
+		issues = append(issues, Issue{
+			Severity: IssueError,
+			Check:    "placeholder",
+			Message:  fmt.Sprintf("unresolved placeholder %s", match[0]),
+		})
+	}
+
+	for _, match := range insecureLink.FindAllStringSubmatch(html, -1) {
+		if match[2] == "http://" || seen[match[2]] {
+			continue
+		}
+		seen[match[2]] = true
+		issues = append(issues, Issue{
+			Severity: IssueWarning,
+			Check:    "insecure-link",
+			Message:  fmt.Sprintf("non-https %s %s", strings.ToLower(match[1]), match[2]),
+		})
+	}
+
+	missingAlt := 0
+	for _, img := range imgTagPattern.FindAllString(html, -1) {
+		if !altAttrPattern.MatchString(img) {
+			missingAlt++
+		}
+	}
+	if missingAlt > 0 {
+		issues = append(issues, Issue{
+			Severity: IssueWarning,
+			Check:    "image-alt",
+			Message:  fmt.Sprintf("%d images have no alt text", missingAlt),
+		})
+	}
+
+	if !unsubscribeTag.MatchString(html) {
+		issues = append(issues, Issue{
+			Severity: IssueError,
+			Check:    "unsubscribe",
+			Message:  "no {{ unsubscribe }} link",
+		})
+	}
+
+	return issues
+}
+
+// checkCampaignHTML validates htmlContent when cfg asks for it, logging each
+// issue. In strict mode any error-level issue fails with ErrHTMLValidation.
+func checkCampaignHTML(htmlContent string, cfg CampaignConfig) ([]Issue, error) {
+	if !cfg.ValidateHTML && !cfg.StrictHTML {
+		return nil, nil
+	}
+
+	issues := ValidateCampaignHTML(htmlContent)
+
+	var failed []string
+	for _, issue := range issues {
+		log.Printf("Campaign HTML %s (%s): %s", issue.Severity, issue.Check, issue.Message)
+		if issue.Severity == IssueError {
+			failed = append(failed, issue.Message)
+		}
+	}
+
+	if cfg.StrictHTML && len(failed) > 0 {
+		return issues, fmt.Errorf("%w: %s", ErrHTMLValidation, strings.Join(failed, "; "))
+	}
+
+	return issues, nil
+}
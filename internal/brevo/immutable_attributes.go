@@ -0,0 +1,39 @@
+package brevo
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// applyImmutable removes each ImmutableAttributes entry from attributes when
+// the existing contact already holds a value for it, so manually curated
+// data survives a sync. before is the contact's current attributes; when
+// known is false they could not be fetched and every immutable attribute is
+// left out. It returns the preserved attribute names.
+func (b *BrevoService) applyImmutable(email string, attributes map[string]any, before map[string]any, known bool) []string {
+	var preserved []string
+
+	for _, name := range csvFilledAttributes(b.config.ContactPerson.Attribute) {
+		if !b.config.ImmutableAttributes[name] {
+			continue
+		}
+		if _, ok := attributes[name]; !ok {
+			continue
+		}
+		if known {
+			if value, ok := before[name]; !ok || value == nil || fmt.Sprint(value) == "" {
+				continue
+			}
+		}
+
+		delete(attributes, name)
+		preserved = append(preserved, name)
+	}
+
+	if len(preserved) > 0 {
+		log.Printf("Preserving %s of %s: immutable and already set", strings.Join(preserved, ", "), email)
+	}
+
+	return preserved
+}
@@ -0,0 +1,50 @@
+package brevo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBulkImportPreservesOnlySetImmutableAttributes(t *testing.T) {
+	service, mock := newRunService(t)
+	service.config.ImmutableAttributes = map[string]bool{"COMPANY_NAME": true}
+	mock.existing = []BrevoContact{
+		{Email: "curated@example.com", Attributes: map[string]any{"COMPANY_NAME": "Curated Ltd"}},
+		{Email: "blank@example.com", Attributes: map[string]any{"COMPANY_NAME": ""}},
+	}
+
+	opts := service.config.Process
+	opts.Decoder = HeaderDecoder{}
+	opts.SkipCampaign = true
+	opts.BulkImport = true
+
+	csvPath := writeCSV(t, "email,vendor_name", "curated@example.com,Acme", "blank@example.com,Beta", "new@example.com,Gamma")
+	results, err := service.ProcessCSVAndSendCampaign(csvPath, opts)
+	if err != nil {
+		t.Fatalf("ProcessCSVAndSendCampaign() error = %v", err)
+	}
+
+	sent := make(map[string]any)
+	for _, contact := range mock.imported {
+		sent[contact.Email] = contact.Attributes["COMPANY_NAME"]
+	}
+	want := map[string]any{"curated@example.com": nil, "blank@example.com": "Beta", "new@example.com": "Gamma"}
+	for email, value := range want {
+		if sent[email] != value {
+			t.Errorf("imported COMPANY_NAME of %s = %v, want %v", email, sent[email], value)
+		}
+	}
+
+	if len(results.UpdatedContacts) != 2 {
+		t.Fatalf("updated = %+v, want both existing contacts", results.UpdatedContacts)
+	}
+	for _, contact := range results.UpdatedContacts {
+		wantPreserved := []string(nil)
+		if contact.Email == "curated@example.com" {
+			wantPreserved = []string{"COMPANY_NAME"}
+		}
+		if !slices.Equal(contact.Preserved, wantPreserved) {
+			t.Errorf("preserved of %s = %v, want %v", contact.Email, contact.Preserved, wantPreserved)
+		}
+	}
+}
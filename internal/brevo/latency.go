@@ -0,0 +1,56 @@
+package brevo
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// LatencyStats summarizes how long AddContact calls took, in milliseconds.
+type LatencyStats struct {
+	Count int     `json:"count"`
+	MinMs float64 `json:"min_ms"`
+	AvgMs float64 `json:"avg_ms"`
+	P50Ms float64 `json:"p50_ms"`
+	P95Ms float64 `json:"p95_ms"`
+	MaxMs float64 `json:"max_ms"`
+}
+
+func computeLatencyStats(durations []time.Duration) LatencyStats {
+	if len(durations) == 0 {
+		return LatencyStats{}
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	return LatencyStats{
+		Count: len(sorted),
+		MinMs: milliseconds(sorted[0]),
+		AvgMs: milliseconds(total / time.Duration(len(sorted))),
+		P50Ms: milliseconds(percentile(sorted, 50)),
+		P95Ms: milliseconds(percentile(sorted, 95)),
+		MaxMs: milliseconds(sorted[len(sorted)-1]),
+	}
+}
+
+// percentile returns the nearest-rank percentile p (0-100] of sorted.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = max(1, min(rank, len(sorted)))
+
+	return sorted[rank-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,101 @@
+package brevo
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func ms(values ...int) []time.Duration {
+	durations := make([]time.Duration, len(values))
+	for i, v := range values {
+		durations[i] = time.Duration(v) * time.Millisecond
+	}
+	return durations
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		p      float64
+		want   time.Duration
+	}{
+		{name: "empty", p: 50, want: 0},
+		{name: "single value", sorted: ms(7), p: 95, want: 7 * time.Millisecond},
+		{name: "median of odd count", sorted: ms(1, 2, 3, 4, 5), p: 50, want: 3 * time.Millisecond},
+		{name: "median of even count takes the lower rank", sorted: ms(1, 2, 3, 4), p: 50, want: 2 * time.Millisecond},
+		{name: "p95 of 20 values", sorted: ms(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20), p: 95, want: 19 * time.Millisecond},
+		{name: "p95 of 10 values is the max", sorted: ms(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), p: 95, want: 10 * time.Millisecond},
+		{name: "p100 is the max", sorted: ms(1, 2, 3), p: 100, want: 3 * time.Millisecond},
+		{name: "tiny p is the min", sorted: ms(1, 2, 3), p: 1, want: 1 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.sorted, tt.p); got != tt.want {
+				t.Errorf("percentile(%v, %v) = %s, want %s", tt.sorted, tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeLatencyStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		want      LatencyStats
+	}{
+		{name: "no calls", want: LatencyStats{}},
+		{
+			name:      "unsorted input",
+			durations: ms(40, 10, 30, 20),
+			want:      LatencyStats{Count: 4, MinMs: 10, AvgMs: 25, P50Ms: 20, P95Ms: 40, MaxMs: 40},
+		},
+		{
+			name:      "one slow outlier",
+			durations: ms(10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 1000),
+			want:      LatencyStats{Count: 20, MinMs: 10, AvgMs: 59.5, P50Ms: 10, P95Ms: 10, MaxMs: 1000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]time.Duration(nil), tt.durations...)
+
+			if got := computeLatencyStats(tt.durations); got != tt.want {
+				t.Errorf("computeLatencyStats() = %+v, want %+v", got, tt.want)
+			}
+			for i := range input {
+				if input[i] != tt.durations[i] {
+					t.Fatalf("computeLatencyStats() reordered its input")
+				}
+			}
+		})
+	}
+}
+
+func TestRunReportsContactLatency(t *testing.T) {
+	service, mock := newRunService(t)
+	mock.onContact = func(w http.ResponseWriter, r *http.Request, payload ContactPayload) bool {
+		time.Sleep(20 * time.Millisecond)
+		return false
+	}
+
+	opts := service.config.Process
+	opts.Decoder = HeaderDecoder{}
+	opts.SkipCampaign = true
+
+	results, err := service.ProcessCSVAndSendCampaign(writeCSV(t, "email,vendor_name", "a@example.com,A", "b@example.com,B", ",C"), opts)
+	if err != nil {
+		t.Fatalf("ProcessCSVAndSendCampaign() error = %v", err)
+	}
+
+	latency := results.ContactLatency
+	if latency.Count != 2 {
+		t.Errorf("latency count = %d, want 2 (rows without email make no call)", latency.Count)
+	}
+	if latency.MinMs < 20 || latency.MaxMs < latency.P95Ms || latency.P95Ms < latency.P50Ms || latency.P50Ms < latency.MinMs {
+		t.Errorf("latency stats %+v are not ordered min <= p50 <= p95 <= max with a 20ms floor", latency)
+	}
+}
@@ -0,0 +1,61 @@
+package brevo
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+)
+
+// maxListDescriptionLength caps a list description at the length of a
+// Brevo text field.
+const maxListDescriptionLength int = 255
+
+// ListDescriptionData is the run context a ListDescriptionTemplate can
+// reference, e.g. "Imported from {{.CSVName}} by run {{.RunID}}".
+type ListDescriptionData struct {
+	CSVName string
+	Folder  string
+	RunID   string
+	Date    string
+}
+
+func parseListDescriptionTemplate(source string) (*template.Template, error) {
+	if source == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New("list-description").Option("missingkey=error").Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid list description template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// listDescription renders the description of the run's list, or "" when no
+// template is configured. Descriptions longer than Brevo accepts are cut
+// with a warning rather than failing the run.
+func (b *BrevoService) listDescription(data ListDescriptionData) (string, error) {
+	if b.listDescriptionTemplate == nil {
+		return "", nil
+	}
+
+	if data.Date == "" {
+		data.Date = b.now().Format("2006-01-02")
+	}
+
+	var buf bytes.Buffer
+	if err := b.listDescriptionTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render list description: %w", err)
+	}
+
+	description := strings.TrimSpace(buf.String())
+	if runes := []rune(description); len(runes) > maxListDescriptionLength {
+		log.Printf("Warning: List description is %d characters, cutting it to Brevo's %d", len(runes), maxListDescriptionLength)
+		description = string(runes[:maxListDescriptionLength])
+	}
+
+	return description, nil
+}
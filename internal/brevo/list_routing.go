@@ -0,0 +1,348 @@
+package brevo
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ListRouting sends each contact to a list picked by the value of one CSV
+// column, in addition to the run's list, e.g. one list per CATEGORY.
+type ListRouting struct {
+	// Column is a CSV column name such as "category" or "city".
+	Column string
+	// ListIDs maps column values, case-insensitively, to existing lists.
+	// Values without an entry get a new list named after the run's list and
+	// the value, created in the run's folder.
+	ListIDs map[string]int
+	// ListNames maps column values to list names, usually loaded from a
+	// routing file with LoadListRoutes. The lists are created in the run's
+	// folder, or reused when one of that name already exists.
+	ListNames map[string]string
+	// RequireMatch rejects values found in neither ListIDs nor ListNames
+	// instead of creating a list for them. Such contacts only go to the
+	// run's list and are reported as routing errors.
+	RequireMatch bool
+	// PerListCampaigns creates and sends one campaign per routed list
+	// instead of the single campaign to the run's list. When false the
+	// campaign step is skipped so the segments can be mailed by hand.
+	PerListCampaigns bool
+}
+
+// RoutingDecision records where one contact was routed.
+type RoutingDecision struct {
+	Row      int    `json:"row,omitempty"`
+	Email    string `json:"email"`
+	Value    string `json:"value"`
+	ListID   int    `json:"list_id,omitempty"`
+	ListName string `json:"list_name,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// RoutedCampaign is the campaign created for one routed list.
+type RoutedCampaign struct {
+	Value      string             `json:"value"`
+	ListID     int                `json:"list_id"`
+	Recipients int                `json:"recipients"`
+	Campaign   CampaignResult     `json:"campaign"`
+	Send       SendCampaignResult `json:"send"`
+	// Pending is set instead of Send when the campaign is above the
+	// confirmation threshold and waits for ConfirmAndSend.
+	Pending *PendingConfirmation `json:"pending_confirmation,omitempty"`
+}
+
+// listRouter resolves, and creates when needed, the routed lists of one run.
+type listRouter struct {
+	routing  ListRouting
+	column   int
+	base     ContactList
+	folderID int
+	lists    map[string]int
+	names    map[string]string
+	values   map[int]string
+	listName map[int]string
+}
+
+// Validate checks that the column exists and that no value is routed both
+// by ID and by name.
+func (routing ListRouting) Validate() error {
+	if routingColumn(routing.Column) < 0 {
+		return fmt.Errorf("unknown list routing column '%s'", routing.Column)
+	}
+
+	for value := range routing.ListNames {
+		for other := range routing.ListIDs {
+			if strings.EqualFold(strings.TrimSpace(value), strings.TrimSpace(other)) {
+				return fmt.Errorf("value '%s' is routed both by list ID and by list name", value)
+			}
+		}
+	}
+
+	if routing.RequireMatch && len(routing.ListIDs) == 0 && len(routing.ListNames) == 0 {
+		return fmt.Errorf("matching is required but no routes are configured")
+	}
+
+	return nil
+}
+
+// routingColumn returns the csvColumns index of name, or -1.
+func routingColumn(name string) int {
+	for i, column := range csvColumns {
+		if column == normalizeHeaderName(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func newListRouter(routing ListRouting, base ContactList) (*listRouter, error) {
+	if err := routing.Validate(); err != nil {
+		return nil, err
+	}
+	column := routingColumn(routing.Column)
+
+	r := &listRouter{
+		routing:  routing,
+		column:   column,
+		base:     base,
+		folderID: base.FolderID,
+		lists:    make(map[string]int, len(routing.ListIDs)),
+		names:    make(map[string]string, len(routing.ListNames)),
+		values:   make(map[int]string, len(routing.ListIDs)),
+		listName: make(map[int]string),
+	}
+	for value, listID := range routing.ListIDs {
+		key := strings.ToLower(strings.TrimSpace(value))
+		r.lists[key] = listID
+		r.values[listID] = key
+	}
+	for value, name := range routing.ListNames {
+		r.names[strings.ToLower(strings.TrimSpace(value))] = name
+	}
+
+	return r, nil
+}
+
+// routedList returns the list data is routed to, or 0 when its column is
+// empty.
+func (b *BrevoService) routedList(r *listRouter, data *CSVData) (int, error) {
+	value := strings.TrimSpace(*data.columnFields()[r.column])
+	if value == "" {
+		return 0, nil
+	}
+
+	key := strings.ToLower(value)
+	if listID, ok := r.lists[key]; ok {
+		return listID, nil
+	}
+
+	name, mapped := r.names[key]
+	if !mapped {
+		if r.routing.RequireMatch {
+			return 0, fmt.Errorf("no route for %s '%s'", r.routing.Column, value)
+		}
+		name = fmt.Sprintf("%s - %s", r.base.Name, value)
+	}
+
+	if r.folderID <= 0 {
+		folderID, err := b.GetOrCreateFolder(DefaultFolderName)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get folder for routed lists: %w", err)
+		}
+		r.folderID = folderID
+	}
+
+	list, err := b.createList(name, r.folderID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create list for %s '%s': %w", r.routing.Column, value, err)
+	}
+
+	log.Printf("Routing %s '%s' to list '%s' (ID: %d)", r.routing.Column, value, name, list.ID)
+	r.lists[key] = list.ID
+	r.values[list.ID] = key
+	r.listName[list.ID] = name
+	return list.ID, nil
+}
+
+// routeContact returns the lists a row should be imported into: the run's
+// list and its routed list. The decision is recorded in results; on a
+// routing failure the row still goes to the run's list.
+func (b *BrevoService) routeContact(r *listRouter, row int, data *CSVData, listID int, results *ProcessingResults) []int {
+	if r == nil || data.Email == "" {
+		return []int{listID}
+	}
+
+	routed, err := b.routedList(r, data)
+	decision := RoutingDecision{
+		Row:      row,
+		Email:    data.Email,
+		Value:    strings.TrimSpace(*data.columnFields()[r.column]),
+		ListID:   routed,
+		ListName: r.listName[routed],
+	}
+	if err != nil {
+		decision.Error = err.Error()
+	}
+	results.Routing = append(results.Routing, decision)
+
+	if err != nil {
+		results.Errors.addRow(row, data.Email, err, "Failed to route contact")
+		return []int{listID}
+	}
+
+	if routed <= 0 || routed == listID {
+		return []int{listID}
+	}
+
+	return []int{listID, routed}
+}
+
+// routeImportedContacts adds contacts a bulk import loaded into the run's
+// list to their routed lists as well.
+func (b *BrevoService) routeImportedContacts(r *listRouter, listID int, results *ProcessingResults) {
+	emails := make(map[int][]string)
+
+	for _, contacts := range [][]ContactResult{results.AddedToCampaign, results.UpdatedContacts} {
+		for i := range contacts {
+			listIDs := b.routeContact(r, contacts[i].Row, contacts[i].Data, listID, results)
+			if len(listIDs) > 1 {
+				contacts[i].ListIDs = listIDs
+				emails[listIDs[1]] = append(emails[listIDs[1]], contacts[i].Email)
+			}
+		}
+	}
+
+	for routed, batch := range emails {
+		added, err := b.addContactsToList(routed, batch)
+		if err != nil {
+			results.Errors.Add("", err, fmt.Sprintf("Failed to add contacts to routed list %d", routed))
+			continue
+		}
+		log.Printf("Added %d of %d contacts to routed list %d", added, len(batch), routed)
+	}
+}
+
+// sendRoutedCampaigns creates and sends a campaign for every routed list
+// that received contacts in this run. Each campaign is held for
+// ConfirmAndSend on its own when it is above the confirmation threshold.
+func (b *BrevoService) sendRoutedCampaigns(r *listRouter, opts ProcessOptions, cfg CampaignConfig, meta CampaignMeta, results *ProcessingResults) []RoutedCampaign {
+	recipients := make(map[int]int)
+	for _, contacts := range [][]ContactResult{results.AddedToCampaign, results.UpdatedContacts} {
+		for _, contact := range contacts {
+			if len(contact.ListIDs) > 1 {
+				recipients[contact.ListIDs[1]]++
+			}
+		}
+	}
+
+	listIDs := make([]int, 0, len(recipients))
+	for listID := range recipients {
+		listIDs = append(listIDs, listID)
+	}
+	sort.Ints(listIDs)
+
+	campaigns := make([]RoutedCampaign, 0, len(listIDs))
+	for _, listID := range listIDs {
+		entry := RoutedCampaign{Value: r.values[listID], ListID: listID, Recipients: recipients[listID]}
+
+		entry.Campaign = b.CreateNewCampaign(listID, cfg, CampaignMeta{
+			CSVName: fmt.Sprintf("%s - %s", meta.CSVName, entry.Value),
+			Count:   entry.Recipients,
+			RunID:   meta.RunID,
+			Source:  meta.CSVName,
+		})
+		if !entry.Campaign.Success {
+			statusErr := &apiStatusError{StatusCode: entry.Campaign.StatusCode, Message: entry.Campaign.Error}
+			results.Errors.Add("", statusErr, fmt.Sprintf("Failed to create campaign for %s '%s'", r.routing.Column, entry.Value))
+			campaigns = append(campaigns, entry)
+			continue
+		}
+
+		if pending := b.pendingConfirmation(opts, entry.Campaign.CampaignID, listID, entry.Recipients); pending != nil {
+			log.Printf("Campaign %d for %s '%s' has %d recipients, above the confirmation threshold of %d. Not sending until ConfirmAndSend is called.",
+				pending.CampaignID, r.routing.Column, entry.Value, pending.Recipients, pending.Threshold)
+			entry.Pending = pending
+			campaigns = append(campaigns, entry)
+			continue
+		}
+
+		entry.Send = b.SendCampaignToContacts(entry.Campaign.CampaignID)
+		if !entry.Send.Success {
+			statusErr := &apiStatusError{StatusCode: entry.Send.StatusCode, Message: entry.Send.Error}
+			results.Errors.Add("", statusErr, fmt.Sprintf("Failed to send campaign %d for %s '%s' (resume with ResumeSend)", entry.Campaign.CampaignID, r.routing.Column, entry.Value))
+		}
+
+		campaigns = append(campaigns, entry)
+	}
+
+	return campaigns
+}
+
+// LoadListRoutes reads a routing file mapping column values to list names.
+// It is a CSV file with a "value,list" header row followed by one route per
+// row. Values are matched case-insensitively and must be unique.
+func LoadListRoutes(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open routing file: %w", err)
+	}
+	defer file.Close()
+
+	records, err := readCSVRecords(file, CSVOptions{LazyQuotes: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read routing file %s: %w", path, err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("routing file %s is empty", path)
+	}
+	if len(records[0]) != 2 {
+		return nil, fmt.Errorf("routing file %s must have exactly two columns, value and list", path)
+	}
+
+	routes := make(map[string]string, len(records)-1)
+	for i, record := range records[1:] {
+		line := i + 2
+		value := strings.TrimSpace(record[0])
+		name := strings.TrimSpace(record[1])
+
+		if value == "" || name == "" {
+			return nil, fmt.Errorf("routing file %s line %d: value and list name are required", path, line)
+		}
+
+		key := strings.ToLower(value)
+		if _, ok := routes[key]; ok {
+			return nil, fmt.Errorf("routing file %s line %d: duplicate value '%s'", path, line, value)
+		}
+		routes[key] = name
+	}
+
+	return routes, nil
+}
+
+// parseListRoutes reads "VALUE=LIST_ID,VALUE=LIST_ID" routes.
+func parseListRoutes(value string) (map[string]int, error) {
+	routes := make(map[string]int)
+	if strings.TrimSpace(value) == "" {
+		return routes, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		name, number, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected VALUE=LIST_ID, got '%s'", entry)
+		}
+
+		listID, err := strconv.Atoi(strings.TrimSpace(number))
+		if err != nil || listID <= 0 {
+			return nil, fmt.Errorf("invalid list ID in '%s'", entry)
+		}
+
+		routes[strings.TrimSpace(name)] = listID
+	}
+
+	return routes, nil
+}
@@ -0,0 +1,42 @@
+package brevo
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestExcludedRowsAreNotRouted(t *testing.T) {
+	service, _ := newRunService(t)
+
+	var listsCreated atomic.Int64
+	forward := service.httpClient.Transport
+	service.httpClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method == "POST" && r.URL.Path == "/v3/contacts/lists" {
+			listsCreated.Add(1)
+		}
+		return forward.RoundTrip(r)
+	})
+
+	opts := service.config.Process
+	opts.Decoder = HeaderDecoder{}
+	opts.SkipCampaign = true
+	opts.DomainAllowlist = []string{"example.com"}
+	opts.ListRouting = &ListRouting{Column: "category"}
+
+	csvPath := writeCSV(t, "email,category", "a@example.com,IT", "b@other.com,Legal", ",Finance")
+	results, err := service.ProcessCSVAndSendCampaign(csvPath, opts)
+	if err != nil {
+		t.Fatalf("ProcessCSVAndSendCampaign() error = %v", err)
+	}
+
+	if len(results.Routing) != 1 || results.Routing[0].Email != "a@example.com" {
+		t.Errorf("routing = %+v, want only a@example.com routed", results.Routing)
+	}
+	if got := listsCreated.Load(); got != 2 {
+		t.Errorf("lists created = %d, want the run's list and the IT list", got)
+	}
+	if len(results.Skipped) != 1 || results.Skipped[0].Email != "b@other.com" {
+		t.Errorf("skipped = %+v, want b@other.com", results.Skipped)
+	}
+}
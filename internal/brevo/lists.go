@@ -0,0 +1,370 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const (
+	// Brevo caps folder list pages at 50.
+	folderListsPageSize  int = 50
+	listContactsPageSize int = 500
+	// Brevo accepts at most 150 emails per add or remove call.
+	listRemoveBatchSize int = 150
+	listAddBatchSize    int = 150
+)
+
+type ContactList struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	FolderID          int    `json:"folderId,omitempty"`
+	TotalBlacklisted  int    `json:"totalBlacklisted"`
+	TotalSubscribers  int    `json:"totalSubscribers"`
+	UniqueSubscribers int    `json:"uniqueSubscribers"`
+	// Description is the description the run set when it created the list.
+	Description string `json:"-"`
+}
+
+type ListsResponse struct {
+	Lists []ContactList `json:"lists"`
+	Count int           `json:"count"`
+}
+
+// listMembershipResponse lists the emails, or the contact IDs when the
+// request named contacts by ID, that Brevo did and did not move.
+type listMembershipResponse struct {
+	Contacts struct {
+		Success []any `json:"success"`
+		Failure []any `json:"failure"`
+	} `json:"contacts"`
+}
+
+// ClearList removes every contact from listID while keeping the list itself,
+// so a stable list can be repopulated on each run. Contacts are removed by
+// ID, so SMS-only contacts without an email go too. It returns how many
+// contacts were removed.
+func (b *BrevoService) ClearList(listID int) (int, error) {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	removed := 0
+
+	log.Printf("Clearing all contacts from list %d...", listID)
+
+	for {
+		// Removal shrinks the list, so the first page always holds the
+		// contacts that are still left.
+		ids, err := b.getListContactIDs(listID, listContactsPageSize, 0)
+		if err != nil {
+			return removed, err
+		}
+
+		if len(ids) == 0 {
+			break
+		}
+
+		pageRemoved := 0
+
+		for start := 0; start < len(ids); start += listRemoveBatchSize {
+			end := min(start+listRemoveBatchSize, len(ids))
+
+			count, err := b.removeContactIDsFromList(listID, ids[start:end])
+			if err != nil {
+				return removed, err
+			}
+
+			pageRemoved += count
+			removed += count
+		}
+
+		log.Printf("Removed %d contacts from list %d. Total so far: %d", pageRemoved, listID, removed)
+
+		if pageRemoved == 0 {
+			return removed, fmt.Errorf("no contacts could be removed from list %d, %d remain", listID, len(ids))
+		}
+	}
+
+	log.Printf("Finished clearing list %d. Total removed: %d", listID, removed)
+	return removed, nil
+}
+
+func (b *BrevoService) getListContactIDs(listID, limit, offset int) ([]int, error) {
+	contacts, err := b.getListContactPage(listID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(contacts))
+	for _, contact := range contacts {
+		ids = append(ids, contact.ID)
+	}
+
+	return ids, nil
+}
+
+// getListContactPage returns a page of listID's contacts, SMS-only ones
+// included.
+func (b *BrevoService) getListContactPage(listID, limit, offset int) ([]BrevoContact, error) {
+	url := fmt.Sprintf("https://api.brevo.com/v3/contacts/lists/%d/contacts?limit=%d&offset=%d", listID, limit, offset)
+
+	resp, err := b.makeAPIRequest(opFetch, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching contacts of list %d at offset %d: %w", listID, offset, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error fetching list %d at offset %d: %d - %s", listID, offset, resp.StatusCode, string(body))
+	}
+
+	var contactsResp ContactsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&contactsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode list %d contacts: %w", listID, err)
+	}
+
+	return contactsResp.Contacts, nil
+}
+
+func (b *BrevoService) removeContactsFromList(listID int, emails []string) (int, error) {
+	return b.removeFromList(listID, map[string][]string{"emails": emails})
+}
+
+func (b *BrevoService) removeContactIDsFromList(listID int, ids []int) (int, error) {
+	return b.removeFromList(listID, map[string][]int{"ids": ids})
+}
+
+// removeFromList removes the contacts payload names from listID and returns
+// how many Brevo removed.
+func (b *BrevoService) removeFromList(listID int, payload any) (int, error) {
+	url := fmt.Sprintf("https://api.brevo.com/v3/contacts/lists/%d/contacts/remove", listID)
+
+	resp, err := b.makeAPIRequest(opAdd, "POST", url, payload)
+	if err != nil {
+		return 0, fmt.Errorf("exception removing contacts from list %d: %w", listID, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read list removal response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to remove contacts from list %d: status %d - %s", listID, resp.StatusCode, string(body))
+	}
+
+	var result listMembershipResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, fmt.Errorf("failed to decode list removal response: %w", err)
+	}
+
+	if len(result.Contacts.Failure) > 0 {
+		log.Printf("Failed to remove %d contacts from list %d: %v", len(result.Contacts.Failure), listID, result.Contacts.Failure)
+	}
+
+	return len(result.Contacts.Success), nil
+}
+
+// addContactsToList adds existing contacts to listID in batches and returns
+// how many Brevo accepted.
+func (b *BrevoService) addContactsToList(listID int, emails []string) (int, error) {
+	url := fmt.Sprintf("https://api.brevo.com/v3/contacts/lists/%d/contacts/add", listID)
+	added := 0
+
+	for start := 0; start < len(emails); start += listAddBatchSize {
+		end := min(start+listAddBatchSize, len(emails))
+		payload := map[string][]string{"emails": emails[start:end]}
+
+		resp, err := b.makeAPIRequest(opAdd, "POST", url, payload)
+		if err != nil {
+			return added, fmt.Errorf("exception adding contacts to list %d: %w", listID, err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return added, fmt.Errorf("failed to read list add response body: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+			return added, fmt.Errorf("failed to add contacts to list %d: status %d - %s", listID, resp.StatusCode, string(body))
+		}
+
+		var result listMembershipResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			return added, fmt.Errorf("failed to decode list add response: %w", err)
+		}
+
+		if len(result.Contacts.Failure) > 0 {
+			log.Printf("Failed to add %d contacts to list %d: %v", len(result.Contacts.Failure), listID, result.Contacts.Failure)
+		}
+
+		added += len(result.Contacts.Success)
+	}
+
+	return added, nil
+}
+
+// GetFolderLists returns every contact list inside folderID.
+func (b *BrevoService) GetFolderLists(folderID int) ([]ContactList, error) {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	lists, err := paginate(b.context(), folderListsPageSize, func(limit, offset int) ([]ContactList, int, error) {
+		url := fmt.Sprintf("https://api.brevo.com/v3/contacts/folders/%d/lists?limit=%d&offset=%d", folderID, limit, offset)
+
+		resp, err := b.makeAPIRequest(opFetch, "GET", url, nil)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error fetching lists of folder %d at offset %d: %w", folderID, offset, err)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read folder lists response body: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, 0, fmt.Errorf("failed to fetch lists of folder %d: status %d - %s", folderID, resp.StatusCode, string(body))
+		}
+
+		var listsResp ListsResponse
+		if err := json.Unmarshal(body, &listsResp); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode folder lists response: %w", err)
+		}
+
+		for i := range listsResp.Lists {
+			if listsResp.Lists[i].FolderID == 0 {
+				listsResp.Lists[i].FolderID = folderID
+			}
+		}
+
+		return listsResp.Lists, listsResp.Count, nil
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Fetched %d lists from folder %d", len(lists), folderID)
+	return lists, nil
+}
+
+func (b *BrevoService) findListInFolder(folderID int, name string) (int, error) {
+	lists, err := b.GetFolderLists(folderID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to re-fetch list '%s' after conflict: %w", name, err)
+	}
+
+	var matches []int
+	for _, list := range lists {
+		if list.Name == name && list.ID > 0 {
+			matches = append(matches, list.ID)
+		}
+	}
+
+	if len(matches) > 1 {
+		return b.resolveDuplicateLists(name, matches)
+	}
+	if len(matches) == 1 {
+		log.Printf("Using existing contact list '%s' with ID: %d", name, matches[0])
+		return matches[0], nil
+	}
+
+	return 0, fmt.Errorf("list '%s' reported as existing but was not found in folder %d", name, folderID)
+}
+
+// isConflictResponse reports whether Brevo refused a create because an object
+// with the same name already exists.
+func isConflictResponse(statusCode int, body string) bool {
+	if statusCode == http.StatusConflict {
+		return true
+	}
+
+	lower := strings.ToLower(body)
+	return statusCode == http.StatusBadRequest &&
+		(strings.Contains(lower, "duplicate_parameter") || strings.Contains(lower, "already exist"))
+}
+
+// GetExistingContactsInList returns the lowercased emails already in listID.
+// It is much cheaper than GetExistingContantsEmail when only membership of
+// the target list matters.
+func (b *BrevoService) GetExistingContactsInList(listID int) (map[string]bool, error) {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	return b.fetchExistingListContacts(listID, nil)
+}
+
+// fetchExistingListContacts is GetExistingContactsInList that also records
+// each member's opt-out state into states when it is non-nil.
+func (b *BrevoService) fetchExistingListContacts(listID int, states *contactStates) (map[string]bool, error) {
+	scope := listFetchScope(listID)
+	start, contacts := b.resumeFetch(scope, states)
+
+	log.Printf("Starting to fetch existing contacts of list %d...", listID)
+
+	err := forEachPage(b.context(), listContactsPageSize, start, func(limit, offset int) ([]BrevoContact, int, error) {
+		// The raw page, SMS-only contacts included, keeps a short page
+		// meaning the end of the list.
+		page, err := b.getListContactPage(listID, limit, offset)
+		if err != nil {
+			return nil, 0, &PartialFetchError{Offset: offset, Fetched: len(contacts), Err: err}
+		}
+		return page, 0, nil
+	}, func(page []BrevoContact, offset int) error {
+		for _, contact := range page {
+			if contact.Email == "" {
+				continue
+			}
+			contacts[strings.ToLower(contact.Email)] = true
+			states.observe(contact)
+		}
+		if len(page) == listContactsPageSize {
+			b.saveFetch(scope, offset+listContactsPageSize, contacts)
+		}
+		return nil
+	})
+	if err != nil {
+		return contacts, err
+	}
+
+	b.clearFetch()
+
+	log.Printf("Finished fetching list %d. Total: %d unique emails found", listID, len(contacts))
+	return contacts, nil
+}
+
+func (b *BrevoService) GetList(listID int) (ContactList, error) {
+	url := fmt.Sprintf("https://api.brevo.com/v3/contacts/lists/%d", listID)
+
+	resp, err := b.makeAPIRequest(opFetch, "GET", url, nil)
+	if err != nil {
+		return ContactList{}, fmt.Errorf("error fetching list %d: %w", listID, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ContactList{}, fmt.Errorf("failed to read list %d response body: %w", listID, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ContactList{}, &NotFoundError{Resource: "list", ID: listID}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ContactList{}, fmt.Errorf("failed to fetch list %d: status %d - %s", listID, resp.StatusCode, string(body))
+	}
+
+	var list ContactList
+	if err := json.Unmarshal(body, &list); err != nil {
+		return ContactList{}, fmt.Errorf("failed to decode list %d response: %w", listID, err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,270 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestCreateDescribedListRecordsDescription(t *testing.T) {
+	tests := []struct {
+		name            string
+		refuse          bool
+		wantDescription string
+		wantRequests    int
+	}{
+		{name: "description accepted", wantDescription: "Imported from winners.csv", wantRequests: 1},
+		{name: "description refused", refuse: true, wantDescription: "", wantRequests: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				var payload map[string]any
+				json.NewDecoder(r.Body).Decode(&payload)
+
+				if _, described := payload["description"]; described && tt.refuse {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"code":"invalid_parameter","message":"description is not valid"}`)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id":12}`)
+			}))
+
+			list, err := service.createDescribedList("Winners", 3, "Imported from winners.csv")
+			if err != nil {
+				t.Fatalf("createDescribedList() error = %v", err)
+			}
+			if list.ID != 12 || list.Description != tt.wantDescription {
+				t.Errorf("list = %+v, want ID 12 and description %q", list, tt.wantDescription)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestGetFolderListsPaginates(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		wantOffsets []int
+	}{
+		{name: "empty folder", total: 0, wantOffsets: []int{0}},
+		{name: "single page", total: 12, wantOffsets: []int{0}},
+		{name: "exactly one full page", total: 50, wantOffsets: []int{0}},
+		{name: "several pages", total: 120, wantOffsets: []int{0, 50, 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var offsets []int
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/contacts/folders/4/lists" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+
+				var limit, offset int
+				fmt.Sscan(r.URL.Query().Get("limit"), &limit)
+				fmt.Sscan(r.URL.Query().Get("offset"), &offset)
+				offsets = append(offsets, offset)
+
+				page := ListsResponse{Lists: []ContactList{}, Count: tt.total}
+				for i := offset; i < min(offset+limit, tt.total); i++ {
+					page.Lists = append(page.Lists, ContactList{ID: i + 1, Name: fmt.Sprintf("Winners %d", i+1)})
+				}
+				json.NewEncoder(w).Encode(page)
+			}))
+
+			lists, err := service.GetFolderLists(4)
+			if err != nil {
+				t.Fatalf("GetFolderLists() error = %v", err)
+			}
+
+			if len(lists) != tt.total {
+				t.Errorf("got %d lists, want %d", len(lists), tt.total)
+			}
+			for i, list := range lists {
+				if list.ID != i+1 || list.FolderID != 4 {
+					t.Errorf("lists[%d] = %+v, want ID %d in folder 4", i, list, i+1)
+				}
+			}
+			if fmt.Sprint(offsets) != fmt.Sprint(tt.wantOffsets) {
+				t.Errorf("requested offsets %v, want %v", offsets, tt.wantOffsets)
+			}
+		})
+	}
+}
+
+func TestGetFolderListsError(t *testing.T) {
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"code":"document_not_found"}`, http.StatusNotFound)
+	}))
+
+	if _, err := service.GetFolderLists(4); err == nil {
+		t.Errorf("GetFolderLists() succeeded for a missing folder")
+	}
+}
+
+func TestCreateFolderConflict(t *testing.T) {
+	tests := []struct {
+		name         string
+		createStatus int
+		createBody   string
+		folders      string
+		wantID       int
+		wantErr      bool
+	}{
+		{name: "created", createStatus: http.StatusCreated, createBody: `{"id":5}`, wantID: 5},
+		{name: "409 reuses the existing folder", createStatus: http.StatusConflict, folders: `[{"id":8,"name":"Winners"}]`, wantID: 8},
+		{name: "duplicate_parameter reuses the existing folder", createStatus: http.StatusBadRequest, createBody: `{"code":"duplicate_parameter"}`, folders: `[{"id":3,"name":"Other"},{"id":8,"name":"Winners"}]`, wantID: 8},
+		{name: "conflict but folder missing", createStatus: http.StatusConflict, folders: `[{"id":3,"name":"Other"}]`, wantErr: true},
+		{name: "other failure", createStatus: http.StatusBadRequest, createBody: `{"code":"invalid_parameter"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/contacts/folders" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+				if r.Method == http.MethodGet {
+					fmt.Fprintf(w, `{"folders":%s}`, tt.folders)
+					return
+				}
+				w.WriteHeader(tt.createStatus)
+				fmt.Fprint(w, tt.createBody)
+			}))
+
+			folderID, err := service.CreateFolder("Winners")
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateFolder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if folderID != tt.wantID {
+				t.Errorf("CreateFolder() = %d, want %d", folderID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestCreateListConflict(t *testing.T) {
+	tests := []struct {
+		name         string
+		createStatus int
+		createBody   string
+		lists        string
+		wantID       int
+		wantErr      bool
+	}{
+		{name: "created", createStatus: http.StatusCreated, createBody: `{"id":11}`, wantID: 11},
+		{name: "409 reuses the existing list", createStatus: http.StatusConflict, lists: `[{"id":12,"name":"Winners List"}]`, wantID: 12},
+		{name: "already exists reuses the existing list", createStatus: http.StatusBadRequest, createBody: `{"message":"List name already exists"}`, lists: `[{"id":12,"name":"Winners List"}]`, wantID: 12},
+		{name: "conflict but list missing", createStatus: http.StatusConflict, lists: `[{"id":13,"name":"Other"}]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/v3/contacts/lists":
+					w.WriteHeader(tt.createStatus)
+					fmt.Fprint(w, tt.createBody)
+				case r.Method == http.MethodGet && r.URL.Path == "/v3/contacts/folders/4/lists":
+					fmt.Fprintf(w, `{"lists":%s}`, tt.lists)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			}))
+
+			list, err := service.createList("Winners List", 4)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("createList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if list.ID != tt.wantID {
+				t.Errorf("createList() ID = %d, want %d", list.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestClearListRemovesSMSOnlyContacts(t *testing.T) {
+	members := map[int]string{1: "a@example.com", 2: "", 3: "c@example.com"}
+
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v3/contacts/lists/10/contacts":
+			var contacts []BrevoContact
+			for id := 1; id <= 3; id++ {
+				if email, ok := members[id]; ok {
+					contacts = append(contacts, BrevoContact{ID: id, Email: email})
+				}
+			}
+			json.NewEncoder(w).Encode(ContactsResponse{Contacts: contacts, Count: len(contacts)})
+		case "POST /v3/contacts/lists/10/contacts/remove":
+			var payload struct {
+				Emails []string `json:"emails"`
+				IDs    []int    `json:"ids"`
+			}
+			json.NewDecoder(r.Body).Decode(&payload)
+			if len(payload.Emails) > 0 {
+				t.Errorf("removed by email %v, want by ID", payload.Emails)
+			}
+			for _, id := range payload.IDs {
+				delete(members, id)
+			}
+			json.NewEncoder(w).Encode(map[string]any{"contacts": map[string]any{"success": payload.IDs}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	removed, err := service.ClearList(10)
+	if err != nil {
+		t.Fatalf("ClearList() error = %v", err)
+	}
+	if removed != 3 || len(members) != 0 {
+		t.Errorf("ClearList() removed %d, left %v, want all 3 removed", removed, members)
+	}
+}
+
+func TestGetExistingContactsInListPastSMSOnlyContact(t *testing.T) {
+	total := listContactsPageSize + 1
+
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		var contacts []BrevoContact
+		for id := offset + 1; id <= min(offset+limit, total); id++ {
+			email := fmt.Sprintf("user%d@example.com", id)
+			if id == 7 {
+				email = ""
+			}
+			contacts = append(contacts, BrevoContact{ID: id, Email: email})
+		}
+		json.NewEncoder(w).Encode(ContactsResponse{Contacts: contacts, Count: total})
+	}))
+
+	contacts, err := service.GetExistingContactsInList(10)
+	if err != nil {
+		t.Fatalf("GetExistingContactsInList() error = %v", err)
+	}
+	if len(contacts) != total-1 {
+		t.Errorf("found %d contacts, want %d", len(contacts), total-1)
+	}
+	if contacts[""] {
+		t.Error("SMS-only contact recorded under an empty email")
+	}
+	if last := fmt.Sprintf("user%d@example.com", total); !contacts[last] {
+		t.Errorf("%s on the second page was not found", last)
+	}
+}
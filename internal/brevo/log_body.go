@@ -0,0 +1,34 @@
+package brevo
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// DefaultMaxLogBodyBytes caps how much of a request or response body is
+// logged, so bulk responses do not flood the log.
+const DefaultMaxLogBodyBytes int = 2048
+
+// logBody returns body for logging, cut to MaxLogBodyBytes with an ellipsis
+// and the full size appended. Zero uses DefaultMaxLogBodyBytes; a negative
+// limit logs bodies whole.
+func (b *BrevoService) logBody(body []byte) string {
+	return truncateForLog(body, b.config.MaxLogBodyBytes)
+}
+
+func truncateForLog(body []byte, limit int) string {
+	if limit == 0 {
+		limit = DefaultMaxLogBodyBytes
+	}
+	if limit < 0 || len(body) <= limit {
+		return string(body)
+	}
+
+	// Back up to a rune boundary so the cut never splits a character.
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+
+	return fmt.Sprintf("%s… (%d bytes total)", body[:cut], len(body))
+}
@@ -0,0 +1,69 @@
+package brevo
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTruncateForLog(t *testing.T) {
+	georgian := "დოკუმენტაცია" // 3 bytes per letter
+
+	tests := []struct {
+		name  string
+		body  string
+		limit int
+		want  string
+	}{
+		{name: "short body is whole", body: `{"id":1}`, limit: 100, want: `{"id":1}`},
+		{name: "exactly at the limit", body: "abcdef", limit: 6, want: "abcdef"},
+		{name: "cut with ellipsis and size", body: "abcdefghij", limit: 4, want: "abcd… (10 bytes total)"},
+		{name: "negative limit logs whole", body: strings.Repeat("x", 5000), limit: -1, want: strings.Repeat("x", 5000)},
+		{name: "zero uses the default", body: strings.Repeat("x", 3000), limit: 0, want: strings.Repeat("x", DefaultMaxLogBodyBytes) + "… (3000 bytes total)"},
+		{name: "never splits a character", body: georgian, limit: 7, want: "დო… (36 bytes total)"},
+		{name: "cut on a boundary", body: georgian, limit: 6, want: "დო… (36 bytes total)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateForLog([]byte(tt.body), tt.limit); got != tt.want {
+				t.Errorf("truncateForLog() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoggedResponseBodiesAreTruncated(t *testing.T) {
+	huge := `{"id":1,"padding":"` + strings.Repeat("p", 10000) + `"}`
+
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(huge))
+	}))
+	service.config.MaxLogBodyBytes = 100
+
+	var buf bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(previous) })
+
+	resp, err := service.AddContact("a@example.com", map[string]bool{}, []int{10}, &CSVData{VendorName: "Acme"})
+	if err != nil {
+		t.Fatalf("AddContact() error = %v", err)
+	}
+	resp.Body.Close()
+	if _, err := service.CreateFolder("Winners"); err != nil {
+		t.Fatalf("CreateFolder() error = %v", err)
+	}
+
+	logged := buf.String()
+	if strings.Contains(logged, strings.Repeat("p", 101)) {
+		t.Errorf("a response body was logged past the 100 byte limit")
+	}
+	if got := strings.Count(logged, fmt.Sprintf("… (%d bytes total)", len(huge))); got != 2 {
+		t.Errorf("found %d truncated bodies in the log, want 2:\n%s", got, logged)
+	}
+}
@@ -0,0 +1,48 @@
+package brevo
+
+import (
+	"net/http"
+)
+
+// Option customizes a BrevoService at construction time.
+type Option func(*BrevoService)
+
+// Headers every request must carry. Custom headers cannot replace them
+// unless AllowHeaderOverride is also given.
+var requiredHeaders = map[string]bool{
+	http.CanonicalHeaderKey("api-key"):      true,
+	http.CanonicalHeaderKey("accept"):       true,
+	http.CanonicalHeaderKey("content-type"): true,
+}
+
+// WithHeaders adds headers to every Brevo request, e.g. for an API gateway
+// sitting in front of Brevo.
+func WithHeaders(headers map[string]string) Option {
+	return func(b *BrevoService) {
+		if b.headers == nil {
+			b.headers = http.Header{}
+		}
+		for key, value := range headers {
+			b.headers.Set(key, value)
+		}
+	}
+}
+
+// AllowHeaderOverride lets WithHeaders replace the api-key, accept and
+// content-type headers instead of being ignored for them.
+func AllowHeaderOverride() Option {
+	return func(b *BrevoService) {
+		b.allowHeaderOverride = true
+	}
+}
+
+// applyCustomHeaders copies the configured headers onto req, skipping the
+// required ones unless overriding was explicitly allowed.
+func (b *BrevoService) applyCustomHeaders(req *http.Request) {
+	for key, values := range b.headers {
+		if requiredHeaders[key] && !b.allowHeaderOverride {
+			continue
+		}
+		req.Header[key] = values
+	}
+}
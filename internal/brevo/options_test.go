@@ -0,0 +1,65 @@
+package brevo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestWithHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    map[string]string
+		wantKey string
+	}{
+		{
+			name:    "gateway headers are sent",
+			opts:    []Option{WithHeaders(map[string]string{"X-Gateway-Token": "secret", "x-tenant": "winners"})},
+			want:    map[string]string{"X-Gateway-Token": "secret", "X-Tenant": "winners", "Accept": "application/json"},
+			wantKey: "test-key",
+		},
+		{
+			name:    "required headers are kept",
+			opts:    []Option{WithHeaders(map[string]string{"api-key": "gateway-key", "Accept": "text/plain", "X-Trace": "1"})},
+			want:    map[string]string{"X-Trace": "1", "Accept": "application/json", "Content-Type": "application/json"},
+			wantKey: "test-key",
+		},
+		{
+			name:    "required headers overridden when allowed",
+			opts:    []Option{WithHeaders(map[string]string{"api-key": "gateway-key"}), AllowHeaderOverride()},
+			want:    map[string]string{"Accept": "application/json"},
+			wantKey: "gateway-key",
+		},
+		{
+			name:    "no custom headers",
+			want:    map[string]string{"Accept": "application/json"},
+			wantKey: "test-key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received http.Header
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r.Header.Clone()
+				w.Write([]byte(`{"id":7,"status":"draft"}`))
+			}))
+			for _, opt := range tt.opts {
+				opt(service)
+			}
+
+			if _, err := service.GetCampaignStatus(7); err != nil {
+				t.Fatalf("GetCampaignStatus() error = %v", err)
+			}
+
+			for key, value := range tt.want {
+				if got := received.Get(key); got != value {
+					t.Errorf("header %s = %q, want %q", key, got, value)
+				}
+			}
+			if got := received.Get("api-key"); got != tt.wantKey {
+				t.Errorf("api-key = %q, want %q", got, tt.wantKey)
+			}
+		})
+	}
+}
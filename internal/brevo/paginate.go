@@ -0,0 +1,55 @@
+package brevo
+
+import "context"
+
+// pageFetcher fetches up to limit items starting at offset. total is the
+// count the endpoint reports, or 0 when it reports none.
+type pageFetcher[T any] func(limit, offset int) (page []T, total int, err error)
+
+// forEachPage walks a limit/offset listing from offset, handing each page to
+// visit along with its offset. It stops after an empty or short page, once
+// the reported total is reached, when visit or fetch fail, or when ctx is
+// cancelled between pages. Pages are paced by the service's rate limiter.
+func forEachPage[T any](ctx context.Context, limit, offset int, fetch pageFetcher[T], visit func(page []T, offset int) error) error {
+	for {
+		page, total, err := fetch(limit, offset)
+		if err != nil {
+			return err
+		}
+
+		if len(page) == 0 {
+			return nil
+		}
+
+		if err := visit(page, offset); err != nil {
+			return err
+		}
+
+		if len(page) < limit || (total > 0 && offset+len(page) >= total) {
+			return nil
+		}
+
+		offset += limit
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// paginate collects every item of a limit/offset listing. progress, when
+// non-nil, is called after each page with the number fetched so far. The
+// items gathered before an error are returned with it.
+func paginate[T any](ctx context.Context, limit int, fetch pageFetcher[T], progress func(fetched int)) ([]T, error) {
+	items := []T{}
+
+	err := forEachPage(ctx, limit, 0, fetch, func(page []T, offset int) error {
+		items = append(items, page...)
+		if progress != nil {
+			progress(len(items))
+		}
+		return nil
+	})
+
+	return items, err
+}
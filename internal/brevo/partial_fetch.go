@@ -0,0 +1,21 @@
+package brevo
+
+import "fmt"
+
+// PartialFetchError is returned by GetExistingContantsEmail when paging
+// stops before the end, e.g. because Brevo throttled the account. The
+// contacts fetched up to that point are returned alongside it.
+type PartialFetchError struct {
+	Offset     int
+	Fetched    int
+	StatusCode int
+	Err        error
+}
+
+func (e *PartialFetchError) Error() string {
+	return fmt.Sprintf("contact fetch interrupted at offset %d after %d contacts: %v", e.Offset, e.Fetched, e.Err)
+}
+
+func (e *PartialFetchError) Unwrap() error {
+	return e.Err
+}
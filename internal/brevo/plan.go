@@ -0,0 +1,415 @@
+package brevo
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	PlanActionCreate    string = "create"
+	PlanActionUpdate    string = "update"
+	PlanActionUnchanged string = "unchanged"
+)
+
+// DiffReport is what a run would do to Brevo, computed without changing
+// anything: the contacts it would create or update, with the attributes each
+// update would change, and the lists it would create.
+type DiffReport struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	CSVPath     string           `json:"csv_path"`
+	Contacts    []PlannedContact `json:"contacts"`
+	Lists       []PlannedList    `json:"lists"`
+	Skipped     []ContactResult  `json:"skipped"`
+	Errors      Errors           `json:"errors"`
+	// Counts holds the number of contacts per plan action.
+	Counts map[string]int `json:"counts"`
+}
+
+// PlannedContact is the planned outcome for one CSV row.
+type PlannedContact struct {
+	Row        int                        `json:"row"`
+	Email      string                     `json:"email"`
+	Action     string                     `json:"action"`
+	Attributes map[string]any             `json:"attributes,omitempty"`
+	Changes    map[string]AttributeChange `json:"changes,omitempty"`
+}
+
+// PlannedList is a list a run would import into.
+type PlannedList struct {
+	Name   string `json:"name"`
+	ID     int    `json:"id,omitempty"`
+	Folder string `json:"folder,omitempty"`
+	Create bool   `json:"create"`
+	// Routed is the ListRouting value that sends contacts to the list.
+	Routed string `json:"routed,omitempty"`
+}
+
+// Plan reads csvPath and compares it with the account's current contacts,
+// reporting what ProcessCSVAndSendCampaign would change. It only reads from
+// Brevo. Outcomes Brevo decides at write time, such as SMS conflicts, cannot
+// be predicted.
+func (b *BrevoService) Plan(csvPath string) (DiffReport, error) {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	opts := b.config.Process
+	report := DiffReport{
+		GeneratedAt: time.Now(),
+		CSVPath:     csvPath,
+		Contacts:    []PlannedContact{},
+		Lists:       []PlannedList{},
+		Skipped:     []ContactResult{},
+		Errors:      []ErrorResult{},
+		Counts:      make(map[string]int),
+	}
+
+	file, err := b.openCSV(csvPath, nil)
+	if err != nil {
+		return report, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer file.Close()
+
+	records, err := readCSVRecords(file, opts.CSV)
+	if err != nil {
+		return report, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	csvData, err := mapCSVToObject(records, opts.Decoder, opts.CSV)
+	if errors.Is(err, ErrEmptyCSV) {
+		return report, nil
+	}
+	if err != nil {
+		return report, fmt.Errorf("failed to map CSV data: %w", err)
+	}
+
+	states := newContactStates(opts, false)
+	existing, err := b.fetchContactAttributes(states)
+	if err != nil {
+		return report, fmt.Errorf("failed to fetch existing contacts: %w", err)
+	}
+
+	// Exclusions are recorded on a scratch result so the plan skips exactly
+	// the rows a run would.
+	var excluded ProcessingResults
+	if opts.SkipBlacklisted {
+		excluded.exclude(states.blacklisted, ExclusionBlacklisted)
+	}
+	if opts.SuppressionListPath != "" {
+		suppressed, err := readSuppressionList(opts.SuppressionListPath)
+		if err != nil {
+			return report, err
+		}
+		excluded.exclude(suppressed, ExclusionSuppressed)
+	}
+	excluded.exclude(outsideAllowlist(csvData, opts.DomainAllowlist), ExclusionDomainNotAllowlisted)
+
+	csvName := csvBaseName(csvPath)
+	list, err := b.planList(csvName, csvData)
+	if err != nil {
+		return report, err
+	}
+	report.Lists = append(report.Lists, list)
+
+	if opts.SkipListUnsubscribed && list.ID > 0 {
+		excluded.exclude(states.unsubscribedFrom(list.ID), ExclusionListUnsubscribed)
+	}
+
+	for i := range csvData {
+		data := &csvData[i]
+		row := i + 2 // 1-based, after the header line
+
+		if data.Email == "" {
+			report.Errors.addRow(row, data.Email, ErrMissingEmail, "Skipping contact with no email address")
+			continue
+		}
+
+		if excluded.skipExcluded(row, data) {
+			continue
+		}
+
+		// The payload is finished as addContact finishes it, so consent
+		// dates, cleared and immutable attributes and the transformer's
+		// changes show in the plan.
+		key := strings.ToLower(data.Email)
+		before, exists := existing[key]
+		payload, _ := b.buildPayload(row, data.Email, nil, data)
+		b.finishPayload(&payload, data, exists, before, exists)
+		b.transformPayload(&payload)
+		planned := PlannedContact{Row: row, Email: data.Email}
+
+		if exists {
+			planned.Changes = diffAttributes(before, payload.Attributes)
+			planned.Action = PlanActionUpdate
+			if len(planned.Changes) == 0 {
+				planned.Action = PlanActionUnchanged
+				planned.Changes = nil
+			}
+		} else {
+			planned.Action = PlanActionCreate
+			planned.Attributes = payload.Attributes
+		}
+
+		// A later row with the same email updates the contact this one
+		// creates or changes.
+		merged := make(map[string]any)
+		for k, v := range existing[key] {
+			merged[k] = v
+		}
+		for k, v := range payload.Attributes {
+			merged[k] = v
+		}
+		existing[key] = merged
+
+		report.Counts[planned.Action]++
+		report.Contacts = append(report.Contacts, planned)
+	}
+
+	report.Skipped = append(report.Skipped, excluded.Skipped...)
+
+	if opts.ListRouting != nil {
+		routed, err := b.planRoutedLists(*opts.ListRouting, list, csvData)
+		if err != nil {
+			return report, err
+		}
+		report.Lists = append(report.Lists, routed...)
+	}
+
+	log.Printf("Plan for %s: %d to create, %d to update, %d unchanged, %d skipped, %d errors",
+		csvPath, report.Counts[PlanActionCreate], report.Counts[PlanActionUpdate], report.Counts[PlanActionUnchanged],
+		len(report.Skipped), len(report.Errors))
+
+	return report, nil
+}
+
+// fetchContactAttributes returns every contact's attributes keyed by
+// lowercased email, or only the target list's when one is configured.
+func (b *BrevoService) fetchContactAttributes(states *contactStates) (map[string]map[string]any, error) {
+	contacts := make(map[string]map[string]any)
+
+	limit := 1000
+	fetch := func(limit, offset int) ([]BrevoContact, int, error) {
+		page, err := b.getContactsPage(limit, offset)
+		return page, 0, err
+	}
+	if b.config.TargetListID > 0 {
+		limit = listContactsPageSize
+		fetch = func(limit, offset int) ([]BrevoContact, int, error) {
+			page, err := b.getListContactPage(b.config.TargetListID, limit, offset)
+			return page, 0, err
+		}
+	}
+
+	err := forEachPage(b.context(), limit, 0, fetch, func(page []BrevoContact, offset int) error {
+		for _, contact := range page {
+			if contact.Email == "" {
+				continue
+			}
+			contacts[strings.ToLower(contact.Email)] = contact.Attributes
+			states.observe(contact)
+		}
+		return nil
+	})
+	if err != nil {
+		return contacts, err
+	}
+
+	log.Printf("Fetched %d existing contacts for the plan", len(contacts))
+	return contacts, nil
+}
+
+func (b *BrevoService) getContactsPage(limit, offset int) ([]BrevoContact, error) {
+	url := fmt.Sprintf("https://api.brevo.com/v3/contacts?limit=%d&offset=%d", limit, offset)
+
+	resp, err := b.makeAPIRequest(opFetch, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching contacts at offset %d: %w", offset, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error fetching contacts at offset %d: %d - %s", offset, resp.StatusCode, string(body))
+	}
+
+	var contactsResp ContactsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&contactsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode contacts at offset %d: %w", offset, err)
+	}
+
+	return contactsResp.Contacts, nil
+}
+
+// planList describes the run's list: the target list, or the list the run
+// would create.
+func (b *BrevoService) planList(csvName string, csvData []CSVData) (PlannedList, error) {
+	if b.config.TargetListID > 0 {
+		list, err := b.GetList(b.config.TargetListID)
+		if err != nil {
+			return PlannedList{}, fmt.Errorf("failed to load target list %d: %w", b.config.TargetListID, err)
+		}
+		return PlannedList{Name: list.Name, ID: list.ID}, nil
+	}
+
+	folderName, err := resolveFolderName(b.config.Process.FolderStrategy, FolderMeta{
+		CSVName:  csvName,
+		Date:     time.Now(),
+		Category: firstCategory(csvData),
+	})
+	if err != nil {
+		return PlannedList{}, fmt.Errorf("failed to resolve folder name: %w", err)
+	}
+
+	return PlannedList{
+		Name:   fmt.Sprintf("Winners List - %s", time.Now().Format("2006-01-02 15:04:05")),
+		Folder: folderName,
+		Create: true,
+	}, nil
+}
+
+// planRoutedLists lists the routed lists the CSV's values lead to, without
+// creating any.
+func (b *BrevoService) planRoutedLists(routing ListRouting, base PlannedList, csvData []CSVData) ([]PlannedList, error) {
+	r, err := newListRouter(routing, ContactList{Name: base.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var lists []PlannedList
+	for i := range csvData {
+		value := strings.TrimSpace(*csvData[i].columnFields()[r.column])
+		key := strings.ToLower(value)
+		if value == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if listID, ok := r.lists[key]; ok {
+			lists = append(lists, PlannedList{ID: listID, Routed: value})
+			continue
+		}
+
+		name, mapped := r.names[key]
+		if !mapped {
+			if routing.RequireMatch {
+				continue
+			}
+			name = fmt.Sprintf("%s - %s", base.Name, value)
+		}
+		lists = append(lists, PlannedList{Name: name, Folder: base.Folder, Create: true, Routed: value})
+	}
+
+	sort.Slice(lists, func(i, j int) bool { return lists[i].Routed < lists[j].Routed })
+	return lists, nil
+}
+
+// writePlan plans csvPath and writes the plan to Config.PlanPath.
+func (b *BrevoService) writePlan(csvPath string) {
+	report, err := b.Plan(csvPath)
+	if err != nil {
+		log.Printf("Failed to plan %s: %v", csvPath, err)
+		return
+	}
+
+	write := WritePlan
+	if strings.EqualFold(filepath.Ext(b.config.PlanPath), ".csv") {
+		write = WritePlanCSV
+	}
+
+	if err := write(report, b.config.PlanPath); err != nil {
+		log.Printf("Failed to write plan: %v", err)
+		return
+	}
+
+	log.Printf("Plan for %s written to %s. Nothing was changed in Brevo.", csvPath, b.config.PlanPath)
+}
+
+// WritePlan writes report to path as indented JSON.
+func WritePlan(report DiffReport, path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode plan: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write plan '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+var planCSVHeader = []string{"row", "email", "action", "attribute", "old", "new"}
+
+// WritePlanCSV writes report to path with one line per changed attribute,
+// and one line for each contact that would be created or left unchanged.
+func WritePlanCSV(report DiffReport, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create plan CSV '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+
+	if err := writer.Write(planCSVHeader); err != nil {
+		return fmt.Errorf("failed to write plan CSV header: %w", err)
+	}
+
+	for _, c := range report.Contacts {
+		lines := [][]string{}
+
+		switch c.Action {
+		case PlanActionCreate:
+			for _, name := range sortedKeys(c.Attributes) {
+				lines = append(lines, []string{name, "", fmt.Sprint(c.Attributes[name])})
+			}
+		case PlanActionUpdate:
+			for _, name := range sortedKeys(c.Changes) {
+				change := c.Changes[name]
+				old := ""
+				if change.Old != nil {
+					old = fmt.Sprint(change.Old)
+				}
+				lines = append(lines, []string{name, old, fmt.Sprint(change.New)})
+			}
+		}
+
+		if len(lines) == 0 {
+			lines = append(lines, []string{"", "", ""})
+		}
+
+		for _, line := range lines {
+			record := append([]string{formatRow(c.Row), c.Email, c.Action}, line...)
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write plan CSV row for %s: %w", c.Email, err)
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush plan CSV: %w", err)
+	}
+
+	return file.Close()
+}
+
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
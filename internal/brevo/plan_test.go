@@ -0,0 +1,81 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPlanFinishesPayloadsLikeARun(t *testing.T) {
+	service, mock := newRunService(t)
+	service.config.ImmutableAttributes = map[string]bool{"COMPANY_NAME": true}
+	service.config.ClearOnEmpty = map[string]bool{"SMS": true}
+	service.config.Consent = ConsentConfig{DefaultToImportDate: true}
+	service.config.Process.Decoder = HeaderDecoder{}
+	service.clock = func() time.Time { return time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC) }
+	mock.existing = []BrevoContact{
+		{Email: "curated@example.com", Attributes: map[string]any{"COMPANY_NAME": "Curated Ltd", "SMS": "995555111"}},
+	}
+
+	csvPath := writeCSV(t, "email,vendor_name", "curated@example.com,Acme", "new@example.com,Gamma")
+	report, err := service.Plan(csvPath)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(report.Contacts) != 2 {
+		t.Fatalf("Plan() planned %d contacts, want 2", len(report.Contacts))
+	}
+
+	curated := report.Contacts[0]
+	if curated.Action != PlanActionUpdate {
+		t.Errorf("curated contact action = %q, want %q", curated.Action, PlanActionUpdate)
+	}
+	if _, ok := curated.Changes["COMPANY_NAME"]; ok {
+		t.Errorf("curated contact changes COMPANY_NAME, want it preserved as immutable: %v", curated.Changes)
+	}
+	if change, ok := curated.Changes["SMS"]; !ok || change.New != "" {
+		t.Errorf("curated contact changes = %v, want SMS cleared", curated.Changes)
+	}
+	if _, ok := curated.Changes["OPT_IN_DATE"]; ok {
+		t.Errorf("curated contact changes = %v, want no import date for an existing contact", curated.Changes)
+	}
+
+	created := report.Contacts[1]
+	if created.Action != PlanActionCreate || fmt.Sprint(created.Attributes["OPT_IN_DATE"]) != "2026-03-02" {
+		t.Errorf("new contact planned as %q with %v, want created with the import date", created.Action, created.Attributes)
+	}
+}
+
+func TestFetchContactAttributesPastSMSOnlyContact(t *testing.T) {
+	total := listContactsPageSize + 1
+
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		var contacts []BrevoContact
+		for id := offset + 1; id <= min(offset+limit, total); id++ {
+			email := fmt.Sprintf("user%d@example.com", id)
+			if id == 7 {
+				email = ""
+			}
+			contacts = append(contacts, BrevoContact{ID: id, Email: email})
+		}
+		json.NewEncoder(w).Encode(ContactsResponse{Contacts: contacts, Count: total})
+	}))
+	service.config.TargetListID = 10
+
+	contacts, err := service.fetchContactAttributes(nil)
+	if err != nil {
+		t.Fatalf("fetchContactAttributes() error = %v", err)
+	}
+	if len(contacts) != total-1 {
+		t.Errorf("fetched %d contacts, want %d", len(contacts), total-1)
+	}
+	if _, ok := contacts[fmt.Sprintf("user%d@example.com", total)]; !ok {
+		t.Error("member on the second page was not fetched")
+	}
+}
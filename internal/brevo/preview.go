@@ -0,0 +1,71 @@
+package brevo
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+)
+
+// Brevo personalization tags, e.g. {{ contact.COMPANY_NAME }}.
+var contactPlaceholderPattern = regexp.MustCompile(`\{\{\s*contact\.([A-Za-z0-9_]+)\s*\}\}`)
+
+// renderCampaignHTML produces the HTML body exactly as it is sent to Brevo.
+func (b *BrevoService) renderCampaignHTML(cfg CampaignConfig) (string, error) {
+	htmlContent := cfg.InlineHTML
+
+	if htmlContent == "" {
+		var err error
+		htmlContent, err = b.LoadHTMLTemplate(cfg.TemplateFile, cfg.Language)
+		if err != nil {
+			return "", fmt.Errorf("failed to load HTML template: %w", err)
+		}
+	}
+
+	htmlContent, err := applyInlineImages(htmlContent, cfg.InlineImages)
+	if err != nil {
+		return "", fmt.Errorf("failed to embed inline images: %w", err)
+	}
+
+	htmlContent, err = processCampaignHTML(htmlContent, cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to process HTML: %w", err)
+	}
+
+	return htmlContent, nil
+}
+
+// RenderCampaignPreview writes the final campaign HTML to outPath without
+// creating a campaign. Personalization tags are filled from sample the way
+// Brevo would fill them for that contact; tags sample has no value for are
+// left untouched. The rendered HTML is returned as well.
+func (b *BrevoService) RenderCampaignPreview(cfg CampaignConfig, sample *CSVData, outPath string) (string, error) {
+	if err := cfg.Validate(); err != nil {
+		return "", fmt.Errorf("invalid campaign config: %w", err)
+	}
+
+	htmlContent, err := b.renderCampaignHTML(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	attributes := b.buildAttributes(sample)
+	if sample != nil {
+		attributes["EMAIL"] = sample.Email
+	}
+
+	htmlContent = contactPlaceholderPattern.ReplaceAllStringFunc(htmlContent, func(tag string) string {
+		name := contactPlaceholderPattern.FindStringSubmatch(tag)[1]
+		if value, ok := attributes[name]; ok {
+			return fmt.Sprint(value)
+		}
+		return tag
+	})
+
+	if err := os.WriteFile(outPath, []byte(htmlContent), 0o644); err != nil {
+		return htmlContent, fmt.Errorf("failed to write preview to '%s': %w", outPath, err)
+	}
+
+	log.Printf("Campaign preview written to %s", outPath)
+	return htmlContent, nil
+}
@@ -0,0 +1,238 @@
+package brevo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// ErrRunTimeout is returned, wrapped, when ProcessOptions.RunTimeout expires
+// before the run finishes. The results gathered so far are still returned.
+var ErrRunTimeout = errors.New("run timed out")
+
+// errContactBudget is the cause a contact's context is cancelled with once
+// ProcessOptions.ContactTimeout runs out.
+var errContactBudget = errors.New("contact time budget exceeded")
+
+// ErrEmptyCSV means the CSV had no data rows. ProcessCSVAndSendCampaign
+// treats it as a successful no-op run rather than a failure.
+var ErrEmptyCSV = errors.New("CSV file is empty or has no data rows")
+
+// ErrRecipientCapExceeded is returned, wrapped, when a run would email more
+// than ProcessOptions.MaxRecipients contacts without an explicit override.
+var ErrRecipientCapExceeded = errors.New("recipient cap exceeded")
+
+// ProcessOptions controls a single ProcessCSVAndSendCampaign run.
+type ProcessOptions struct {
+	// RunTimeout bounds the whole run, cancelling in-flight requests when it
+	// expires. Zero means no limit beyond the per-request timeouts.
+	RunTimeout time.Duration
+
+	// SkipCampaign stops the run after the import: it only builds the
+	// list, so it can be reviewed in Brevo before anyone is emailed.
+	SkipCampaign bool
+
+	// ContactTimeout bounds the total time spent on one contact, retries
+	// included. A contact that runs over is recorded as timed out and the
+	// run moves on. It covers every contact imported one by one, including
+	// those a bulk import or ReprocessErrors retries. Zero means no budget
+	// beyond the per-request timeouts.
+	ContactTimeout time.Duration
+
+	CSV CSVOptions
+	// Decoder maps CSV records to contacts. Nil uses PositionalDecoder.
+	Decoder RowDecoder `json:"-"`
+
+	// MaxRecipients refuses to create or send the campaign when the list
+	// holds more recipients than this, guarding against a malformed CSV
+	// targeting the whole account. Zero disables the cap.
+	MaxRecipients int
+	// OverrideRecipientCap must be set explicitly to send past the cap.
+	OverrideRecipientCap bool
+	// MinRecipients skips the campaign when fewer recipients than this
+	// would get it, so slow days don't produce near-empty sends. A campaign
+	// with no recipients is always skipped.
+	MinRecipients int
+
+	// ConfirmationThreshold creates but does not send a campaign with more
+	// recipients than this, leaving it for ConfirmAndSend. Zero sends
+	// without confirmation.
+	ConfirmationThreshold int
+
+	// BulkImport loads contacts through Brevo's asynchronous import instead
+	// of one request per contact. ImportTimeout bounds the wait for each
+	// import process; zero uses DefaultImportTimeout.
+	BulkImport    bool
+	ImportTimeout time.Duration
+	// RetryFailedImports imports the contacts of a chunk Brevo rejected
+	// with a transient error, and those its import report lists as failed,
+	// one by one instead of giving up on them.
+	RetryFailedImports bool
+
+	// SkipBlacklisted leaves contacts Brevo has email-blacklisted out of
+	// the list, recording them as skipped, since they would not receive
+	// the campaign anyway.
+	SkipBlacklisted bool
+	// SkipListUnsubscribed leaves out contacts who unsubscribed from the
+	// run's list, reporting them as skipped instead of re-adding them.
+	SkipListUnsubscribed bool
+
+	// SuppressionListPath names a file of emails that must never be
+	// contacted. They are skipped as "suppressed" on every run; use
+	// ImportSuppressionList to also blacklist them in Brevo.
+	SuppressionListPath string
+
+	// DomainAllowlist, when set, limits the run to contacts whose email
+	// domain is listed, for pilots on internal addresses. Everyone else is
+	// skipped as "domain not allowlisted".
+	DomainAllowlist []string
+
+	// ScheduleAfter schedules the campaign this long after the import
+	// finishes instead of sending it right away, so contact updates have
+	// settled. It must be between 10 minutes and 30 days.
+	ScheduleAfter time.Duration
+
+	// VerifySentTimeout, when set, waits up to this long after sending for
+	// Brevo to report the campaign as sent, catching sends that were
+	// accepted but stalled.
+	VerifySentTimeout time.Duration
+
+	// TimezoneSchedule, when set, schedules one campaign per recipient
+	// timezone instead of sending a single campaign right away.
+	TimezoneSchedule *TimezoneAwareSchedule
+
+	// ListRouting, when set, also adds each contact to a list chosen by one
+	// of its CSV columns. It replaces the campaign step and takes precedence
+	// over TimezoneSchedule.
+	ListRouting *ListRouting
+
+	// Cumulative, when set, imports every run into one stable list and
+	// leaves the campaign to the weekly SendCumulativeCampaign.
+	Cumulative *CumulativeList
+
+	// RunID identifies the run in the automatic campaign tags. Start sets
+	// it for each run.
+	RunID string `json:"-"`
+
+	// ReconcileCounts fetches the account and list contact counts before
+	// and after the run and reports the delta. It costs a few extra calls.
+	ReconcileCounts bool
+
+	// FolderStrategy derives the folder for a newly created list. Nil keeps
+	// every list in the Winners folder.
+	FolderStrategy FolderStrategy `json:"-"`
+}
+
+func DefaultProcessOptions() ProcessOptions {
+	return ProcessOptions{
+		CSV: DefaultCSVOptions(),
+	}
+}
+
+// runTimeoutError reports ErrRunTimeout once the run's deadline has passed,
+// or the context error if the caller cancelled the run.
+func (b *BrevoService) runTimeoutError(opts ProcessOptions) error {
+	if err := b.apiBudgetError(); err != nil {
+		return err
+	}
+
+	err := b.context().Err()
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrRunTimeout, opts.RunTimeout)
+	}
+	return err
+}
+
+// withContactBudget returns the service to import one contact with, bound to
+// opts.ContactTimeout when it is set. The cancel func must always be called.
+func (b *BrevoService) withContactBudget(opts ProcessOptions) (*BrevoService, context.CancelFunc) {
+	if opts.ContactTimeout <= 0 {
+		return b, func() {}
+	}
+
+	ctx, cancel := context.WithTimeoutCause(b.context(), opts.ContactTimeout, errContactBudget)
+	bounded := b.WithContext(ctx)
+	bounded.contactBudget = opts.ContactTimeout
+	return bounded, cancel
+}
+
+// contactTimedOut reports whether the contact's own budget ran out, as
+// opposed to the run around it being cancelled or timing out.
+func (b *BrevoService) contactTimedOut() bool {
+	return b.contactBudget > 0 && errors.Is(context.Cause(b.context()), errContactBudget)
+}
+
+// checkRecipientCap enforces MaxRecipients for a campaign to listID.
+func (b *BrevoService) checkRecipientCap(opts ProcessOptions, listID int, runCount int) error {
+	if opts.MaxRecipients <= 0 {
+		return nil
+	}
+
+	return enforceRecipientCap(opts, b.recipientCount(listID, runCount))
+}
+
+// enforceRecipientCap enforces MaxRecipients for a campaign to count
+// recipients.
+func enforceRecipientCap(opts ProcessOptions, count int) error {
+	if opts.MaxRecipients <= 0 {
+		return nil
+	}
+
+	log.Printf("Recipient cap: %d, actual recipients: %d", opts.MaxRecipients, count)
+
+	if count <= opts.MaxRecipients {
+		return nil
+	}
+
+	if opts.OverrideRecipientCap {
+		log.Printf("Warning: %d recipients exceed the cap of %d, continuing because the override is set", count, opts.MaxRecipients)
+		return nil
+	}
+
+	return fmt.Errorf("%w: %d recipients, cap is %d (set the override to send anyway)", ErrRecipientCapExceeded, count, opts.MaxRecipients)
+}
+
+// CampaignSkip records why the campaign step was skipped.
+type CampaignSkip struct {
+	Reason        string `json:"reason"`
+	Recipients    int    `json:"recipients"`
+	MinRecipients int    `json:"min_recipients,omitempty"`
+}
+
+// checkRecipientMinimum returns a CampaignSkip when a campaign to listID
+// would have no recipients or fewer than MinRecipients.
+func (b *BrevoService) checkRecipientMinimum(opts ProcessOptions, listID int, runCount int) *CampaignSkip {
+	count := runCount
+	if opts.MinRecipients > 0 || count == 0 {
+		count = b.recipientCount(listID, runCount)
+	}
+
+	if count == 0 {
+		return &CampaignSkip{Reason: "no recipients, skipped", MinRecipients: opts.MinRecipients}
+	}
+
+	if count < opts.MinRecipients {
+		return &CampaignSkip{Reason: "below minimum, skipped", Recipients: count, MinRecipients: opts.MinRecipients}
+	}
+
+	return nil
+}
+
+// recipientCount is the number of contacts a campaign to listID reaches.
+// A reused target list may already hold contacts from earlier runs, so its
+// subscriber count is used when available.
+func (b *BrevoService) recipientCount(listID int, runCount int) int {
+	if b.config.TargetListID <= 0 {
+		return runCount
+	}
+
+	list, err := b.GetList(listID)
+	if err != nil {
+		log.Printf("Warning: Could not fetch subscriber count of list %d: %v. Using this run's count.", listID, err)
+		return runCount
+	}
+
+	return max(list.TotalSubscribers, runCount)
+}
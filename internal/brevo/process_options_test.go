@@ -0,0 +1,129 @@
+package brevo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportContactTimeBudget(t *testing.T) {
+	tests := []struct {
+		name         string
+		email        string
+		cancelRun    bool
+		wantTimeout  bool
+		wantImported bool
+	}{
+		{name: "fast contact is imported", email: "fast@example.com", wantImported: true},
+		{name: "stalling contact times out", email: "stall@example.com", wantTimeout: true},
+		{name: "cancelled run is not a contact timeout", email: "stall@example.com", cancelRun: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var payload struct {
+					Email string `json:"email"`
+				}
+				json.NewDecoder(r.Body).Decode(&payload)
+
+				if strings.HasPrefix(payload.Email, "stall") {
+					select {
+					case <-r.Context().Done():
+					case <-time.After(5 * time.Second):
+					}
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+			}))
+
+			opts := service.config.Process
+			opts.ContactTimeout = 50 * time.Millisecond
+			if tt.cancelRun {
+				opts.ContactTimeout = time.Minute
+				ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+				defer cancel()
+				service = service.WithContext(ctx)
+			}
+
+			contactService, cancel := service.withContactBudget(opts)
+			defer cancel()
+
+			var results ProcessingResults
+			started := time.Now()
+			failed := contactService.importContact(2, &CSVData{Email: tt.email}, map[string]bool{}, []int{1}, &results)
+
+			if elapsed := time.Since(started); elapsed > 2*time.Second {
+				t.Fatalf("importContact took %s, the budget did not stop it", elapsed)
+			}
+			if tt.wantImported != (failed < 0) {
+				t.Fatalf("importContact() failure = %+v, want imported %v", results.Errors, tt.wantImported)
+			}
+			if tt.wantImported {
+				if len(results.AddedToCampaign) != 1 {
+					t.Errorf("added = %d, want 1", len(results.AddedToCampaign))
+				}
+				return
+			}
+			if timedOut := strings.Contains(results.Errors[failed].Error, "contact timed out"); timedOut != tt.wantTimeout {
+				t.Errorf("error = %q, want contact timeout %v", results.Errors[failed].Error, tt.wantTimeout)
+			}
+		})
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		delay        time.Duration
+		runTimeout   time.Duration
+		wantTimeout  bool
+		wantImported int
+		wantSends    int
+	}{
+		{name: "fast run finishes", runTimeout: 5 * time.Second, wantImported: 3, wantSends: 1},
+		{name: "no run timeout", wantImported: 3, wantSends: 1},
+		{name: "slow run is cut short", delay: 150 * time.Millisecond, runTimeout: 250 * time.Millisecond, wantTimeout: true, wantImported: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newRunService(t)
+			mock.onContact = func(w http.ResponseWriter, r *http.Request, payload ContactPayload) bool {
+				select {
+				case <-time.After(tt.delay):
+				case <-r.Context().Done():
+				}
+				return false
+			}
+
+			csvPath := writeCSV(t, "email,vendor_name", "a@example.com,A", "b@example.com,B", "c@example.com,C")
+			opts := service.config.Process
+			opts.Decoder = HeaderDecoder{}
+			opts.RunTimeout = tt.runTimeout
+
+			started := time.Now()
+			results, err := service.ProcessCSVAndSendCampaign(csvPath, opts)
+
+			if elapsed := time.Since(started); tt.wantTimeout && elapsed > time.Second {
+				t.Errorf("run took %s, the run timeout did not stop it", elapsed)
+			}
+			if errors.Is(err, ErrRunTimeout) != tt.wantTimeout {
+				t.Fatalf("ProcessCSVAndSendCampaign() error = %v, want timeout %v", err, tt.wantTimeout)
+			}
+			if !tt.wantTimeout && err != nil {
+				t.Fatalf("ProcessCSVAndSendCampaign() error = %v", err)
+			}
+			if got := len(results.AddedToCampaign); got != tt.wantImported {
+				t.Errorf("imported %d contacts, want %d (partial results must be kept)", got, tt.wantImported)
+			}
+			if mock.sends != tt.wantSends {
+				t.Errorf("sendNow called %d times, want %d", mock.sends, tt.wantSends)
+			}
+		})
+	}
+}
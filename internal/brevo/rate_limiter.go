@@ -0,0 +1,80 @@
+package brevo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter caps requests per second across everything sharing it. The
+// service keeps one for the whole account, so concurrent runs on the same
+// BrevoService, and copies made by WithContext, draw from a single budget.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// DefaultRateLimit is the requests per second allowed when BREVO_RATE_LIMIT
+// is not set, matching the pace the old fixed delays between calls kept.
+const DefaultRateLimit = 10.0
+
+// NewRateLimiter allows perSecond requests per second on average and up to
+// burst at once. A perSecond of zero or less returns nil, which never
+// waits.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{rate: perSecond, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// WithRateLimiter makes the service draw from limiter, e.g. to share one
+// budget between services built for different API keys of one account.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(b *BrevoService) {
+		b.limiter = limiter
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	// Taking the token before sleeping reserves the slot, so waiters are
+	// served in the order they arrived.
+	l.tokens--
+	if l.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Hand the reserved slot back so a cancelled run does not slow
+		// down the runs still sharing the limiter.
+		l.mu.Lock()
+		l.tokens = min(l.burst, l.tokens+1)
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
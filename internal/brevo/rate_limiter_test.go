@@ -0,0 +1,132 @@
+package brevo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterSharedByConcurrentRuns(t *testing.T) {
+	const (
+		perSecond = 40.0
+		burst     = 2
+	)
+
+	service, mock := newRunService(t)
+	service.limiter = NewRateLimiter(perSecond, burst)
+
+	var mu sync.Mutex
+	var sent []time.Time
+	forward := service.httpClient.Transport
+	service.httpClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		sent = append(sent, time.Now())
+		mu.Unlock()
+		return forward.RoundTrip(req)
+	})
+
+	opts := service.config.Process
+	opts.Decoder = HeaderDecoder{}
+	opts.SkipCampaign = true
+
+	var wg sync.WaitGroup
+	for file := range 2 {
+		lines := []string{"email"}
+		for i := range 5 {
+			lines = append(lines, fmt.Sprintf("file%d-%d@example.com", file, i))
+		}
+		csvPath := writeCSV(t, lines...)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := service.ProcessCSVAndSendCampaign(csvPath, opts); err != nil {
+				t.Errorf("ProcessCSVAndSendCampaign(%s) error = %v", csvPath, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(mock.contacts) != 10 {
+		t.Fatalf("imported %d contacts, want 10", len(mock.contacts))
+	}
+
+	sort.Slice(sent, func(i, j int) bool { return sent[i].Before(sent[j]) })
+
+	// Any n consecutive requests beyond the burst need (n-burst)/rate
+	// seconds, whichever run sent them.
+	slack := 5 * time.Millisecond
+	for i := range sent {
+		for j := i + burst; j < len(sent); j++ {
+			minimum := time.Duration(float64(j-i+1-burst) / perSecond * float64(time.Second))
+			if gap := sent[j].Sub(sent[i]); gap+slack < minimum {
+				t.Fatalf("requests %d to %d of %d went out in %s, the shared cap allows no less than %s", i, j, len(sent), gap, minimum)
+			}
+		}
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	t.Run("nil limiter never waits", func(t *testing.T) {
+		var limiter *RateLimiter
+		if NewRateLimiter(0, 5) != nil {
+			t.Errorf("NewRateLimiter(0, 5) is not nil")
+		}
+		for range 100 {
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Fatalf("Wait() error = %v", err)
+			}
+		}
+	})
+
+	t.Run("burst then paced", func(t *testing.T) {
+		limiter := NewRateLimiter(20, 3)
+
+		started := time.Now()
+		for range 3 {
+			limiter.Wait(context.Background())
+		}
+		if elapsed := time.Since(started); elapsed > 20*time.Millisecond {
+			t.Errorf("burst of 3 took %s, want no wait", elapsed)
+		}
+
+		limiter.Wait(context.Background())
+		limiter.Wait(context.Background())
+		if elapsed := time.Since(started); elapsed < 90*time.Millisecond {
+			t.Errorf("two requests past the burst took %s, want about 100ms", elapsed)
+		}
+	})
+
+	t.Run("cancelled wait", func(t *testing.T) {
+		limiter := NewRateLimiter(1, 1)
+		limiter.Wait(context.Background())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Wait() error = %v, want the context's deadline", err)
+		}
+	})
+
+	t.Run("cancelled wait hands its slot back", func(t *testing.T) {
+		limiter := NewRateLimiter(10, 1)
+		started := time.Now()
+		limiter.Wait(context.Background())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		limiter.Wait(ctx)
+
+		// The next slot is 100ms after the first request; had the cancelled
+		// wait kept its slot, it would be 200ms.
+		limiter.Wait(context.Background())
+		if elapsed := time.Since(started); elapsed > 150*time.Millisecond {
+			t.Errorf("wait after a cancelled one ended after %s, want about 100ms", elapsed)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package brevo
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+)
+
+// DefaultReasonTemplate explains an inclusion from the tender columns.
+const DefaultReasonTemplate string = `{{if .ID}}Won tender {{.ID}}{{if .CATEGORY}} ({{.CATEGORY}}){{end}}{{if .STOP}} on {{.STOP}}{{end}}{{end}}`
+
+// ReasonConfig writes why a contact was included in the run into a Brevo
+// attribute, so support can tell a vendor why they got the email.
+type ReasonConfig struct {
+	// Attribute receives the reason; empty disables the feature.
+	Attribute string
+	// Template renders the reason from the contact's CSVData fields, e.g.
+	// "Won tender {{.ID}} on {{.STOP}}". Empty uses DefaultReasonTemplate.
+	Template string
+}
+
+func parseReasonTemplate(cfg ReasonConfig) (*template.Template, error) {
+	if cfg.Attribute == "" {
+		return nil, nil
+	}
+
+	source := cfg.Template
+	if source == "" {
+		source = DefaultReasonTemplate
+	}
+
+	tmpl, err := template.New("reason").Option("missingkey=error").Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reason template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// contactReason renders the inclusion reason for data, or "" when the
+// feature is off or the template renders nothing.
+func (b *BrevoService) contactReason(data *CSVData) string {
+	if b.reasonTemplate == nil || data == nil {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := b.reasonTemplate.Execute(&buf, data); err != nil {
+		log.Printf("Warning: Could not render the inclusion reason of %s: %v", data.Email, err)
+		return ""
+	}
+
+	return strings.TrimSpace(buf.String())
+}
+
+// addReason stores the inclusion reason under the reason attribute, skipping
+// empty reasons so an existing value is not blanked.
+func (b *BrevoService) addReason(attributes map[string]any, data *CSVData) {
+	if reason := b.contactReason(data); reason != "" {
+		attributes[b.config.Reason.Attribute] = reason
+	}
+}
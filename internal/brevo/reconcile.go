@@ -0,0 +1,105 @@
+package brevo
+
+import (
+	"fmt"
+	"log"
+)
+
+// reconcileTolerance is the share of new contacts the account count must
+// grow by before a run counts as reconciled.
+const reconcileTolerance float64 = 0.9
+
+// Reconciliation compares contact counts fetched before and after a run
+// with what the run reported, to catch imports Brevo acknowledged but never
+// applied.
+type Reconciliation struct {
+	AccountBefore int `json:"account_before"`
+	AccountAfter  int `json:"account_after"`
+	AccountDelta  int `json:"account_delta"`
+	ListID        int `json:"list_id,omitempty"`
+	ListBefore    int `json:"list_before"`
+	ListAfter     int `json:"list_after"`
+	ListDelta     int `json:"list_delta"`
+	// NewContacts is how many contacts the run reported as added. Contacts
+	// a bulk import loaded without saying whether they were new are not
+	// counted.
+	NewContacts int `json:"new_contacts"`
+	// Shortfall is set when AccountDelta is far below NewContacts.
+	Shortfall bool   `json:"shortfall"`
+	Error     string `json:"error,omitempty"`
+}
+
+// countAccountContacts returns the number of contacts in the account.
+func (b *BrevoService) countAccountContacts() (int, error) {
+	count, err := b.getCount("https://api.brevo.com/v3/contacts?limit=1&offset=0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to count contacts: %w", err)
+	}
+	return count, nil
+}
+
+// reconcileBefore records the counts a run starts from. A new list starts
+// empty, so only a configured target list is counted.
+func (b *BrevoService) reconcileBefore() *Reconciliation {
+	rec := &Reconciliation{}
+
+	count, err := b.countAccountContacts()
+	if err != nil {
+		rec.Error = err.Error()
+		return rec
+	}
+	rec.AccountBefore = count
+
+	if b.config.TargetListID > 0 {
+		list, err := b.GetList(b.config.TargetListID)
+		if err != nil {
+			rec.Error = err.Error()
+			return rec
+		}
+		rec.ListBefore = list.UniqueSubscribers
+	}
+
+	return rec
+}
+
+// reconcileAfter completes rec with the counts after the run and flags a
+// shortfall.
+func (b *BrevoService) reconcileAfter(rec *Reconciliation, results ProcessingResults) {
+	if rec.Error != "" {
+		return
+	}
+
+	for _, contact := range results.AddedToCampaign {
+		if contact.Action == ContactActionAdded {
+			rec.NewContacts++
+		}
+	}
+
+	count, err := b.countAccountContacts()
+	if err != nil {
+		rec.Error = err.Error()
+		return
+	}
+	rec.AccountAfter = count
+	rec.AccountDelta = rec.AccountAfter - rec.AccountBefore
+
+	if results.ListID > 0 {
+		list, err := b.GetList(results.ListID)
+		if err != nil {
+			rec.Error = err.Error()
+			return
+		}
+		rec.ListID = results.ListID
+		rec.ListAfter = list.UniqueSubscribers
+		rec.ListDelta = rec.ListAfter - rec.ListBefore
+	}
+
+	rec.Shortfall = float64(rec.AccountDelta) < float64(rec.NewContacts)*reconcileTolerance
+
+	log.Printf("Reconciliation: account %d -> %d (%+d), list %d -> %d (%+d), %d new contacts reported",
+		rec.AccountBefore, rec.AccountAfter, rec.AccountDelta, rec.ListBefore, rec.ListAfter, rec.ListDelta, rec.NewContacts)
+	if rec.Shortfall {
+		log.Printf("Warning: the account grew by %d contacts but the run reported %d new ones. The import may have silently failed.",
+			rec.AccountDelta, rec.NewContacts)
+	}
+}
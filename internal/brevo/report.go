@@ -0,0 +1,90 @@
+package brevo
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var resultsCSVHeader = []string{"row", "email", "action", "reason", "contact_id"}
+
+// SortByEmail orders the per-contact slices of r by lowercased email, then
+// row, so reports of the same input compare cleanly across runs no matter in
+// which order contacts finished.
+func (r *ProcessingResults) SortByEmail() {
+	byContact := func(a, b ContactResult) int {
+		return cmp.Or(cmp.Compare(strings.ToLower(a.Email), strings.ToLower(b.Email)), cmp.Compare(a.Row, b.Row))
+	}
+
+	slices.SortStableFunc(r.AddedToCampaign, byContact)
+	slices.SortStableFunc(r.UpdatedContacts, byContact)
+	slices.SortStableFunc(r.Skipped, byContact)
+	slices.SortStableFunc(r.Errors, func(a, b ErrorResult) int {
+		return cmp.Or(cmp.Compare(strings.ToLower(a.Email), strings.ToLower(b.Email)), cmp.Compare(a.Row, b.Row))
+	})
+	slices.SortStableFunc(r.Routing, func(a, b RoutingDecision) int {
+		return cmp.Or(cmp.Compare(strings.ToLower(a.Email), strings.ToLower(b.Email)), cmp.Compare(a.Row, b.Row))
+	})
+}
+
+// WriteResultsCSV writes a flat email,action,reason,contact_id summary of results to
+// path, one row per contact, for reviewers who prefer a spreadsheet.
+func WriteResultsCSV(results ProcessingResults, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create results CSV '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+
+	if err := writer.Write(resultsCSVHeader); err != nil {
+		return fmt.Errorf("failed to write results CSV header: %w", err)
+	}
+
+	groups := []struct {
+		action   string
+		contacts []ContactResult
+	}{
+		{"added", results.AddedToCampaign},
+		{"updated", results.UpdatedContacts},
+		{"skipped", results.Skipped},
+	}
+
+	for _, group := range groups {
+		for _, c := range group.contacts {
+			if err := writer.Write([]string{formatRow(c.Row), c.Email, group.action, c.Reason, formatRow(c.ContactID)}); err != nil {
+				return fmt.Errorf("failed to write results CSV row for %s: %w", c.Email, err)
+			}
+		}
+	}
+
+	for _, e := range results.Errors {
+		reason := e.Error
+		if e.Details != "" {
+			reason = fmt.Sprintf("%s (%s)", e.Error, e.Details)
+		}
+
+		if err := writer.Write([]string{formatRow(e.Row), e.Email, "error", reason, ""}); err != nil {
+			return fmt.Errorf("failed to write results CSV row for %s: %w", e.Email, err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush results CSV: %w", err)
+	}
+
+	return file.Close()
+}
+
+func formatRow(row int) string {
+	if row <= 0 {
+		return ""
+	}
+	return strconv.Itoa(row)
+}
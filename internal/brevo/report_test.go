@@ -0,0 +1,102 @@
+package brevo
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSortByEmail(t *testing.T) {
+	contacts := func(rows ...ContactResult) []ContactResult { return rows }
+	emails := func(results []ContactResult) []string {
+		var out []string
+		for _, result := range results {
+			out = append(out, result.Email)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		results  ProcessingResults
+		wantAdd  []string
+		wantRows []int
+	}{
+		{
+			name: "completion order becomes email order",
+			results: ProcessingResults{AddedToCampaign: contacts(
+				ContactResult{Row: 2, Email: "c@example.com"},
+				ContactResult{Row: 3, Email: "A@example.com"},
+				ContactResult{Row: 4, Email: "b@example.com"},
+			)},
+			wantAdd:  []string{"A@example.com", "b@example.com", "c@example.com"},
+			wantRows: []int{3, 4, 2},
+		},
+		{
+			name: "same email is ordered by row",
+			results: ProcessingResults{AddedToCampaign: contacts(
+				ContactResult{Row: 9, Email: "a@example.com"},
+				ContactResult{Row: 5, Email: "A@example.com"},
+			)},
+			wantAdd:  []string{"A@example.com", "a@example.com"},
+			wantRows: []int{5, 9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := tt.results
+			results.Errors = Errors{{Row: 4, Email: "z@example.com"}, {Row: 2, Email: "y@example.com"}}
+			results.Skipped = contacts(ContactResult{Row: 3, Email: "n@example.com"}, ContactResult{Row: 2, Email: "m@example.com"})
+			results.SortByEmail()
+
+			if got := emails(results.AddedToCampaign); !slices.Equal(got, tt.wantAdd) {
+				t.Errorf("added = %v, want %v", got, tt.wantAdd)
+			}
+			var rows []int
+			for _, result := range results.AddedToCampaign {
+				rows = append(rows, result.Row)
+			}
+			if !slices.Equal(rows, tt.wantRows) {
+				t.Errorf("rows = %v, want %v", rows, tt.wantRows)
+			}
+			if results.Errors[0].Email != "y@example.com" || results.Skipped[0].Email != "m@example.com" {
+				t.Errorf("errors %v and skipped %v are not sorted", results.Errors, emails(results.Skipped))
+			}
+		})
+	}
+}
+
+func TestRunOnceWritesResultsCSVWhenRunFails(t *testing.T) {
+	service, _ := newRunService(t)
+	service.config.MaxAPICallsPerRun = 8
+	service.config.ReportCSVPath = filepath.Join(t.TempDir(), "results.csv")
+	service.config.Process.Decoder = HeaderDecoder{}
+
+	lines := []string{"email"}
+	for i := range 20 {
+		lines = append(lines, fmt.Sprintf("winner%d@example.com", i))
+	}
+
+	if _, err := service.runOnce(writeCSV(t, lines...)); !errors.Is(err, ErrAPIBudgetExceeded) {
+		t.Fatalf("runOnce() error = %v, want ErrAPIBudgetExceeded", err)
+	}
+
+	file, err := os.Open(service.config.ReportCSVPath)
+	if err != nil {
+		t.Fatalf("results CSV not written for a failed run: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("read results CSV: %v", err)
+	}
+	if len(rows) < 2 || !slices.Equal(rows[0], resultsCSVHeader) {
+		t.Errorf("results CSV = %v, want the header and the rows processed before the failure", rows)
+	}
+}
@@ -0,0 +1,78 @@
+package brevo
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// ReprocessErrors retries the contacts whose earlier failure was marked
+// retryable and returns results with their new outcome merged in. Failures
+// that are not about a contact (e.g. campaign errors) and permanent failures
+// are carried over untouched. A listID of 0 reuses results.ListID.
+//
+// existingContacts should be the map the run deduped against, so retried
+// updates still get the existing-contact handling; nil fetches it again.
+func (b *BrevoService) ReprocessErrors(results ProcessingResults, csvData []CSVData, existingContacts map[string]bool, listID int) (ProcessingResults, error) {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	if listID == 0 {
+		listID = results.ListID
+	}
+	if listID == 0 {
+		return results, fmt.Errorf("cannot reprocess errors: no list to import into")
+	}
+
+	if existingContacts == nil {
+		var err error
+		existingContacts, err = b.fetchRunContacts(nil)
+		if err != nil {
+			return results, fmt.Errorf("failed to fetch existing contacts: %w", err)
+		}
+	}
+
+	byEmail := make(map[string]int, len(csvData))
+	for i, data := range csvData {
+		byEmail[strings.ToLower(data.Email)] = i
+	}
+
+	pending := results.Errors
+	results.Errors = Errors{}
+	retried := 0
+
+	for _, errResult := range pending {
+		if !errResult.Retryable || errResult.Email == "" {
+			results.Errors = append(results.Errors, errResult)
+			continue
+		}
+
+		index, ok := rowIndex(errResult.Row, len(csvData))
+		if !ok || !strings.EqualFold(csvData[index].Email, errResult.Email) {
+			index, ok = byEmail[strings.ToLower(errResult.Email)]
+		}
+		if !ok {
+			log.Printf("Cannot retry %s: no matching CSV row", errResult.Email)
+			results.Errors = append(results.Errors, errResult)
+			continue
+		}
+
+		retried++
+		contactService, cancel := b.withContactBudget(b.config.Process)
+		if failed := contactService.importContact(index+2, &csvData[index], existingContacts, []int{listID}, &results); failed >= 0 {
+			results.Errors[failed].Attempts = errResult.Attempts + 1
+		}
+		cancel()
+	}
+
+	results.ErrorCategories = results.Errors.ByCategory()
+
+	log.Printf("Reprocessed %d retryable errors. Remaining errors: %d", retried, len(results.Errors))
+	return results, nil
+}
+
+// rowIndex converts a 1-based CSV row (header on row 1) to a csvData index.
+func rowIndex(row, count int) (int, bool) {
+	index := row - 2
+	return index, row > 0 && index >= 0 && index < count
+}
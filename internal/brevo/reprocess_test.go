@@ -0,0 +1,121 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestReprocessErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantErrors   int
+		wantAttempts int
+		wantAdded    int
+	}{
+		{"retry succeeds", http.StatusCreated, 1, 0, 1},
+		{"retry fails again", http.StatusServiceUnavailable, 2, 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/v3/contacts" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, `{"id":1}`)
+			}))
+
+			csvData := []CSVData{{Email: "retry@example.com"}, {Email: "permanent@example.com"}}
+			results := ProcessingResults{ListID: 9}
+			retry := results.Errors.addRow(2, "retry@example.com", &apiStatusError{StatusCode: 503, Message: "unavailable"}, "Failed to add/update contact")
+			results.Errors[retry].Attempts = 2
+			results.Errors.addRow(3, "permanent@example.com", ErrMissingEmail, "Skipping contact with no email address")
+
+			got, err := service.ReprocessErrors(results, csvData, map[string]bool{}, 0)
+			if err != nil {
+				t.Fatalf("ReprocessErrors() error = %v", err)
+			}
+
+			if got.Errors.Count() != tt.wantErrors {
+				t.Fatalf("errors = %+v, want %d", got.Errors, tt.wantErrors)
+			}
+			attempts := make(map[string]int)
+			for _, errResult := range got.Errors {
+				attempts[errResult.Email] = errResult.Attempts
+			}
+			if attempts["permanent@example.com"] != 1 {
+				t.Errorf("permanent failure attempts = %d, want it carried over with 1", attempts["permanent@example.com"])
+			}
+			if attempts["retry@example.com"] != tt.wantAttempts {
+				t.Errorf("retried attempts = %d, want %d", attempts["retry@example.com"], tt.wantAttempts)
+			}
+			if len(got.AddedToCampaign) != tt.wantAdded {
+				t.Errorf("added = %d, want %d", len(got.AddedToCampaign), tt.wantAdded)
+			}
+			if got.ErrorCategories[ErrorCategoryValidation] != 1 {
+				t.Errorf("ErrorCategories = %v, want one validation error", got.ErrorCategories)
+			}
+		})
+	}
+}
+
+func TestReprocessErrorsKeepsExistingContacts(t *testing.T) {
+	var sent ContactPayload
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v3/contacts":
+			offset := r.URL.Query().Get("offset")
+			if offset != "0" {
+				fmt.Fprint(w, `{"contacts":[],"count":1}`)
+				return
+			}
+			fmt.Fprint(w, `{"contacts":[{"id":7,"email":"retry@example.com"}],"count":1}`)
+		case "GET /v3/contacts/retry@example.com":
+			fmt.Fprint(w, `{"id":7,"email":"retry@example.com","attributes":{"COMPANY_NAME":"Curated Ltd"}}`)
+		case "POST /v3/contacts":
+			json.NewDecoder(r.Body).Decode(&sent)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+	service.config.ImmutableAttributes = map[string]bool{"COMPANY_NAME": true}
+
+	csvData := []CSVData{{Email: "retry@example.com", VendorName: "Acme"}}
+	results := ProcessingResults{ListID: 9}
+	results.Errors.addRow(2, "retry@example.com", &apiStatusError{StatusCode: 503, Message: "unavailable"}, "Failed to add/update contact")
+
+	got, err := service.ReprocessErrors(results, csvData, nil, 0)
+	if err != nil {
+		t.Fatalf("ReprocessErrors() error = %v", err)
+	}
+
+	if _, ok := sent.Attributes["COMPANY_NAME"]; ok {
+		t.Errorf("sent attributes = %v, want the immutable COMPANY_NAME left out", sent.Attributes)
+	}
+	if len(got.UpdatedContacts) != 1 || len(got.UpdatedContacts[0].Preserved) != 1 {
+		t.Errorf("updated = %+v, want retry@example.com updated with COMPANY_NAME preserved", got.UpdatedContacts)
+	}
+}
+
+func TestReprocessErrorsRequiresList(t *testing.T) {
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	}))
+
+	results := ProcessingResults{}
+	results.Errors.addRow(2, "retry@example.com", &apiStatusError{StatusCode: 503, Message: "unavailable"}, "Failed to add/update contact")
+
+	got, err := service.ReprocessErrors(results, []CSVData{{Email: "retry@example.com"}}, map[string]bool{}, 0)
+	if err == nil {
+		t.Fatal("ReprocessErrors() error = nil, want one for a run without a list")
+	}
+	if got.Errors.Count() != 1 {
+		t.Errorf("errors = %+v, want the pending error kept", got.Errors)
+	}
+}
@@ -0,0 +1,307 @@
+package brevo
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JournalConfig records every Brevo API call to a JSON-lines file, or, in
+// replay mode, answers API calls from such a file instead of the network.
+type JournalConfig struct {
+	// Path is the journal file. Empty disables the journal.
+	Path string
+	// Replay serves responses recorded in Path instead of calling Brevo.
+	Replay bool
+}
+
+// JournalEntry is one recorded API call. Emails, phone numbers and other
+// contact details are replaced by stable tokens, so a journal can be shared;
+// replayed responses carry the tokens, not the original values. The api-key
+// header is never recorded. A call that got no response has Status 0 and
+// the transport failure in Error, and replays as that failure.
+type JournalEntry struct {
+	Time     time.Time       `json:"time"`
+	Method   string          `json:"method"`
+	URL      string          `json:"url"`
+	Payload  json.RawMessage `json:"payload,omitempty"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+const brevoAPIHost = "api.brevo.com"
+
+// piiAttributes are payload keys whose values are redacted wherever they
+// appear, on top of any string that looks like an email.
+var piiAttributes = map[string]bool{
+	"EMAIL":          true,
+	"EMAILS":         true,
+	"SMS":            true,
+	"PHONE":          true,
+	"FAX":            true,
+	"ADDRESS":        true,
+	"CONTACTS":       true,
+	"CONTACT_PERSON": true,
+	"COMPANY_ID":     true,
+	"ID_CODE":        true,
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// redactToken replaces value with a token derived from its hash, so the
+// same value always redacts to the same token.
+func redactToken(value string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(value)))
+	return "redacted-" + hex.EncodeToString(sum[:6])
+}
+
+func redactEmails(s string) string {
+	return emailPattern.ReplaceAllStringFunc(s, func(email string) string {
+		return redactToken(email) + "@redacted.invalid"
+	})
+}
+
+// redactURL redacts emails and phone numbers used as path identifiers.
+func redactURL(raw string) string {
+	if unescaped, err := url.PathUnescape(raw); err == nil {
+		raw = unescaped
+	}
+
+	parsed, err := url.Parse(redactEmails(raw))
+	if err != nil {
+		return redactEmails(raw)
+	}
+
+	if parsed.Query().Get("identifierType") == "phone_id" {
+		segments := strings.Split(parsed.Path, "/")
+		last := len(segments) - 1
+		segments[last] = redactToken(segments[last])
+		parsed.Path = strings.Join(segments, "/")
+	}
+
+	return parsed.String()
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return redactEmails(v)
+	case []any:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+		return v
+	case map[string]any:
+		for key, inner := range v {
+			if piiAttributes[strings.ToUpper(key)] {
+				v[key] = redactPII(inner)
+				continue
+			}
+			v[key] = redactValue(inner)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func redactPII(value any) any {
+	switch v := value.(type) {
+	case string:
+		if emailPattern.MatchString(v) {
+			return redactEmails(v)
+		}
+		return redactToken(v)
+	case []any, map[string]any:
+		return redactValue(v)
+	default:
+		return redactToken(fmt.Sprint(v))
+	}
+}
+
+// redactBody returns body as redacted JSON, or nil when it is empty or not
+// JSON.
+func redactBody(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		redacted, _ := json.Marshal(redactEmails(string(body)))
+		return redacted
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+// journalTransport records each round trip to the journal file, or serves
+// recorded responses in replay mode.
+type journalTransport struct {
+	next http.RoundTripper
+
+	mu      sync.Mutex
+	file    *os.File
+	replay  map[string][]JournalEntry
+	replays bool
+}
+
+func newJournalTransport(cfg JournalConfig, next http.RoundTripper) (*journalTransport, error) {
+	t := &journalTransport{next: next, replays: cfg.Replay}
+
+	if cfg.Replay {
+		entries, err := readJournal(cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		t.replay = make(map[string][]JournalEntry)
+		for _, entry := range entries {
+			key := entry.Method + " " + entry.URL
+			t.replay[key] = append(t.replay[key], entry)
+		}
+		return t, nil
+	}
+
+	file, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open request journal %s: %w", cfg.Path, err)
+	}
+	t.file = file
+	return t, nil
+}
+
+func readJournal(path string) ([]JournalEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open request journal %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid request journal entry on line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read request journal %s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+func (t *journalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only Brevo API calls are journaled. Webhook URLs carry their own
+	// credentials and export downloads are not API calls.
+	if req.URL.Host != brevoAPIHost {
+		return t.next.RoundTrip(req)
+	}
+
+	var payload []byte
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err == nil {
+			payload, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	entry := JournalEntry{
+		Time:    time.Now().UTC(),
+		Method:  req.Method,
+		URL:     redactURL(req.URL.String()),
+		Payload: redactBody(payload),
+	}
+
+	if t.replays {
+		return t.replayed(req, entry)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		entry.Error = redactEmails(err.Error())
+		t.record(entry)
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		entry.Status = resp.StatusCode
+		entry.Error = redactEmails(err.Error())
+		t.record(entry)
+		return nil, fmt.Errorf("failed to read response body for journal: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	entry.Status = resp.StatusCode
+	entry.Response = redactBody(body)
+	t.record(entry)
+
+	return resp, nil
+}
+
+// replayed answers req with the next recorded response for the same method
+// and URL.
+func (t *journalTransport) replayed(req *http.Request, entry JournalEntry) (*http.Response, error) {
+	key := entry.Method + " " + entry.URL
+
+	t.mu.Lock()
+	recorded := t.replay[key]
+	if len(recorded) == 0 {
+		t.mu.Unlock()
+		return nil, fmt.Errorf("no recorded response for %s", key)
+	}
+	next := recorded[0]
+	t.replay[key] = recorded[1:]
+	t.mu.Unlock()
+
+	if next.Error != "" {
+		return nil, fmt.Errorf("replayed failure for %s: %s", key, next.Error)
+	}
+
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", next.Status, http.StatusText(next.Status)),
+		StatusCode: next.Status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(next.Response)),
+		Request:    req,
+	}, nil
+}
+
+func (t *journalTransport) record(entry JournalEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.file.Write(append(line, '\n'))
+}
@@ -0,0 +1,56 @@
+package brevo
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestJournalRecordsTransportFailures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+
+	failing := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("dial tcp: connection refused for a@example.com")
+	})
+	recorder, err := newJournalTransport(JournalConfig{Path: path}, failing)
+	if err != nil {
+		t.Fatalf("newJournalTransport() error = %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", "https://api.brevo.com/v3/contacts/a@example.com", nil)
+	if _, err := recorder.RoundTrip(req); err == nil {
+		t.Fatal("RoundTrip() succeeded, want the transport failure")
+	}
+	recorder.file.Close()
+
+	entries, err := readJournal(path)
+	if err != nil {
+		t.Fatalf("readJournal() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("entries = %d, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Status != 0 || !strings.Contains(entry.Error, "connection refused") {
+		t.Errorf("entry = %+v, want status 0 and the transport error", entry)
+	}
+	if strings.Contains(entry.Error, "a@example.com") || strings.Contains(entry.URL, "a@example.com") {
+		t.Errorf("entry = %+v, want the email redacted", entry)
+	}
+
+	replayer, err := newJournalTransport(JournalConfig{Path: path, Replay: true}, failing)
+	if err != nil {
+		t.Fatalf("newJournalTransport(replay) error = %v", err)
+	}
+	if _, err := replayer.RoundTrip(req); err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("replayed RoundTrip() error = %v, want the recorded failure", err)
+	}
+}
@@ -0,0 +1,145 @@
+package brevo
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how transient Brevo failures (network errors, 429
+// and 5xx) are retried. Backoff doubles after each attempt up to MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 2 * time.Second,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// DefaultRunRetryPolicy runs the pipeline once; RUN_RETRY_ATTEMPTS raises it
+// for the unattended nightly job.
+func DefaultRunRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    1,
+		InitialBackoff: 5 * time.Minute,
+		MaxBackoff:     30 * time.Minute,
+	}
+}
+
+// isRetryableRun reports whether a failed run can safely be started again.
+// Imports are upserts and the next attempt imports into the failed
+// attempt's list; once a campaign exists another attempt could email twice.
+// A campaign whose create response was lost has no ID, so every campaign
+// the run tried to create is looked up by name, and one that cannot be
+// checked counts as created.
+func (b *BrevoService) isRetryableRun(results ProcessingResults, err error) bool {
+	if errors.Is(err, ErrRecipientCapExceeded) || errors.Is(err, ErrAPIBudgetExceeded) {
+		return false
+	}
+
+	for _, campaign := range runCampaigns(results) {
+		if campaign.CampaignID > 0 {
+			return false
+		}
+		if campaign.CampaignName == "" {
+			continue
+		}
+
+		campaignID, found, lookupErr := b.findCampaignByName(campaign.CampaignName)
+		if lookupErr != nil {
+			log.Printf("Warning: Could not check whether campaign '%s' was created: %v", campaign.CampaignName, lookupErr)
+			return false
+		}
+		if found {
+			log.Printf("Campaign '%s' was created despite the failed response (ID: %d)", campaign.CampaignName, campaignID)
+			return false
+		}
+	}
+
+	return true
+}
+
+// runCampaigns lists every campaign a run created or tried to create.
+func runCampaigns(results ProcessingResults) []CampaignResult {
+	campaigns := []CampaignResult{results.CampaignInfo}
+	for _, routed := range results.RoutedCampaigns {
+		campaigns = append(campaigns, routed.Campaign)
+	}
+	for _, scheduled := range results.TimezoneCampaigns {
+		campaigns = append(campaigns, scheduled.Campaign)
+	}
+	return campaigns
+}
+
+// isRetryableStatus reports whether a response status is worth retrying.
+// Status 0 stands for a request that never got a response.
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == 0 ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= http.StatusInternalServerError
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return d
+}
+
+// wait sleeps for the backoff of attempt, returning false if the service
+// context is cancelled first.
+func (b *BrevoService) wait(attempt int) bool {
+	timer := time.NewTimer(b.config.Retry.backoff(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-b.context().Done():
+		return false
+	}
+}
+
+// postCampaignWithRetry retries postCampaign on transient failures and only
+// reports the failure once the retry policy is exhausted. Creating a
+// campaign is not idempotent: when the failure may have come after Brevo
+// created it (no response, or a 5xx), the campaign is looked up by name
+// first and only re-created when it does not exist.
+func (b *BrevoService) postCampaignWithRetry(payload CampaignPayload) (CampaignResult, string) {
+	result, body := b.postCampaign(payload)
+
+	for attempt := 1; !result.Success && isRetryableStatus(result.StatusCode) && attempt < b.config.Retry.MaxAttempts; attempt++ {
+		log.Printf("Campaign creation attempt %d failed (%s). Retrying in %s...", attempt, result.Error, b.config.Retry.backoff(attempt))
+
+		if !b.wait(attempt) {
+			break
+		}
+
+		if result.StatusCode != http.StatusTooManyRequests {
+			campaignID, found, err := b.findCampaignByName(payload.Name)
+			if err != nil {
+				log.Printf("Not retrying campaign '%s': could not check whether it was created: %v", payload.Name, err)
+				break
+			}
+			if found {
+				log.Printf("Campaign '%s' was created despite the failed response (ID: %d)", payload.Name, campaignID)
+				return CampaignResult{Success: true, CampaignID: campaignID, CampaignName: payload.Name, StatusCode: http.StatusCreated}, ""
+			}
+		}
+
+		result, body = b.postCampaign(payload)
+	}
+
+	return result, body
+}
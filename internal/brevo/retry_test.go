@@ -0,0 +1,198 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsRetryableRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		results     ProcessingResults
+		err         error
+		listStatus  int
+		wantRetry   bool
+		wantLookups int
+	}{
+		{
+			name:      "failed before any campaign",
+			err:       fmt.Errorf("failed to create contact list"),
+			wantRetry: true,
+		},
+		{
+			name:    "campaign exists",
+			results: ProcessingResults{CampaignInfo: CampaignResult{CampaignID: 7}},
+			err:     fmt.Errorf("failed to send"),
+		},
+		{
+			name: "recipient cap",
+			err:  fmt.Errorf("wrapped: %w", ErrRecipientCapExceeded),
+		},
+		{
+			name: "api budget",
+			err:  fmt.Errorf("wrapped: %w", ErrAPIBudgetExceeded),
+		},
+		{
+			name:        "lost create response found by name",
+			results:     ProcessingResults{CampaignInfo: CampaignResult{CampaignName: "Winners - 1"}},
+			err:         fmt.Errorf("campaign creation failed"),
+			listStatus:  http.StatusOK,
+			wantLookups: 1,
+		},
+		{
+			name:        "routed campaign found by name",
+			results:     ProcessingResults{RoutedCampaigns: []RoutedCampaign{{Campaign: CampaignResult{CampaignName: "Winners - 1"}}}},
+			err:         fmt.Errorf("campaign creation failed"),
+			listStatus:  http.StatusOK,
+			wantLookups: 1,
+		},
+		{
+			name:        "create failed and nothing was created",
+			results:     ProcessingResults{CampaignInfo: CampaignResult{CampaignName: "Winners - 2"}},
+			err:         fmt.Errorf("campaign creation failed"),
+			listStatus:  http.StatusOK,
+			wantRetry:   true,
+			wantLookups: 1,
+		},
+		{
+			name:        "lookup fails",
+			results:     ProcessingResults{CampaignInfo: CampaignResult{CampaignName: "Winners - 1"}},
+			err:         fmt.Errorf("campaign creation failed"),
+			listStatus:  http.StatusBadGateway,
+			wantLookups: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookups := 0
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/emailCampaigns" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+				lookups++
+				w.WriteHeader(tt.listStatus)
+				fmt.Fprint(w, `{"campaigns":[{"id":7,"name":"Winners - 1","status":"draft"}]}`)
+			}))
+
+			if got := service.isRetryableRun(tt.results, tt.err); got != tt.wantRetry {
+				t.Errorf("isRetryableRun() = %v, want %v", got, tt.wantRetry)
+			}
+			if lookups != tt.wantLookups {
+				t.Errorf("lookups = %d, want %d", lookups, tt.wantLookups)
+			}
+		})
+	}
+}
+
+func TestPostCampaignWithRetry(t *testing.T) {
+	// Each step is one create attempt: the status Brevo answers and whether
+	// it created the campaign anyway.
+	type step struct {
+		status  int
+		created bool
+	}
+
+	tests := []struct {
+		name        string
+		steps       []step
+		wantSuccess bool
+		wantID      int
+		wantCreates int
+		wantLookups int
+	}{
+		{
+			name:        "503 then 201",
+			steps:       []step{{status: http.StatusServiceUnavailable}, {status: http.StatusCreated, created: true}},
+			wantSuccess: true,
+			wantID:      1,
+			wantCreates: 2,
+			wantLookups: 1,
+		},
+		{
+			name:        "lost response found by name",
+			steps:       []step{{status: http.StatusBadGateway, created: true}},
+			wantSuccess: true,
+			wantID:      1,
+			wantCreates: 1,
+			wantLookups: 1,
+		},
+		{
+			name:        "429 retried without a lookup",
+			steps:       []step{{status: http.StatusTooManyRequests}, {status: http.StatusCreated, created: true}},
+			wantSuccess: true,
+			wantID:      1,
+			wantCreates: 2,
+		},
+		{
+			name:        "client error is not retried",
+			steps:       []step{{status: http.StatusBadRequest}},
+			wantCreates: 1,
+		},
+		{
+			name: "retries exhausted",
+			steps: []step{
+				{status: http.StatusServiceUnavailable},
+				{status: http.StatusServiceUnavailable},
+				{status: http.StatusServiceUnavailable},
+			},
+			wantCreates: 3,
+			wantLookups: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var campaigns []campaignStatusResponse
+			creates, lookups := 0, 0
+
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/emailCampaigns" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					return
+				}
+
+				if r.Method == http.MethodGet {
+					lookups++
+					json.NewEncoder(w).Encode(map[string]any{"campaigns": campaigns})
+					return
+				}
+
+				if creates >= len(tt.steps) {
+					t.Errorf("unexpected create attempt %d", creates+1)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				current := tt.steps[creates]
+				creates++
+
+				if current.created {
+					campaigns = append(campaigns, campaignStatusResponse{ID: len(campaigns) + 1, Name: "Winners - 1", Status: "draft"})
+				}
+				w.WriteHeader(current.status)
+				if current.status == http.StatusCreated {
+					fmt.Fprintf(w, `{"id":%d}`, len(campaigns))
+				}
+			}))
+			service.config.Retry = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+			result, _ := service.postCampaignWithRetry(CampaignPayload{Name: "Winners - 1"})
+
+			if result.Success != tt.wantSuccess || result.CampaignID != tt.wantID {
+				t.Errorf("postCampaignWithRetry() = %+v, want success %v with ID %d", result, tt.wantSuccess, tt.wantID)
+			}
+			if creates != tt.wantCreates {
+				t.Errorf("create attempts = %d, want %d", creates, tt.wantCreates)
+			}
+			if lookups != tt.wantLookups {
+				t.Errorf("lookups = %d, want %d", lookups, tt.wantLookups)
+			}
+			if len(campaigns) > 1 {
+				t.Errorf("%d campaigns were created, want at most 1", len(campaigns))
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ReportSchemaVersion identifies the layout of RunReport. Bump it whenever a
+// field is removed, renamed or changes meaning so report consumers can
+// branch on it; purely additive fields do not need a bump.
+const ReportSchemaVersion int = 1
+
+// RunReport is the canonical artifact of a single run: what happened to the
+// contacts (Results) plus what this run was (source file, timing, config).
+type RunReport struct {
+	SchemaVersion int               `json:"schema_version"`
+	Version       string            `json:"version"`
+	RunID         string            `json:"run_id"`
+	CSVPath       string            `json:"csv_path"`
+	CSVHash       string            `json:"csv_sha256,omitempty"`
+	StartedAt     time.Time         `json:"started_at"`
+	FinishedAt    time.Time         `json:"finished_at"`
+	Duration      string            `json:"duration"`
+	Config        Config            `json:"config"`
+	Results       ProcessingResults `json:"results"`
+	// Reconciliation is set when ProcessOptions.ReconcileCounts is on.
+	Reconciliation *Reconciliation `json:"reconciliation,omitempty"`
+	// CampaignHTMLPath is where the campaign's HTML, as Brevo holds it, was
+	// archived when ArchiveCampaignHTML is on.
+	CampaignHTMLPath string `json:"campaign_html_path,omitempty"`
+	// APICalls is how many Brevo API calls the run made.
+	APICalls int    `json:"api_calls"`
+	Error    string `json:"error,omitempty"`
+}
+
+func newRunID(startedAt time.Time) string {
+	return fmt.Sprintf("%s-%s", startedAt.Format("20060102T150405"), shortSuffix())
+}
+
+// EffectiveConfig returns the configuration the service runs with, after
+// defaults, .env, environment variables and overrides were applied, with
+// secrets redacted. It is the same configuration run reports record.
+func (b *BrevoService) EffectiveConfig() Config {
+	return redactConfig(b.config)
+}
+
+// redactConfig returns a copy of config that is safe to write to disk.
+func redactConfig(config Config) Config {
+	config.APIKey = redactSecret(config.APIKey)
+	// Chat webhook URLs embed their own credentials.
+	if config.Webhook.URL != "" {
+		config.Webhook.URL = redactSecret(config.Webhook.URL)
+	}
+
+	images := make([]InlineImage, len(config.Campaign.InlineImages))
+	for i, img := range config.Campaign.InlineImages {
+		images[i] = InlineImage{Placeholder: img.Placeholder, ContentType: img.ContentType}
+	}
+	config.Campaign.InlineImages = images
+
+	return config
+}
+
+func redactSecret(secret string) string {
+	if len(secret) <= 4 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
+
+func WriteRunReport(report RunReport, path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode run report: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write run report '%s': %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package brevo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEffectiveConfigPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	dotenv := "BREVO_API_KEY=xkeysib-dotenv-1234\n" +
+		"SENDER_NAME=Dotenv Sender\n" +
+		"SENDER_EMAIL=dotenv@example.com\n" +
+		"BREVO_FETCH_TIMEOUT=45s\n" +
+		"RUN_WEBHOOK_URL=https://hooks.example.com/services/T000/B000/secret\n"
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(dotenv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	// godotenv.Load sets what it reads in the process environment;
+	// registering the keys restores them once the test ends.
+	for _, key := range []string{"BREVO_API_KEY", "SENDER_NAME", "RUN_WEBHOOK_URL", "BREVO_ADD_TIMEOUT"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	t.Setenv("SENDER_EMAIL", "env@example.com")
+	t.Setenv("BREVO_FETCH_TIMEOUT", "90s")
+	t.Setenv("SENDER_OVERRIDE_EMAIL", "staging@example.com")
+	t.Setenv("SENDER_OVERRIDE_NAME", "")
+
+	service, err := NewBrevoService()
+	if err != nil {
+		t.Fatalf("NewBrevoService() error = %v", err)
+	}
+	effective := service.EffectiveConfig()
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "default when nothing sets it", got: effective.Timeouts.AddTimeout, want: DefaultAddTimeout},
+		{name: ".env over the default", got: effective.SenderName, want: "Dotenv Sender"},
+		{name: "environment over .env", got: effective.Timeouts.FetchTimeout, want: 90 * time.Second},
+		{name: "override over environment and .env", got: effective.SenderEmail, want: "staging@example.com"},
+		{name: "api key redacted", got: effective.APIKey, want: "****1234"},
+		{name: "webhook URL redacted", got: effective.Webhook.URL, want: "****cret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	if service.config.APIKey != "xkeysib-dotenv-1234" {
+		t.Errorf("EffectiveConfig() redacted the service's own key")
+	}
+}
@@ -0,0 +1,44 @@
+package brevo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxScheduleAhead caps how far out ScheduleAfter may push a send. Brevo
+// accepts dates well beyond it, but a send months away is almost certainly
+// a typo in the duration.
+const maxScheduleAhead = 30 * 24 * time.Hour
+
+// ErrScheduleWindow is returned, wrapped, when a computed send time falls
+// outside the window a campaign can be scheduled in.
+var ErrScheduleWindow = errors.New("send time outside the scheduling window")
+
+// WithClock replaces time.Now for schedule computations.
+func WithClock(now func() time.Time) Option {
+	return func(b *BrevoService) {
+		b.clock = now
+	}
+}
+
+// now returns the current time from the configured clock.
+func (b *BrevoService) now() time.Time {
+	if b.clock != nil {
+		return b.clock()
+	}
+	return time.Now()
+}
+
+// scheduleAfter returns the send time d after now, checking that Brevo will
+// accept it: at least scheduleLeadTime away and at most maxScheduleAhead.
+func (b *BrevoService) scheduleAfter(d time.Duration) (time.Time, error) {
+	if d < scheduleLeadTime {
+		return time.Time{}, fmt.Errorf("%w: %s is less than the minimum lead time of %s", ErrScheduleWindow, d, scheduleLeadTime)
+	}
+	if d > maxScheduleAhead {
+		return time.Time{}, fmt.Errorf("%w: %s is more than the maximum of %s", ErrScheduleWindow, d, maxScheduleAhead)
+	}
+
+	return b.now().Add(d), nil
+}
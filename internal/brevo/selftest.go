@@ -0,0 +1,306 @@
+package brevo
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const selfTestFolderName string = "Self Test"
+
+// SelfTestStep is the outcome of one stage of the self-test.
+type SelfTestStep struct {
+	Name     string `json:"name"`
+	Passed   bool   `json:"passed"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+// SelfTestReport lists every self-test step in the order it ran.
+type SelfTestReport struct {
+	Recipient string         `json:"recipient"`
+	Steps     []SelfTestStep `json:"steps"`
+	Passed    bool           `json:"passed"`
+}
+
+// SelfTest loads the configuration from the environment and runs the
+// self-test against it. The recipient is SELFTEST_RECIPIENT or, when unset,
+// the sender address.
+func SelfTest() (SelfTestReport, error) {
+	service, err := NewBrevoService()
+	if err != nil {
+		return SelfTestReport{}, fmt.Errorf("failed to initialize Brevo service: %w", err)
+	}
+
+	recipient := os.Getenv("SELFTEST_RECIPIENT")
+	if recipient == "" {
+		recipient = service.config.SenderEmail
+	}
+
+	return service.SelfTest(recipient), nil
+}
+
+// SelfTest runs the whole pipeline once against a built-in single-row CSV:
+// it creates a folder and list, imports recipient, creates a campaign and
+// sends it as a test email to recipient only. Everything it created is
+// deleted afterwards, including the contact unless it already existed; an
+// existing contact gets the attributes it had before the import back.
+func (b *BrevoService) SelfTest(recipient string) SelfTestReport {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	report := SelfTestReport{Recipient: recipient, Steps: []SelfTestStep{}}
+
+	step := func(name string, fn func() error) bool {
+		started := time.Now()
+		err := fn()
+		result := SelfTestStep{Name: name, Passed: err == nil, Duration: time.Since(started).Round(time.Millisecond).String()}
+		if err != nil {
+			result.Error = err.Error()
+			log.Printf("Self-test step '%s' failed: %v", name, err)
+		} else {
+			log.Printf("Self-test step '%s' passed", name)
+		}
+		report.Steps = append(report.Steps, result)
+		return err == nil
+	}
+
+	var (
+		folderID       int
+		createdFolder  bool
+		list           ContactList
+		contactExisted bool
+		before         map[string]any
+		importedEmail  string
+		campaignID     int
+	)
+
+	defer func() {
+		step("teardown", func() error {
+			return b.afterRun().selfTestTeardown(campaignID, list.ID, importedEmail, before, contactExisted, folderID, createdFolder)
+		})
+
+		report.Passed = true
+		for _, s := range report.Steps {
+			report.Passed = report.Passed && s.Passed
+		}
+	}()
+
+	if !step("connectivity", b.Ping) {
+		return report
+	}
+
+	if !step("sender", func() error {
+		_, err := b.selectSender()
+		return err
+	}) {
+		return report
+	}
+
+	if !step("template", func() error {
+		_, err := b.renderCampaignHTML(b.config.Campaign)
+		return err
+	}) {
+		return report
+	}
+
+	if !step("folder", func() error {
+		id, found, err := b.findFolder(selfTestFolderName)
+		if err != nil {
+			return err
+		}
+		if !found {
+			if id, err = b.CreateFolder(selfTestFolderName); err != nil {
+				return err
+			}
+			createdFolder = true
+		}
+		folderID = id
+		return nil
+	}) {
+		return report
+	}
+
+	if !step("list", func() error {
+		var err error
+		list, err = b.createList(fmt.Sprintf("Self Test - %s", time.Now().Format("2006-01-02 15:04:05")), folderID)
+		return err
+	}) {
+		return report
+	}
+
+	if !step("import", func() error {
+		records, err := readCSVRecords(strings.NewReader(selfTestCSV(recipient)), b.config.Process.CSV)
+		if err != nil {
+			return err
+		}
+		csvData, err := mapCSVToObject(records, PositionalDecoder{}, b.config.Process.CSV)
+		if err != nil {
+			return err
+		}
+
+		before, err = b.getContactAttributes(recipient)
+		if err != nil {
+			return err
+		}
+		contactExisted = before != nil
+
+		resp, err := b.AddContact(recipient, map[string]bool{strings.ToLower(recipient): contactExisted}, []int{list.ID}, &csvData[0])
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if _, ok := ContactActionForStatus(resp.StatusCode); !ok {
+			return fmt.Errorf("unexpected status %d importing %s", resp.StatusCode, recipient)
+		}
+		importedEmail = recipient
+		return nil
+	}) {
+		return report
+	}
+
+	if !step("campaign", func() error {
+		result := b.CreateNewCampaign(list.ID, b.config.Campaign, CampaignMeta{CSVName: "selftest", Count: 1, Source: "selftest"})
+		if !result.Success {
+			return fmt.Errorf("%s", result.Error)
+		}
+		campaignID = result.CampaignID
+		return nil
+	}) {
+		return report
+	}
+
+	step("send test", func() error {
+		return b.sendTestEmail(campaignID, []string{recipient})
+	})
+
+	return report
+}
+
+// selfTestCSV is a one-row positional CSV for recipient.
+func selfTestCSV(recipient string) string {
+	header := strings.Join(csvColumns, ",")
+	row := []string{"1", "", "SELFTEST", "1", "Self Test", recipient, "", "Self Test", "", "", "", "", "", ""}
+	return header + "\n" + strings.Join(row, ",") + "\n"
+}
+
+// sendTestEmail sends campaignID as a test email to emails only; the
+// campaign's list is not mailed.
+func (b *BrevoService) sendTestEmail(campaignID int, emails []string) error {
+	url := fmt.Sprintf("https://api.brevo.com/v3/emailCampaigns/%d/sendTest", campaignID)
+
+	resp, err := b.makeAPIRequest(opCampaign, "POST", url, map[string][]string{"emailTo": emails})
+	if err != nil {
+		return fmt.Errorf("exception sending test email for campaign %d: %w", campaignID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to send test email for campaign %d: status %d - %s", campaignID, resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// selfTestTeardown deletes what the self-test created, in reverse order, and
+// restores the attributes of a contact that existed before.
+func (b *BrevoService) selfTestTeardown(campaignID, listID int, email string, before map[string]any, contactExisted bool, folderID int, createdFolder bool) error {
+	var failures []string
+
+	if campaignID > 0 {
+		if err := b.deleteResource(fmt.Sprintf("https://api.brevo.com/v3/emailCampaigns/%d", campaignID)); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if email != "" && !contactExisted {
+		if err := b.deleteResource("https://api.brevo.com/v3/contacts/" + url.PathEscape(email)); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if email != "" && contactExisted {
+		if err := b.restoreContactAttributes(email, before); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if listID > 0 {
+		if err := b.deleteResource(fmt.Sprintf("https://api.brevo.com/v3/contacts/lists/%d", listID)); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if createdFolder && folderID > 0 {
+		if err := b.deleteResource(fmt.Sprintf("https://api.brevo.com/v3/contacts/folders/%d", folderID)); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// restoreContactAttributes puts back the attributes email had before the
+// self-test imported it. Attributes it did not have are cleared.
+func (b *BrevoService) restoreContactAttributes(email string, before map[string]any) error {
+	current, err := b.getContactAttributes(email)
+	if err != nil {
+		return fmt.Errorf("failed to restore contact %s: %w", email, err)
+	}
+
+	restore := make(map[string]any)
+	for key := range diffAttributes(before, current) {
+		if value, ok := before[key]; ok {
+			restore[key] = value
+		} else {
+			restore[key] = ""
+		}
+	}
+
+	if len(restore) == 0 {
+		return nil
+	}
+
+	contactURL := "https://api.brevo.com/v3/contacts/" + url.PathEscape(email)
+	resp, err := b.makeAPIRequest(opAdd, "PUT", contactURL, map[string]any{"attributes": restore})
+	if err != nil {
+		return fmt.Errorf("exception restoring contact %s: %w", email, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to restore contact %s: status %d - %s", email, resp.StatusCode, string(body))
+	}
+
+	log.Printf("Restored %d attributes of existing contact %s", len(restore), email)
+	return nil
+}
+
+// deleteResource deletes the object at resourceURL. An object that is
+// already gone counts as deleted.
+func (b *BrevoService) deleteResource(resourceURL string) error {
+	resp, err := b.makeAPIRequest(opAdd, "DELETE", resourceURL, nil)
+	if err != nil {
+		return fmt.Errorf("exception deleting %s: %w", resourceURL, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("failed to delete %s: status %d - %s", resourceURL, resp.StatusCode, string(body))
+}
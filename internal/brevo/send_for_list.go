@@ -0,0 +1,83 @@
+package brevo
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// ErrListEmpty is returned, wrapped, when a campaign would go to a list
+// without recipients.
+var ErrListEmpty = errors.New("list has no recipients")
+
+// ListCampaignResult is the outcome of SendCampaignForList.
+type ListCampaignResult struct {
+	ListID     int                  `json:"list_id"`
+	ListName   string               `json:"list_name"`
+	Recipients int                  `json:"recipients"`
+	Campaign   CampaignResult       `json:"campaign"`
+	Send       SendCampaignResult   `json:"send"`
+	Pending    *PendingConfirmation `json:"pending_confirmation,omitempty"`
+}
+
+// SendCampaignForList creates and sends a campaign to an existing list
+// without touching the CSV or any contacts. It is the recovery path when an
+// earlier run imported its contacts but failed to send.
+func (b *BrevoService) SendCampaignForList(listID int, cfg CampaignConfig) (ListCampaignResult, error) {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	opts := b.config.Process
+
+	list, err := b.GetList(listID)
+	if err != nil {
+		return ListCampaignResult{ListID: listID}, fmt.Errorf("failed to load list %d: %w", listID, err)
+	}
+
+	result := ListCampaignResult{ListID: list.ID, ListName: list.Name, Recipients: list.TotalSubscribers}
+	if result.Recipients == 0 {
+		return result, fmt.Errorf("%w: list %d ('%s')", ErrListEmpty, list.ID, list.Name)
+	}
+
+	if err := b.checkRecipientCap(opts, list.ID, result.Recipients); err != nil {
+		return result, err
+	}
+
+	log.Printf("Sending a campaign to existing list '%s' (ID: %d) with %d recipients", list.Name, list.ID, result.Recipients)
+
+	result.Campaign = b.CreateNewCampaign(list.ID, cfg, CampaignMeta{
+		CSVName: list.Name,
+		Count:   result.Recipients,
+		RunID:   newRunID(b.now()),
+		Source:  "recovery",
+	})
+	if !result.Campaign.Success {
+		return result, fmt.Errorf("failed to create campaign for list %d: %s", list.ID, result.Campaign.Error)
+	}
+
+	if pending := b.pendingConfirmation(opts, result.Campaign.CampaignID, list.ID, result.Recipients); pending != nil {
+		log.Printf("Campaign %d has %d recipients, above the confirmation threshold of %d. Not sending until ConfirmAndSend is called.",
+			pending.CampaignID, pending.Recipients, pending.Threshold)
+		result.Pending = pending
+		return result, nil
+	}
+
+	result.Send = b.ResumeSend(result.Campaign.CampaignID)
+	if !result.Send.Success {
+		return result, fmt.Errorf("campaign %d was created but not sent (resume with ResumeSend): %s", result.Campaign.CampaignID, result.Send.Error)
+	}
+
+	log.Printf("Campaign %d sent to list '%s' (ID: %d)", result.Campaign.CampaignID, list.Name, list.ID)
+	return result, nil
+}
+
+// SendForList is the standalone recovery trigger: it sends the configured
+// campaign to listID.
+func SendForList(listID int) (ListCampaignResult, error) {
+	service, err := NewBrevoService()
+	if err != nil {
+		return ListCampaignResult{}, fmt.Errorf("failed to initialize Brevo service: %w", err)
+	}
+
+	return service.SendCampaignForList(listID, service.config.Campaign)
+}
@@ -0,0 +1,156 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/mail"
+	"os"
+	"strings"
+)
+
+type Sender struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type brevoSender struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Active bool   `json:"active"`
+}
+
+type sendersResponse struct {
+	Senders []brevoSender `json:"senders"`
+}
+
+// parseSenders reads a comma-separated list like
+// "Name <a@x.com>, b@y.com" as used by SENDER_FALLBACKS.
+func parseSenders(value string) ([]Sender, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	addresses, err := mail.ParseAddressList(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender list '%s': %w", value, err)
+	}
+
+	senders := make([]Sender, 0, len(addresses))
+	for _, addr := range addresses {
+		senders = append(senders, Sender{Name: addr.Name, Email: addr.Address})
+	}
+
+	return senders, nil
+}
+
+func (b *BrevoService) getActiveSenders() (map[string]bool, error) {
+	resp, err := b.makeAPIRequest(opFetch, "GET", "https://api.brevo.com/v3/senders", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching senders: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read senders response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch senders: status %d - %s", resp.StatusCode, string(body))
+	}
+
+	var sendersResp sendersResponse
+	if err := json.Unmarshal(body, &sendersResp); err != nil {
+		return nil, fmt.Errorf("failed to decode senders response: %w", err)
+	}
+
+	active := make(map[string]bool, len(sendersResp.Senders))
+	for _, sender := range sendersResp.Senders {
+		if sender.Active {
+			active[strings.ToLower(sender.Email)] = true
+		}
+	}
+
+	return active, nil
+}
+
+// VerifySender reports whether email is a verified, active sender on the
+// Brevo account.
+func (b *BrevoService) VerifySender(email string) (bool, error) {
+	active, err := b.getActiveSenders()
+	if err != nil {
+		return false, err
+	}
+	return active[strings.ToLower(email)], nil
+}
+
+// selectSender returns the configured sender, or the first verified
+// fallback when the primary sender is not verified. Without fallbacks the
+// primary is used as is.
+func (b *BrevoService) selectSender() (Sender, error) {
+	primary := Sender{Name: b.config.SenderName, Email: b.config.SenderEmail}
+
+	if len(b.config.SenderFallbacks) == 0 {
+		return primary, nil
+	}
+
+	active, err := b.getActiveSenders()
+	if err != nil {
+		log.Printf("Warning: Could not verify senders (%v). Using primary sender %s.", err, primary.Email)
+		return primary, nil
+	}
+
+	candidates := append([]Sender{primary}, b.config.SenderFallbacks...)
+	for i, sender := range candidates {
+		if !active[strings.ToLower(sender.Email)] {
+			log.Printf("Sender %s is not verified. Skipping.", sender.Email)
+			continue
+		}
+
+		if sender.Name == "" {
+			sender.Name = primary.Name
+		}
+
+		if i > 0 {
+			log.Printf("Falling back to sender %s (%d of %d)", sender.Email, i+1, len(candidates))
+		}
+		return sender, nil
+	}
+
+	return Sender{}, fmt.Errorf("none of %d configured senders is verified", len(candidates))
+}
+
+// applySenderOverride replaces the configured sender with
+// SENDER_OVERRIDE_EMAIL and SENDER_OVERRIDE_NAME when set, so a staging run
+// can use a test sender on the production deployment. Fallback senders are
+// dropped while an override is active so a run never falls back to a
+// production sender.
+func applySenderOverride(config *Config) error {
+	email := strings.TrimSpace(os.Getenv("SENDER_OVERRIDE_EMAIL"))
+	name := strings.TrimSpace(os.Getenv("SENDER_OVERRIDE_NAME"))
+	if email == "" && name == "" {
+		return nil
+	}
+
+	if email != "" {
+		addr, err := mail.ParseAddress(email)
+		if err != nil || addr.Address != email {
+			return fmt.Errorf("invalid SENDER_OVERRIDE_EMAIL '%s'", email)
+		}
+		config.SenderEmail = email
+	}
+	if name != "" {
+		config.SenderName = name
+	}
+
+	config.SenderFallbacks = nil
+	config.SenderOverridden = true
+
+	log.Printf("WARNING: SENDER OVERRIDE ACTIVE. Campaigns will be sent as %s <%s>, not the configured sender.",
+		config.SenderName, config.SenderEmail)
+	return nil
+}
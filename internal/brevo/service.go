@@ -2,8 +2,11 @@ package brevo
 
 import (
 	"bytes"
-	"encoding/csv"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"github.com/joho/godotenv"
@@ -13,6 +16,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"text/template"
 	"time"
 )
 
@@ -22,6 +26,80 @@ type Config = struct {
 	APIKey      string
 	SenderName  string
 	SenderEmail string
+	SenderFallbacks []Sender
+	// SenderOverridden is set when SENDER_OVERRIDE_EMAIL or
+	// SENDER_OVERRIDE_NAME replaced the sender for this run.
+	SenderOverridden bool
+	Campaign    CampaignConfig
+	Timeouts    Timeouts
+	Retry       RetryPolicy
+	// RunRetry retries the whole run after a failure that happened before
+	// any campaign was created.
+	RunRetry    RetryPolicy
+	Process     ProcessOptions
+	DefaultCountry string
+	AllowPartialFetch bool
+	// TargetListID imports into an existing list instead of creating one,
+	// and limits the dedup fetch to that list's members.
+	TargetListID int
+	ReportPath  string
+	ReportCSVPath string
+	// SortResults orders the report's contact results by email so reports
+	// of the same input diff cleanly.
+	SortResults bool
+	// ArchiveCampaignHTML saves the created campaign's HTML, fetched back
+	// from Brevo, next to the run report.
+	ArchiveCampaignHTML bool
+	// MaxLogBodyBytes caps logged request and response bodies. Zero uses
+	// DefaultMaxLogBodyBytes; a negative value logs them whole.
+	MaxLogBodyBytes int
+	// MaxAPICallsPerRun aborts a run that makes more Brevo API calls than
+	// this, as a guard against runaway loops. Zero uses
+	// DefaultMaxAPICallsPerRun.
+	MaxAPICallsPerRun int
+	// PlanPath makes Start write a Plan of the run there instead of
+	// running it. A path ending in .csv gets the CSV form.
+	PlanPath    string
+	FetchCheckpointPath string
+	SMSConflictStrategy SMSConflictStrategy
+	// TrackContactChanges fetches each existing contact before updating it
+	// so the report can show what changed. It costs one extra request per
+	// existing contact.
+	TrackContactChanges bool
+	// UpdateByContactID updates a contact the service already knows the
+	// Brevo ID of by that ID instead of upserting it by email.
+	UpdateByContactID bool
+	// ListDescriptionTemplate renders a description for the run's list from
+	// ListDescriptionData, so lists document their source in the Brevo UI.
+	ListDescriptionTemplate string
+	// SourceRowAttribute, when set, names the Brevo attribute that stores
+	// each contact's CSV row number. The attribute must already exist in
+	// the account as a number.
+	SourceRowAttribute string
+	Reason      ReasonConfig
+	Consent     ConsentConfig
+	ContactPerson ContactPersonConfig
+	Webhook     WebhookConfig
+	Digest      DigestConfig
+	AttributeLimits AttributeLimits
+	// ClearOnEmpty names attributes that are cleared on existing contacts
+	// when their CSV value is empty. Other empty values are skipped so the
+	// value in Brevo is kept. Bulk imports never clear.
+	ClearOnEmpty map[string]bool
+	// ImmutableAttributes are never overwritten on an existing contact that
+	// already has a value for them, e.g. a manually corrected COMPANY_NAME.
+	// Empty values are still filled in.
+	ImmutableAttributes map[string]bool
+	// DuplicateNames decides between folders or lists sharing a name.
+	DuplicateNames DuplicateNamePolicy
+	// UserAgent replaces DefaultUserAgent when set.
+	UserAgent string
+	// RateLimit caps requests per second for the whole account, with
+	// RateBurst requests allowed at once. It defaults to DefaultRateLimit;
+	// zero leaves requests unthrottled.
+	RateLimit float64
+	RateBurst int
+	Journal   JournalConfig
 }
 
 type CSVData struct {
@@ -39,6 +117,9 @@ type CSVData struct {
 	Fax        string `json:"fax"`
 	City       string `json:"city"`
 	Country    string `json:"country"`
+	// Extra holds the columns the header decoder found beyond the export's,
+	// keyed by normalized header name.
+	Extra      map[string]string `json:"extra,omitempty"`
 }
 
 type BrevoContact struct {
@@ -49,6 +130,8 @@ type BrevoContact struct {
 	CreatedAt         string                 `json:"createdAt"`
 	ModifiedAt        string                 `json:"modifiedAt"`
 	ListIds           []int                  `json:"listIds"`
+	// ListUnsubscribed holds the lists the contact unsubscribed from.
+	ListUnsubscribed  []int                  `json:"listUnsubscribed,omitempty"`
 	Attributes        map[string]any         `json:"attributes"`
 }
 
@@ -56,6 +139,29 @@ type BrevoContact struct {
 type BrevoService struct {
 	config Config
 	httpClient *http.Client
+	ctx context.Context
+	credentials *credentials
+	checkpoint FetchCheckpoint
+	payloadTransformer PayloadTransformer
+	headers http.Header
+	allowHeaderOverride bool
+	statsCache *accountStatsCache
+	deprecations *deprecationTracker
+	sources map[string]Source
+	limiter *RateLimiter
+	clock func() time.Time
+	reasonTemplate *template.Template
+	listDescriptionTemplate *template.Template
+	// contactBudget is set on the copy importing a single contact under
+	// ProcessOptions.ContactTimeout.
+	contactBudget time.Duration
+	contactIDs *contactIDs
+	renamedAttributes *renamedAttributes
+	// apiBudget is set on the copy running a single run.
+	apiBudget *apiBudget
+	// retryListID is the list a failed run attempt created, reused by the
+	// next attempt instead of creating another.
+	retryListID int
 }
 
 type ContactsResponse struct {
@@ -93,46 +199,130 @@ type CampaignPayload struct {
 	Subject     string            `json:"subject"`
 	HTMLContent string            `json:"htmlContent"`
 	Recipients  map[string][]int  `json:"recipients"`
+	AttachmentURL string          `json:"attachmentUrl,omitempty"`
+	InlineImageActivation bool    `json:"inlineImageActivation,omitempty"`
+	ScheduledAt   string          `json:"scheduledAt,omitempty"`
+	// Tag holds the campaign's tags joined by commas, since Brevo takes a
+	// single tag string per campaign.
+	Tag           string          `json:"tag,omitempty"`
 }
 
 type CampaignResult struct {
 	Success      bool   `json:"success"`
 	CampaignID   int    `json:"campaign_id,omitempty"`
 	CampaignName string `json:"campaign_name,omitempty"`
+	SenderEmail  string `json:"sender_email,omitempty"`
 	StatusCode   int    `json:"status_code"`
 	Error        string `json:"error,omitempty"`
+	// Tags are the campaign's tags; Brevo only stores the first.
+	Tags         []string `json:"tags,omitempty"`
+	HTMLIssues   []Issue  `json:"html_issues,omitempty"`
 }
 
 type SendCampaignResult struct {
-	Success    bool   `json:"success"`
-	Message    string `json:"message,omitempty"`
-	StatusCode int    `json:"status_code"`
-	Error      string `json:"error,omitempty"`
+	Success     bool   `json:"success"`
+	AlreadySent bool   `json:"already_sent,omitempty"`
+	Message     string `json:"message,omitempty"`
+	StatusCode  int    `json:"status_code"`
+	Error       string `json:"error,omitempty"`
 }
 
 type ProcessingResults struct {
 	AddedToCampaign        []ContactResult `json:"added_to_campaign"`
 	UpdatedContacts        []ContactResult `json:"updated_contacts"`
-	Errors                 []ErrorResult   `json:"errors"`
+	Skipped                []ContactResult `json:"skipped"`
+	Errors                 Errors          `json:"errors"`
+	// ErrorCategories counts Errors per category.
+	ErrorCategories        map[ErrorCategory]int `json:"error_categories,omitempty"`
+	FolderID               int             `json:"folder_id,omitempty"`
+	ListID                 int             `json:"list_id,omitempty"`
+	ListName               string          `json:"list_name,omitempty"`
+	// ListDescription is the description set on the run's list from
+	// ListDescriptionTemplate. It is empty when Brevo refused it or the
+	// run reused an existing list.
+	ListDescription        string          `json:"list_description,omitempty"`
 	CampaignInfo           CampaignResult  `json:"campaign_info"`
 	TotalExistingContacts  int             `json:"total_existing_contacts"`
+	Warnings               []string        `json:"warnings,omitempty"`
+	ContactLatency         LatencyStats    `json:"contact_latency"`
+	// PendingConfirmation is set when the campaign was created but not sent
+	// because it exceeded the confirmation threshold.
+	PendingConfirmation    *PendingConfirmation `json:"pending_confirmation,omitempty"`
+	// Excluded counts contacts left out of the list, keyed by reason.
+	Excluded               map[string]int  `json:"excluded,omitempty"`
+	// SkippedDomains counts contacts skipped by DomainAllowlist, keyed by
+	// email domain.
+	SkippedDomains         map[string]int  `json:"skipped_domains,omitempty"`
+	TimezoneCampaigns      []TimezoneCampaign `json:"timezone_campaigns,omitempty"`
+	RoutedCampaigns        []RoutedCampaign   `json:"routed_campaigns,omitempty"`
+	// Routing records each contact's ListRouting decision.
+	Routing                []RoutingDecision  `json:"routing,omitempty"`
+	// CampaignScheduledAt is when Brevo will send the campaign, when
+	// ScheduleAfter scheduled it rather than sending it right away.
+	CampaignScheduledAt    *time.Time         `json:"campaign_scheduled_at,omitempty"`
+	// CampaignVerifiedStatus is the status the campaign reached when
+	// VerifySentTimeout checked it after sending.
+	CampaignVerifiedStatus string             `json:"campaign_verified_status,omitempty"`
+	// CampaignSkipped is set when too few recipients made the run skip
+	// creating and sending the campaign.
+	CampaignSkipped        *CampaignSkip      `json:"campaign_skipped,omitempty"`
+
+	latencies []time.Duration
+	excluded  map[string]string
+	// csvHash is the SHA-256 of the CSV as stored, taken while it was read.
+	csvHash string
 }
 
+const (
+	ContactActionAdded   string = "Added"
+	ContactActionUpdated string = "Updated"
+	// ContactActionImported is a contact a bulk import loaded without
+	// telling whether it created or updated it.
+	ContactActionImported string = "Imported"
+)
+
 type ContactResult struct {
-	Email  string   `json:"email"`
-	Data   *CSVData `json:"data"`
-	Action string   `json:"action,omitempty"`
+	Row        int      `json:"row,omitempty"`
+	Email      string   `json:"email"`
+	Data       *CSVData `json:"data"`
+	Action     string   `json:"action,omitempty"`
+	Reason     string   `json:"reason,omitempty"`
+	StatusCode int      `json:"status_code,omitempty"`
+	// SMSConflict names the strategy applied when the contact's SMS was
+	// already taken by another contact.
+	SMSConflict SMSConflictStrategy `json:"sms_conflict,omitempty"`
+	// Changes maps each attribute an update modified to its old and new
+	// value. Only set for updated contacts when TrackContactChanges is on.
+	Changes map[string]AttributeChange `json:"changes,omitempty"`
+	// Truncations lists attribute values cut or dropped for being too long.
+	Truncations []AttributeTruncation `json:"truncations,omitempty"`
+	// ListIDs holds the run's list and the routed list when ListRouting
+	// sent the contact to a second list.
+	ListIDs []int `json:"list_ids,omitempty"`
+	// Preserved lists immutable attributes left unchanged because the
+	// contact already had a value.
+	Preserved []string `json:"preserved,omitempty"`
+	// InclusionReason is why the contact was included, as written to the
+	// reason attribute.
+	InclusionReason string `json:"inclusion_reason,omitempty"`
+	// ContactID is the contact's Brevo ID when the run learned it, from the
+	// create response or a fetch of the contact.
+	ContactID int `json:"contact_id,omitempty"`
 }
 
 type ErrorResult struct {
-	Email   string `json:"email,omitempty"`
-	Error   string `json:"error"`
-	Details string `json:"details,omitempty"`
+	Row       int    `json:"row,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Error     string `json:"error"`
+	Details   string `json:"details,omitempty"`
+	Category  ErrorCategory `json:"category,omitempty"`
+	Retryable bool   `json:"retryable"`
+	Attempts  int    `json:"attempts,omitempty"`
 }
 
 
 
-func NewBrevoService() (*BrevoService, error) {
+func NewBrevoService(opts ...Option) (*BrevoService, error) {
 	err := godotenv.Load()
 
 	if err != nil {
@@ -143,105 +333,484 @@ func NewBrevoService() (*BrevoService, error) {
 		APIKey:      os.Getenv("BREVO_API_KEY"),
 		SenderName:  os.Getenv("SENDER_NAME"),
 		SenderEmail: os.Getenv("SENDER_EMAIL"),
+		Campaign:    DefaultCampaignConfig(),
+		Timeouts:    Timeouts{
+			FetchTimeout:    getEnvDuration("BREVO_FETCH_TIMEOUT", DefaultFetchTimeout),
+			AddTimeout:      getEnvDuration("BREVO_ADD_TIMEOUT", DefaultAddTimeout),
+			CampaignTimeout: getEnvDuration("BREVO_CAMPAIGN_TIMEOUT", DefaultCampaignTimeout),
+		},
+		Retry:       DefaultRetryPolicy(),
+		RunRetry:    DefaultRunRetryPolicy(),
+		Process:     DefaultProcessOptions(),
+		DefaultCountry: strings.ToUpper(strings.TrimSpace(os.Getenv("DEFAULT_COUNTRY"))),
+		ReportPath:  os.Getenv("REPORT_PATH"),
+		ReportCSVPath: os.Getenv("REPORT_CSV_PATH"),
+		SortResults: getEnvBool("SORT_RESULTS", false),
+		ArchiveCampaignHTML: getEnvBool("ARCHIVE_CAMPAIGN_HTML", false),
+		MaxLogBodyBytes: getEnvInt("MAX_LOG_BODY_BYTES", DefaultMaxLogBodyBytes),
+		MaxAPICallsPerRun: getEnvInt("MAX_API_CALLS_PER_RUN", DefaultMaxAPICallsPerRun),
+		PlanPath: os.Getenv("PLAN_PATH"),
+		FetchCheckpointPath: os.Getenv("FETCH_CHECKPOINT_PATH"),
+		AllowPartialFetch: getEnvBool("ALLOW_PARTIAL_CONTACT_FETCH", false),
+		TargetListID: getEnvInt("TARGET_LIST_ID", 0),
+		TrackContactChanges: getEnvBool("TRACK_CONTACT_CHANGES", false),
+		UpdateByContactID: getEnvBool("UPDATE_BY_CONTACT_ID", false),
+		UserAgent: strings.TrimSpace(os.Getenv("USER_AGENT")),
+		Webhook:   WebhookConfig{
+			URL:          os.Getenv("RUN_WEBHOOK_URL"),
+			Format:       strings.ToLower(strings.TrimSpace(os.Getenv("RUN_WEBHOOK_FORMAT"))),
+			TemplateFile: os.Getenv("RUN_WEBHOOK_TEMPLATE"),
+			PreviewURL:   os.Getenv("CAMPAIGN_PREVIEW_URL"),
+		},
+		SourceRowAttribute: strings.ToUpper(strings.TrimSpace(os.Getenv("SOURCE_ROW_ATTRIBUTE"))),
+	}
+
+	if name := normalizeAttributeName(config.SourceRowAttribute); name != config.SourceRowAttribute {
+		log.Printf("Warning: SOURCE_ROW_ATTRIBUTE '%s' does not follow Brevo's naming rules. Using '%s'.", config.SourceRowAttribute, name)
+		config.SourceRowAttribute = name
+	}
+
+	config.Reason = ReasonConfig{
+		Attribute: normalizeAttributeName(os.Getenv("REASON_ATTRIBUTE")),
+		Template:  os.Getenv("REASON_TEMPLATE"),
+	}
+	config.Consent = ConsentConfig{
+		Column:              strings.TrimSpace(os.Getenv("CONSENT_DATE_COLUMN")),
+		Attribute:           normalizeAttributeName(os.Getenv("CONSENT_DATE_ATTRIBUTE")),
+		Layout:              os.Getenv("CONSENT_DATE_LAYOUT"),
+		DefaultToImportDate: getEnvBool("CONSENT_DATE_DEFAULT_TODAY", false),
+	}
+
+	config.ContactPerson.Attribute = normalizeAttributeName(os.Getenv("CONTACT_PERSON_ATTRIBUTE"))
+	config.ContactPerson.Names, err = parseContactNames(os.Getenv("CONTACT_PERSON_NAMES"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CONTACT_PERSON_NAMES: %w", err)
+	}
+
+	reasonTemplate, err := parseReasonTemplate(config.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REASON_TEMPLATE: %w", err)
+	}
+
+	config.ListDescriptionTemplate = os.Getenv("LIST_DESCRIPTION_TEMPLATE")
+	listDescriptionTemplate, err := parseListDescriptionTemplate(config.ListDescriptionTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LIST_DESCRIPTION_TEMPLATE: %w", err)
+	}
+
+	config.Process.RunTimeout = getEnvDuration("RUN_TIMEOUT", 0)
+	config.Process.ContactTimeout = getEnvDuration("CONTACT_TIMEOUT", 0)
+	config.RunRetry.MaxAttempts = getEnvInt("RUN_RETRY_ATTEMPTS", config.RunRetry.MaxAttempts)
+	config.RunRetry.InitialBackoff = getEnvDuration("RUN_RETRY_BACKOFF", config.RunRetry.InitialBackoff)
+	config.Process.SkipCampaign = !getEnvBool("CREATE_CAMPAIGN", true)
+	config.Process.MaxRecipients = getEnvInt("MAX_RECIPIENTS", 0)
+	config.Process.MinRecipients = getEnvInt("MIN_RECIPIENTS", 0)
+	config.Process.ScheduleAfter = getEnvDuration("CAMPAIGN_SCHEDULE_AFTER", 0)
+	config.Process.VerifySentTimeout = getEnvDuration("CAMPAIGN_VERIFY_SENT_TIMEOUT", 0)
+	config.Process.ReconcileCounts = getEnvBool("RECONCILE_COUNTS", false)
+	config.Process.OverrideRecipientCap = getEnvBool("OVERRIDE_RECIPIENT_CAP", false)
+	config.Process.ConfirmationThreshold = getEnvInt("CONFIRMATION_THRESHOLD", 0)
+	config.Process.BulkImport = getEnvBool("BULK_IMPORT", false)
+	config.Process.RetryFailedImports = getEnvBool("BULK_RETRY_FAILED", false)
+	config.Process.SkipBlacklisted = getEnvBool("SKIP_BLACKLISTED", false)
+	config.Process.SkipListUnsubscribed = getEnvBool("SKIP_LIST_UNSUBSCRIBED", false)
+	config.Process.SuppressionListPath = os.Getenv("SUPPRESSION_LIST_PATH")
+	config.Process.DomainAllowlist = parseDomainAllowlist(os.Getenv("DOMAIN_ALLOWLIST"))
+	config.Process.ImportTimeout = getEnvDuration("IMPORT_TIMEOUT", DefaultImportTimeout)
+	config.Process.FolderStrategy, err = folderStrategyByName(os.Getenv("FOLDER_STRATEGY"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid FOLDER_STRATEGY: %w", err)
+	}
+
+	config.Process.Decoder, err = decoderByName(os.Getenv("CSV_DECODER"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV_DECODER: %w", err)
+	}
+
+	if localTime := os.Getenv("SCHEDULE_LOCAL_TIME"); localTime != "" {
+		hour, minute, err := parseLocalTime(localTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SCHEDULE_LOCAL_TIME: %w", err)
+		}
+		config.Process.TimezoneSchedule = &TimezoneAwareSchedule{
+			LocalHour:       hour,
+			LocalMinute:     minute,
+			DefaultTimezone: os.Getenv("SCHEDULE_DEFAULT_TIMEZONE"),
+		}
+		if tz := config.Process.TimezoneSchedule.DefaultTimezone; tz != "" {
+			if _, err := time.LoadLocation(tz); err != nil {
+				return nil, fmt.Errorf("invalid SCHEDULE_DEFAULT_TIMEZONE: %w", err)
+			}
+		}
+	}
+
+	if name := strings.TrimSpace(os.Getenv("CUMULATIVE_LIST_NAME")); name != "" {
+		day := time.Friday
+		if value := os.Getenv("CUMULATIVE_SEND_DAY"); value != "" {
+			if day, err = parseWeekday(value); err != nil {
+				return nil, fmt.Errorf("invalid CUMULATIVE_SEND_DAY: %w", err)
+			}
+		}
+		config.Process.Cumulative = &CumulativeList{
+			ListName:       name,
+			SendDay:        day,
+			StatePath:      os.Getenv("CUMULATIVE_STATE_PATH"),
+			ClearAfterSend: getEnvBool("CUMULATIVE_CLEAR_AFTER_SEND", false),
+		}
+	}
+
+	if column := os.Getenv("LIST_ROUTING_COLUMN"); column != "" {
+		routes, err := parseListRoutes(os.Getenv("LIST_ROUTING_LISTS"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid LIST_ROUTING_LISTS: %w", err)
+		}
+		config.Process.ListRouting = &ListRouting{
+			Column:           column,
+			ListIDs:          routes,
+			PerListCampaigns: getEnvBool("LIST_ROUTING_CAMPAIGNS", false),
+			RequireMatch:     getEnvBool("LIST_ROUTING_REQUIRE_MATCH", false),
+		}
+
+		if path := os.Getenv("LIST_ROUTING_FILE"); path != "" {
+			config.Process.ListRouting.ListNames, err = LoadListRoutes(path)
+			if err != nil {
+				return nil, fmt.Errorf("invalid LIST_ROUTING_FILE: %w", err)
+			}
+		}
+
+		if err := config.Process.ListRouting.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid list routing: %w", err)
+		}
+	}
+
+	config.Process.CSV.TrimSpace = getEnvBool("CSV_TRIM_SPACE", true)
+	config.Process.CSV.CollapseNameSpaces = getEnvBool("CSV_COLLAPSE_NAME_SPACES", false)
+	config.Process.CSV.LazyQuotes = getEnvBool("CSV_LAZY_QUOTES", false)
+	config.Process.CSV.Comment, err = parseCSVComment(os.Getenv("CSV_COMMENT"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV_COMMENT: %w", err)
+	}
+	if err := config.Process.CSV.validate(); err != nil {
+		return nil, fmt.Errorf("invalid CSV options: %w", err)
+	}
+
+	config.Campaign.AttachmentURL = os.Getenv("CAMPAIGN_ATTACHMENT_URL")
+	if tags := os.Getenv("CAMPAIGN_TAGS"); tags != "" {
+		config.Campaign.Tags = strings.Split(tags, ",")
+	}
+	config.Campaign.NameTemplate = os.Getenv("CAMPAIGN_NAME_TEMPLATE")
+	config.Campaign.InlineImageActivation = getEnvBool("CAMPAIGN_INLINE_IMAGE_ACTIVATION", false)
+	config.Campaign.InlineCSS = getEnvBool("CAMPAIGN_INLINE_CSS", false)
+	config.Campaign.MinifyHTML = getEnvBool("CAMPAIGN_MINIFY_HTML", false)
+	config.Campaign.ValidateHTML = getEnvBool("CAMPAIGN_VALIDATE_HTML", false)
+	config.Campaign.StrictHTML = getEnvBool("CAMPAIGN_STRICT_HTML", false)
+	config.Campaign.Language = strings.ToLower(strings.TrimSpace(os.Getenv("CAMPAIGN_LANGUAGE")))
+	if config.Campaign.Language != "" {
+		if err := validateLanguage(config.Campaign.Language); err != nil {
+			return nil, fmt.Errorf("invalid CAMPAIGN_LANGUAGE: %w", err)
+		}
+	}
+
+	config.SMSConflictStrategy, err = parseSMSConflictStrategy(os.Getenv("SMS_CONFLICT_STRATEGY"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMS_CONFLICT_STRATEGY: %w", err)
+	}
+
+	if _, ok := webhookTemplates[config.Webhook.Format]; config.Webhook.Format != "" && !ok {
+		return nil, fmt.Errorf("invalid RUN_WEBHOOK_FORMAT '%s' (use generic, slack or teams)", config.Webhook.Format)
+	}
+
+	config.AttributeLimits.MaxLength, err = parseAttributeLimits(os.Getenv("ATTRIBUTE_MAX_LENGTHS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ATTRIBUTE_MAX_LENGTHS: %w", err)
+	}
+	config.AttributeLimits.DefaultMaxLength = getEnvInt("ATTRIBUTE_MAX_LENGTH", 0)
+	config.AttributeLimits.SkipOversized = getEnvBool("SKIP_OVERSIZED_ATTRIBUTES", false)
+
+	config.DuplicateNames, err = parseDuplicateNamePolicy(os.Getenv("DUPLICATE_NAME_POLICY"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DUPLICATE_NAME_POLICY: %w", err)
+	}
+
+	config.ClearOnEmpty, err = parseAttributeSet(os.Getenv("CLEAR_ON_EMPTY"), csvFilledAttributes(config.ContactPerson.Attribute))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CLEAR_ON_EMPTY: %w", err)
+	}
+
+	config.ImmutableAttributes, err = parseAttributeSet(os.Getenv("IMMUTABLE_ATTRIBUTES"), csvFilledAttributes(config.ContactPerson.Attribute))
+	if err != nil {
+		return nil, fmt.Errorf("invalid IMMUTABLE_ATTRIBUTES: %w", err)
+	}
+
+	config.RateLimit = getEnvFloat("BREVO_RATE_LIMIT", DefaultRateLimit)
+	config.RateBurst = getEnvInt("BREVO_RATE_BURST", 1)
+
+	config.Journal.Path = os.Getenv("REQUEST_JOURNAL")
+	config.Journal.Replay = getEnvBool("REQUEST_JOURNAL_REPLAY", false)
+	if config.Journal.Replay && config.Journal.Path == "" {
+		return nil, fmt.Errorf("REQUEST_JOURNAL_REPLAY requires REQUEST_JOURNAL")
+	}
+
+	config.Digest.Recipients, err = parseSenders(os.Getenv("DIGEST_RECIPIENTS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DIGEST_RECIPIENTS: %w", err)
+	}
+	config.Digest.TemplateFile = os.Getenv("DIGEST_TEMPLATE")
+	config.Digest.MaxErrors = getEnvInt("DIGEST_MAX_ERRORS", DefaultDigestMaxErrors)
+
+	config.SenderFallbacks, err = parseSenders(os.Getenv("SENDER_FALLBACKS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SENDER_FALLBACKS: %w", err)
+	}
+
+	if err := applySenderOverride(&config); err != nil {
+		return nil, err
 	}
 
 	if config.APIKey == "" || config.SenderName == "" || config.SenderEmail == "" {
 		return nil, fmt.Errorf("missing required environment variables: BREVO_API_KEY, SENDER_NAME, SENDER_EMAIL")
 	}
 
-	return &BrevoService{
+	if config.DefaultCountry != "" {
+		code, ok := normalizeCountry(config.DefaultCountry)
+		if !ok {
+			return nil, fmt.Errorf("invalid DEFAULT_COUNTRY '%s'", config.DefaultCountry)
+		}
+		config.DefaultCountry = code
+	}
+
+	if err := config.Campaign.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid campaign configuration: %w", err)
+	}
+
+	// Timeouts are applied per request via Config.Timeouts rather than
+	// on the client, so bulk fetches and quick writes get separate budgets.
+	service := &BrevoService{
 		config : config,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-	}, nil
+		httpClient: &http.Client{},
+		ctx: context.Background(),
+		reasonTemplate: reasonTemplate,
+		listDescriptionTemplate: listDescriptionTemplate,
+		credentials: newCredentials(config.APIKey),
+		statsCache: &accountStatsCache{},
+		deprecations: &deprecationTracker{seen: make(map[string]DeprecationNotice)},
+		limiter: NewRateLimiter(config.RateLimit, config.RateBurst),
+		contactIDs: newContactIDs(),
+		renamedAttributes: newRenamedAttributes(),
+	}
+
+	if config.Journal.Path != "" {
+		journal, err := newJournalTransport(config.Journal, http.DefaultTransport)
+		if err != nil {
+			return nil, err
+		}
+		service.httpClient.Transport = journal
+	}
+
+	if config.FetchCheckpointPath != "" {
+		service.checkpoint = NewFileFetchCheckpoint(config.FetchCheckpointPath)
+	}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service, nil
 }
 
 
-func (b *BrevoService) makeAPIRequest(method, url string, payload any) (*http.Response, error) {
-	var reqBody io.Reader
+func (b *BrevoService) makeAPIRequest(op operation, method, url string, payload any) (*http.Response, error) {
+	var jsonData []byte
 
 	if payload != nil {
-		jsonData, err := json.Marshal(payload)
+		var err error
+		jsonData, err = json.Marshal(payload)
 
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal payload: %w", err)
 		}
+	}
+
+	apiKey := b.credentials.get()
+
+	resp, err := b.doAPIRequest(op, method, url, jsonData, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized && b.credentials.canRefresh() {
+		refreshed, refreshErr := b.credentials.refresh(apiKey)
+		if refreshErr != nil {
+			log.Printf("Warning: Could not refresh API key after 401: %v", refreshErr)
+			return resp, nil
+		}
+
+		if refreshed {
+			log.Printf("API key rotated after 401. Retrying %s %s", method, url)
+			resp.Body.Close()
+			return b.doAPIRequest(op, method, url, jsonData, b.credentials.get())
+		}
+	}
+
+	return resp, nil
+}
+
+func (b *BrevoService) doAPIRequest(op operation, method, url string, jsonData []byte, apiKey string) (*http.Response, error) {
+	var reqBody io.Reader
+
+	if jsonData != nil {
+		reqBody = bytes.NewReader(jsonData)
+	}
 
-		reqBody = bytes.NewBuffer(jsonData)
+	if err := b.apiBudget.take(); err != nil {
+		return nil, err
+	}
+
+	// Waiting for the limiter does not count against the request timeout.
+	if err := b.limiter.Wait(b.context()); err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	ctx, cancel := context.WithTimeout(b.context(), b.config.Timeouts.forOperation(op))
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("api-key", b.config.APIKey)
+	req.Header.Set("api-key", apiKey)
 	req.Header.Set("accept", "application/json")
 	req.Header.Set("content-type", "application/json")
+	req.Header.Set("User-Agent", b.userAgent())
+	b.applyCustomHeaders(req)
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	b.deprecations.observe(method, url, resp.Header)
 
-	return b.httpClient.Do(req)
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
 }
 
 func (b *BrevoService) GetExistingContantsEmail() (map[string]bool, error) {
-	allContacts := make(map[string]bool)
-	offset := 0
-	limit := 1000
+	b, cancel := b.withAPIBudget()
+	defer cancel()
 
-	log.Println("Starting to fetch all existing contacts...")
+	return b.fetchExistingContacts(nil)
+}
+
+// fetchRunContacts fetches the contacts a run dedups against: the target
+// list's members when TargetListID is set, otherwise the whole account.
+func (b *BrevoService) fetchRunContacts(states *contactStates) (map[string]bool, error) {
+	if b.config.TargetListID > 0 {
+		return b.fetchExistingListContacts(b.config.TargetListID, states)
+	}
+	return b.fetchExistingContacts(states)
+}
 
-	for {
-		url := fmt.Sprintf("https://api.brevo.com/v3/contacts?limit=%d&offset=%d", limit, offset)
+// fetchExistingContacts is GetExistingContantsEmail that also records each
+// contact's opt-out state into states when it is non-nil.
+func (b *BrevoService) fetchExistingContacts(states *contactStates) (map[string]bool, error) {
+	limit := 1000
+	offset, allContacts := b.resumeFetch(accountFetchScope, states)
 
-		resp, err := b.makeAPIRequest("GET", url, nil)
+	log.Println("Starting to fetch all existing contacts...")
 
+	next := offset
+	err := forEachPage(b.context(), limit, offset, func(limit, offset int) ([]BrevoContact, int, error) {
+		page, err := b.getAllContactsPage(limit, offset)
 		if err != nil {
-			return nil, fmt.Errorf("error fetching contacts at offset %d: %w", offset, err)
+			err.Fetched = len(allContacts)
+			return nil, 0, err
+		}
+		return page, 0, nil
+	}, func(page []BrevoContact, offset int) error {
+		for _, contact := range page {
+			if contact.Email != "" {
+				allContacts[strings.ToLower(contact.Email)] = true
+				states.observe(contact)
+			}
 		}
 
-		defer resp.Body.Close()
+		log.Printf("Fetched %d contacts (offset: %d). Total so far: %d", len(page), offset, len(allContacts))
 
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("API error at offset %d: %d", offset, resp.StatusCode)
+		next = offset + limit
+		if len(page) == limit {
+			b.saveFetch(accountFetchScope, next, allContacts)
 		}
+		return nil
+	})
 
-		var contactsResp ContactsResponse
+	var partialErr *PartialFetchError
+	if err != nil && !errors.As(err, &partialErr) {
+		err = &PartialFetchError{Offset: next, Fetched: len(allContacts), Err: err}
+	}
+	if err != nil {
+		return allContacts, err
+	}
 
-		if err := json.NewDecoder(resp.Body).Decode(&contactsResp); err != nil {
-			return nil, fmt.Errorf("failed to decode response: %w", err)
-		}
+	b.clearFetch()
 
-		if len(contactsResp.Contacts) == 0 {
-			break
-		}
+	log.Printf("Finished fetching contacts. Total: %d unique emails found", len(allContacts))
+	return allContacts, nil
+}
 
-		for _, contact := range contactsResp.Contacts {
-			if contact.Email != "" {
-				allContacts[strings.ToLower(contact.Email)] = true
-			}
-		}
+// getAllContactsPage fetches one page of the account's contacts for
+// fetchExistingContacts, failing with a PartialFetchError.
+func (b *BrevoService) getAllContactsPage(limit, offset int) ([]BrevoContact, *PartialFetchError) {
+	url := fmt.Sprintf("https://api.brevo.com/v3/contacts?limit=%d&offset=%d", limit, offset)
 
-		log.Printf("Fetched %d contacts (offset: %d). Total so far: %d", len(contactsResp.Contacts), offset, len(allContacts))
+	resp, err := b.makeAPIRequest(opFetch, "GET", url, nil)
+	if err != nil {
+		return nil, &PartialFetchError{Offset: offset, Err: err}
+	}
+	defer resp.Body.Close()
 
-		if len(contactsResp.Contacts) < limit {
-			break
+	if resp.StatusCode != http.StatusOK {
+		return nil, &PartialFetchError{
+			Offset:     offset,
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("API error at offset %d: %d", offset, resp.StatusCode),
 		}
+	}
 
-		offset += limit
-		time.Sleep(100 * time.Millisecond) // rate limiting
+	var contactsResp ContactsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&contactsResp); err != nil {
+		return nil, &PartialFetchError{Offset: offset, Err: fmt.Errorf("failed to decode response: %w", err)}
 	}
 
-	log.Printf("Finished fetching contacts. Total: %d unique emails found", len(allContacts))
-	return allContacts, nil
+	return contactsResp.Contacts, nil
 }
 
 
 func (b *BrevoService) GetOrCreateFolder(name string) (int, error) {
-	resp, err := b.makeAPIRequest("GET", FolderUrl, nil)
+	folderID, found, err := b.findFolder(name)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if found {
+		log.Printf("Found existing folder '%s' with ID: %d", name, folderID)
+		return folderID, nil
+	}
+
+	log.Printf("Folder '%s' not found. Creating new one...", name)
+
+	return b.CreateFolder(name)
+} 
+
+func (b *BrevoService) findFolder(name string) (int, bool, error) {
+	resp, err := b.makeAPIRequest(opFetch, "GET", FolderUrl, nil)
 
 	if err != nil {
-		return 0, fmt.Errorf("error checking existing folders: %w", err)
+		return 0, false, fmt.Errorf("error checking existing folders: %w", err)
 	}
 
 	defer resp.Body.Close()
@@ -249,13 +818,13 @@ func (b *BrevoService) GetOrCreateFolder(name string) (int, error) {
 	body, err := io.ReadAll(resp.Body)
 
 	if err != nil {
-		return 0, fmt.Errorf("failed to read folders response body: %w", err)
+		return 0, false, fmt.Errorf("failed to read folders response body: %w", err)
 	}
 
-	log.Printf("Folders API response: %d - %s", resp.StatusCode, string(body))
+	log.Printf("Folders API response: %d - %s", resp.StatusCode, b.logBody(body))
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("failed to fetch folders: status %d - %s", resp.StatusCode, string(body))
+		return 0, false, fmt.Errorf("failed to fetch folders: status %d - %s", resp.StatusCode, string(body))
 	}
 
 	var folderResp FoldersResponse
@@ -263,26 +832,35 @@ func (b *BrevoService) GetOrCreateFolder(name string) (int, error) {
 		log.Printf("Failed to decode folders response: %v", err)
 	}
 
+	var matches []int
 	for _, folder := range folderResp.Folders {
 		if folder.Name == name {
 			if folder.ID <= 0 {
-				return 0, fmt.Errorf("invalid folder ID %d for folder '%s'", folder.ID, name)
+				return 0, false, fmt.Errorf("invalid folder ID %d for folder '%s'", folder.ID, name)
 			}
-			log.Printf("Found existing folder '%s' with ID: %d", name, folder.ID)
-			return folder.ID, nil
+			matches = append(matches, folder.ID)
 		}
 	}
 
-	log.Printf("Folder '%s' not found. Creating new one...", name)
+	switch len(matches) {
+	case 0:
+		return 0, false, nil
+	case 1:
+		return matches[0], true, nil
+	}
 
-	return b.CreateFolder(name)
-} 
+	folderID, err := b.resolveDuplicateFolders(name, matches)
+	if err != nil {
+		return 0, false, err
+	}
+	return folderID, true, nil
+}
 
 
 func (b *BrevoService) CreateFolder(name string) (int, error) {
 	payload := map[string]string{"name": name}
 
-	resp, err := b.makeAPIRequest("POST", FolderUrl , payload)
+	resp, err := b.makeAPIRequest(opAdd, "POST", FolderUrl , payload)
 
 	if err != nil {
 		return 0, fmt.Errorf("exception creating folder '%s': %w", name, err)
@@ -295,32 +873,50 @@ func (b *BrevoService) CreateFolder(name string) (int, error) {
 		return 0, fmt.Errorf("failed to read folder creation response body: %w", err)
 	}
 
-	log.Printf("Create Folder API response: %d - %s", resp.StatusCode, string(body))
+	log.Printf("Create Folder API response: %d - %s", resp.StatusCode, b.logBody(body))
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
-		return 0, fmt.Errorf("failed to create folder '%s': status %d - %s", name, resp.StatusCode, string(body))
-	}
+	if isConflictResponse(resp.StatusCode, string(body)) {
+		// A concurrent run created the folder first; use theirs.
+		log.Printf("Folder '%s' already exists. Re-fetching its ID...", name)
 
-	var result map[string]any
+		folderID, found, err := b.findFolder(name)
+		if err != nil {
+			return 0, fmt.Errorf("failed to re-fetch folder '%s' after conflict: %w", name, err)
+		}
+		if !found {
+			return 0, fmt.Errorf("folder '%s' reported as existing but was not found", name)
+		}
+
+		log.Printf("Using existing folder '%s' with ID: %d", name, folderID)
+		return folderID, nil
+	}
 
-	if err := json.Unmarshal(body, &result); err != nil {
-		return 0, fmt.Errorf("failed to decode folder creation response: %w", err)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		return 0, fmt.Errorf("failed to create folder '%s': status %d - %s", name, resp.StatusCode, string(body))
 	}
 
-	folderID, ok := result["id"].(float64)
+	folderID, err := decodeCreatedID(body)
 
-	if !ok || folderID <= 0 {
-		return 0, fmt.Errorf("invalid or missing folder ID in response: %v", result)
+	if err != nil {
+		return 0, fmt.Errorf("invalid folder creation response: %w", err)
 	}
 
-	log.Printf("Created new folder '%s' with ID: %d", name, int(folderID))
-	return int(folderID), nil
+	log.Printf("Created new folder '%s' with ID: %d", name, folderID)
+	return folderID, nil
 }
 
 
 func (b *BrevoService) AddContact(email string, existingContacts map[string]bool, listIDs []int, contactData *CSVData) (*http.Response, error) {
-	if b.config.APIKey == "" {
-		return nil, fmt.Errorf("BREVO_API_KEY is not configured in environment variables")
+	resp, _, err := b.addContact(0, email, existingContacts, listIDs, contactData)
+	return resp, err
+}
+
+// addContact is AddContact that also reports which SMSConflictStrategy was
+// applied and, when TrackContactChanges is set, what an update changed. row
+// is the contact's CSV row, or 0 when it is not known.
+func (b *BrevoService) addContact(row int, email string, existingContacts map[string]bool, listIDs []int, contactData *CSVData) (*http.Response, contactOutcome, error) {
+	if b.credentials.get() == "" {
+		return nil, contactOutcome{}, fmt.Errorf("BREVO_API_KEY is not configured in environment variables")
 	}
 
 	log.Printf("users list: %d contacts found", len(existingContacts))
@@ -331,13 +927,56 @@ func (b *BrevoService) AddContact(email string, existingContacts map[string]bool
 		log.Printf("[-] %s already exists. Will update with new data if provided.", email)
 	}
 
-	payload := b.buildPayload(email, listIDs, contactData)
+	payload, truncations := b.buildPayload(row, email, listIDs, contactData)
+
+	var before map[string]any
+	track := b.config.TrackContactChanges && contactExists
+	immutable := len(b.config.ImmutableAttributes) > 0 && contactExists
+	known := false
+	if track || immutable {
+		var err error
+		before, err = b.getContactAttributes(email)
+		if err != nil {
+			log.Printf("Warning: Could not fetch current attributes of %s: %v. Changes will not be recorded and immutable attributes are left out.", email, err)
+			track = false
+		} else {
+			known = true
+		}
+	}
+
+	preserved := b.finishPayload(&payload, contactData, contactExists, before, known)
+	b.transformPayload(&payload)
+
+	var resp *http.Response
+	var smsConflict SMSConflictStrategy
+	var err error
+	if id := b.contactIDs.get(email); id > 0 && b.config.UpdateByContactID {
+		resp, smsConflict, err = b.updateContactByID(id, email, payload)
+	} else {
+		resp, smsConflict, err = b.sendContactPayload(email, payload)
+	}
+	outcome := contactOutcome{smsConflict: smsConflict, truncations: truncations, preserved: preserved, contactID: b.contactIDs.get(email)}
+
+	if err != nil || !track || resp.StatusCode != http.StatusNoContent {
+		return resp, outcome, err
+	}
+
+	sent := payload.Attributes
+	if smsConflict == SMSConflictDropSMS {
+		sent = make(map[string]any, len(payload.Attributes))
+		for k, v := range payload.Attributes {
+			if k != "SMS" {
+				sent[k] = v
+			}
+		}
+	}
 
-	return b.sendContactPayload(email, payload, contactExists)
+	outcome.changes = diffAttributes(before, sent)
+	return resp, outcome, nil
 }
 
 
-func (b *BrevoService) buildPayload(email string, listIDs []int, contactData *CSVData) ContactPayload {
+func (b *BrevoService) buildPayload(row int, email string, listIDs []int, contactData *CSVData) (ContactPayload, []AttributeTruncation) {
 
 	payload := ContactPayload {
 		Email:         email,
@@ -345,6 +984,9 @@ func (b *BrevoService) buildPayload(email string, listIDs []int, contactData *CS
 	}
 
 	attributes := b.buildAttributes(contactData)
+	b.addSourceRow(attributes, row)
+	b.addReason(attributes, contactData)
+	truncations := b.config.AttributeLimits.apply(email, attributes)
 	if len(attributes) > 0 {
 		payload.Attributes = attributes
 		log.Printf("Adding contact with attributes: %v", attributes)
@@ -356,7 +998,30 @@ func (b *BrevoService) buildPayload(email string, listIDs []int, contactData *CS
 		payload.ListIds = listIDs
 	}
 
-	return payload
+	return payload, truncations
+}
+
+// finishPayload applies the steps that depend on whether the contact
+// exists: the consent date, then for existing contacts ClearOnEmpty and the
+// immutable attributes, checked against before as in applyImmutable. It
+// returns the attributes left out as immutable.
+func (b *BrevoService) finishPayload(payload *ContactPayload, contactData *CSVData, exists bool, before map[string]any, known bool) []string {
+	if b.config.Consent.enabled() {
+		if payload.Attributes == nil {
+			payload.Attributes = make(map[string]any)
+		}
+		b.addConsentDate(payload.Attributes, contactData, !exists)
+	}
+
+	if !exists {
+		return nil
+	}
+
+	payload.Attributes = b.applyClearOnEmpty(payload.Email, payload.Attributes)
+	if len(b.config.ImmutableAttributes) == 0 {
+		return nil
+	}
+	return b.applyImmutable(payload.Email, payload.Attributes, before, known)
 }
 
 func (b *BrevoService) buildAttributes(contactData *CSVData) map[string]any {
@@ -387,35 +1052,87 @@ func (b *BrevoService) buildAttributes(contactData *CSVData) map[string]any {
 		}
 	}
 
+	if country := b.resolveCountry(contactData); country != "" {
+		attributes["COUNTRY"] = country
+	}
+
+	b.addContactPerson(attributes, contactData)
+
+	b.normalizeAttributeKeys(attributes)
+
 	return attributes
 }
 
-func (b *BrevoService) sendContactPayload(email string, payload ContactPayload, contactExists bool) (*http.Response, error) {
-	url := "https://api.brevo.com/v3/contacts"
-	resp, err := b.makeAPIRequest("POST", url, payload)
+// resolveCountry returns the ISO code for the contact's Country column,
+// falling back to the configured default when it is blank.
+func (b *BrevoService) resolveCountry(contactData *CSVData) string {
+	value := strings.TrimSpace(contactData.Country)
+	if value == "" {
+		return b.config.DefaultCountry
+	}
+
+	code, ok := normalizeCountry(value)
+	if !ok {
+		log.Printf("Warning: Unknown country '%s' for %s. Skipping COUNTRY attribute.", value, contactData.Email)
+		return ""
+	}
+
+	return code
+}
+
+// addSourceRow stores row under SourceRowAttribute when the feature is on
+// and the row is known.
+func (b *BrevoService) addSourceRow(attributes map[string]any, row int) {
+	if b.config.SourceRowAttribute == "" || row <= 0 {
+		return
+	}
+	attributes[b.config.SourceRowAttribute] = row
+}
+
+func (b *BrevoService) sendContactPayload(email string, payload ContactPayload) (*http.Response, SMSConflictStrategy, error) {
+	send := func(payload ContactPayload) (*http.Response, []byte, error) {
+		resp, err := b.makeAPIRequest(opAdd, "POST", "https://api.brevo.com/v3/contacts", payload)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		b.rememberCreatedID(email, resp.StatusCode, body)
+		return resp, body, nil
+	}
+
+	resp, body, err := send(payload)
 	if err != nil {
 		log.Printf("Exception occurred while contacting Brevo API for %s: %v", email, err)
-		return nil, err
+		return nil, "", err
 	}
 
-	body, _ := io.ReadAll(resp.Body)
-	log.Printf("Brevo API response: %d - %s", resp.StatusCode, string(body))
+	log.Printf("Brevo API response: %d - %s", resp.StatusCode, b.logBody(body))
 
 	if b.isDuplicateSMSError(resp, string(body)) {
-		return b.retryWithoutSMS(email, payload)
+		return b.resolveSMSConflict(email, payload, resp, send)
 	}
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
-		log.Printf("Failed to add/update contact %s: %d %s", email, resp.StatusCode, string(body))
-	} else {
-		action := "Updated"
-		if !contactExists {
-			action = "Added"
-		}
+	if action, ok := ContactActionForStatus(resp.StatusCode); ok {
 		log.Printf("%s contact %s with additional data", action, email)
+	} else {
+		log.Printf("Failed to add/update contact %s: %d %s", email, resp.StatusCode, b.logBody(body))
 	}
 
-	return resp, nil
+	return resp, "", nil
+}
+
+// ContactActionForStatus maps Brevo's create-contact status to the action
+// that actually happened: 201 means a new contact, 204 means an update.
+func ContactActionForStatus(statusCode int) (string, bool) {
+	switch statusCode {
+	case http.StatusCreated:
+		return ContactActionAdded, true
+	case http.StatusNoContent:
+		return ContactActionUpdated, true
+	default:
+		return "", false
+	}
 }
 
 func (b *BrevoService) isDuplicateSMSError(resp *http.Response, body string) bool {
@@ -423,7 +1140,10 @@ func (b *BrevoService) isDuplicateSMSError(resp *http.Response, body string) boo
 	strings.Contains(body, "SMS is already associated with another Contact")
 }
 
-func (b *BrevoService) LoadHTMLTemplate(filename string) (string, error) {
+// LoadHTMLTemplate loads filename from the static directory. When lang is
+// set, the localized variant (message_template.<lang>.html) is preferred and
+// the plain file is the fallback.
+func (b *BrevoService) LoadHTMLTemplate(filename string, lang string) (string, error) {
 	_, currentFile, _, ok := runtime.Caller(0)
 
 	if !ok {
@@ -432,97 +1152,202 @@ func (b *BrevoService) LoadHTMLTemplate(filename string) (string, error) {
 
 	currentDir := filepath.Dir(currentFile)
 
-	path := filepath.Join(currentDir, "..", "..", "static", filename)
+	candidates := templateCandidates(filename, lang)
+	for _, candidate := range candidates {
+		path := filepath.Join(currentDir, "..", "..", "static", candidate)
 
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return "", err
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if lang != "" && candidate == filename {
+			log.Printf("No '%s' template for %s. Falling back to %s", lang, filename, filename)
+		}
+
+		return string(data), nil
 	}
 
-	return string(data), nil
+	return "", fmt.Errorf("no template found for '%s' (language '%s'), tried: %s", filename, lang, strings.Join(candidates, ", "))
 }
 
 
-func (b *BrevoService) CreateNewCampaign(listID int) CampaignResult {
-	htmlContent, err := b.LoadHTMLTemplate("message_template.html")
+func (b *BrevoService) CreateNewCampaign(listID int, cfg CampaignConfig, meta CampaignMeta) CampaignResult {
+	return b.createCampaign(map[string][]int{"listIds": {listID}}, cfg, meta, time.Time{})
+}
+
+// createCampaign creates the campaign for recipients. A non-zero scheduledAt
+// makes Brevo send it by itself at that time.
+func (b *BrevoService) createCampaign(recipients map[string][]int, cfg CampaignConfig, meta CampaignMeta, scheduledAt time.Time) CampaignResult {
+	if err := cfg.Validate(); err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Invalid campaign config: %v", err),
+			StatusCode: 0,
+		}
+	}
+
+	htmlContent, err := b.renderCampaignHTML(cfg)
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to render campaign HTML: %v", err),
+			StatusCode: 0,
+		}
+	}
+
+	issues, err := checkCampaignHTML(htmlContent, cfg)
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Campaign HTML failed validation: %v", err),
+			StatusCode: 0,
+			HTMLIssues: issues,
+		}
+	}
+
+	campaignName, err := cfg.RenderName(meta)
+	if err != nil {
+		return CampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to render campaign name: %v", err),
+			StatusCode: 0,
+		}
+	}
+
+	sender, err := b.selectSender()
 	if err != nil {
 		return CampaignResult{
 			Success:    false,
-			Error:      fmt.Sprintf("Failed to load HTML template: %v", err),
+			Error:      fmt.Sprintf("No usable sender: %v", err),
 			StatusCode: 0,
 		}
 	}
 
-	timestamp := time.Now().Unix()
-	campaignName := fmt.Sprintf("CSV Import Campaign - %d", timestamp)
+	log.Printf("Creating campaign with sender %s <%s>", sender.Name, sender.Email)
 
 	payload := CampaignPayload{
 		Sender: map[string]string{
-			"name":  b.config.SenderName,
-			"email": b.config.SenderEmail,
+			"name":  sender.Name,
+			"email": sender.Email,
 		},
 		Name:        campaignName,
-		Subject:     "დოკუმენტაციის თარგმნა ნოტარიულად დამოწმებით",
+		Subject:     cfg.Subject,
 		HTMLContent: htmlContent,
-		Recipients: map[string][]int{
-			"listIds": {listID},
-		},
+		Recipients:  recipients,
+		AttachmentURL: cfg.AttachmentURL,
+		InlineImageActivation: cfg.InlineImageActivation,
+	}
+
+	tags := campaignTags(cfg, meta)
+	payload.Tag = campaignTag(tags)
+
+	if !scheduledAt.IsZero() {
+		payload.ScheduledAt = scheduledAt.UTC().Format(time.RFC3339)
 	}
 
+	result, body := b.postCampaignWithRetry(payload)
+	result.SenderEmail = sender.Email
+
+	for attempt := 1; !result.Success && isDuplicateCampaignNameError(result.StatusCode, body) && attempt <= maxCampaignNameRetries; attempt++ {
+		payload.Name = fmt.Sprintf("%s - %s", campaignName, shortSuffix())
+		log.Printf("Campaign name '%s' already taken. Retrying as '%s'", campaignName, payload.Name)
+		result, body = b.postCampaignWithRetry(payload)
+		result.SenderEmail = sender.Email
+	}
+
+	if result.Success {
+		result.Tags = tags
+	}
+	result.HTMLIssues = issues
+
+	return result
+}
+
+// postCampaign creates the campaign described by payload and also returns the
+// raw error body so callers can inspect why Brevo rejected it.
+func (b *BrevoService) postCampaign(payload CampaignPayload) (CampaignResult, string) {
 	url := "https://api.brevo.com/v3/emailCampaigns"
 
-	resp, err := b.makeAPIRequest("POST", url, payload)
+	resp, err := b.makeAPIRequest(opCampaign, "POST", url, payload)
 
 	if err != nil {
 		return CampaignResult{
-			Success:    false,
-			Error:      fmt.Sprintf("Exception: %v", err),
-			StatusCode: 0,
-		}
+			Success:      false,
+			CampaignName: payload.Name,
+			Error:        fmt.Sprintf("Exception: %v", err),
+			StatusCode:   0,
+		}, ""
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusAccepted {
-		var result map[string]any
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
 			return CampaignResult{
-				Success:    false,
-				Error:      fmt.Sprintf("Failed to decode response: %v", err),
-				StatusCode: resp.StatusCode,
-			}
+				Success:      false,
+				CampaignName: payload.Name,
+				Error:        fmt.Sprintf("Failed to read response: %v", err),
+				StatusCode:   resp.StatusCode,
+			}, ""
 		}
 
-		campaignID, ok := result["id"].(float64)
-		if !ok {
+		campaignID, err := decodeCreatedID(body)
+		if err != nil {
 			return CampaignResult{
-				Success:    false,
-				Error:      "Invalid campaign ID in response",
-				StatusCode: resp.StatusCode,
-			}
+				Success:      false,
+				CampaignName: payload.Name,
+				Error:        fmt.Sprintf("Invalid campaign ID in response: %v", err),
+				StatusCode:   resp.StatusCode,
+			}, ""
 		}
 
-		log.Printf("Campaign '%s' created successfully with ID: %d", campaignName, int(campaignID))
+		log.Printf("Campaign '%s' created successfully with ID: %d", payload.Name, campaignID)
 		return CampaignResult{
 			Success:      true,
-			CampaignID:   int(campaignID),
-			CampaignName: campaignName,
+			CampaignID:   campaignID,
+			CampaignName: payload.Name,
 			StatusCode:   resp.StatusCode,
-		}
+		}, ""
 	}
 
 	body, _ := io.ReadAll(resp.Body)
 	return CampaignResult{
-		Success:    false,
-		Error:      fmt.Sprintf("API Error: %d - %s", resp.StatusCode, string(body)),
-		StatusCode: resp.StatusCode,
-	}
+		Success:      false,
+		CampaignName: payload.Name,
+		Error:        fmt.Sprintf("API Error: %d - %s", resp.StatusCode, string(body)),
+		StatusCode:   resp.StatusCode,
+	}, string(body)
 }
 
 
 func (b *BrevoService) SendCampaignToContacts(campaignID int) SendCampaignResult {
+	// A retried send (e.g. after a lost ack) must not email recipients twice.
+	status, err := b.GetCampaignStatus(campaignID)
+	if err != nil {
+		return SendCampaignResult{
+			Success:    false,
+			Error:      fmt.Sprintf("Failed to verify campaign status before sending: %v", err),
+			StatusCode: 0,
+		}
+	}
+
+	if isCampaignAlreadySent(status) {
+		log.Printf("Campaign %d already has status '%s'. Refusing to send again.", campaignID, status)
+		return SendCampaignResult{
+			Success:     true,
+			AlreadySent: true,
+			Message:     fmt.Sprintf("Campaign %d was already sent (status: %s)", campaignID, status),
+			StatusCode:  http.StatusOK,
+		}
+	}
+
 	url := fmt.Sprintf("https://api.brevo.com/v3/emailCampaigns/%d/sendNow", campaignID)
 
-	resp, err := b.makeAPIRequest("POST", url, nil)
+	resp, err := b.makeAPIRequest(opCampaign, "POST", url, nil)
 	if err != nil {
 		return SendCampaignResult{
 			Success:    false,
@@ -542,7 +1367,7 @@ func (b *BrevoService) SendCampaignToContacts(campaignID int) SendCampaignResult
 	}
 
 	body, _ := io.ReadAll(resp.Body)
-	log.Printf("Failed to send campaign %d: %d %s", campaignID, resp.StatusCode, string(body))
+	log.Printf("Failed to send campaign %d: %d %s", campaignID, resp.StatusCode, b.logBody(body))
 	return SendCampaignResult{
 		Success:    false,
 		Error:      fmt.Sprintf("Send failed: %d - %s", resp.StatusCode, string(body)),
@@ -550,7 +1375,7 @@ func (b *BrevoService) SendCampaignToContacts(campaignID int) SendCampaignResult
 	}
 }
 
-func (b *BrevoService) retryWithoutSMS(email string, payload ContactPayload) (*http.Response, error) {
+func (b *BrevoService) retryWithoutSMS(email string, payload ContactPayload, send contactSender) (*http.Response, error) {
 	log.Printf("SMS already exists for another contact. Retrying %s without SMS field...", email)
 
 	newAttributes := make(map[string]any)
@@ -563,206 +1388,490 @@ func (b *BrevoService) retryWithoutSMS(email string, payload ContactPayload) (*h
 	payloadWithoutSMS := payload
 	payloadWithoutSMS.Attributes = newAttributes
 
-	url := "https://api.brevo.com/v3/contacts"
-
-	if len(newAttributes) > 0 {
-		log.Printf("Retrying with payload: %v", payloadWithoutSMS)
-		resp, err := b.makeAPIRequest("POST", url, payloadWithoutSMS)
-		if err != nil {
-			return nil, err
-		}
-
-		body, _ := io.ReadAll(resp.Body)
-		log.Printf("Retry without SMS - Brevo API response: %d - %s", resp.StatusCode, string(body))
-		return resp, nil
-	} else {
-		log.Printf("No other attributes to update for %s, treating as success", email)
-		return &http.Response{StatusCode: http.StatusNoContent}, nil
+	// Sent even without other attributes: Brevo's answer tells a created
+	// contact from an existing one, and it still joins the run's lists.
+	log.Printf("Retrying with payload: %s", b.logBody([]byte(fmt.Sprint(payloadWithoutSMS))))
+	resp, body, err := send(payloadWithoutSMS)
+	if err != nil {
+		return nil, err
 	}
+
+	log.Printf("Retry without SMS - Brevo API response: %d - %s", resp.StatusCode, b.logBody(body))
+	return resp, nil
 }
 
 func (b *BrevoService) CreateNewContactList(csvName string) (int, error) {
-	folderID, err := b.GetOrCreateFolder("Winners")
+	list, err := b.createContactList(csvName, DefaultFolderName, "")
+	return list.ID, err
+}
+
+// createContactList creates the run's list in folderName and returns it
+// with its name and folder so callers can record the whole chain.
+func (b *BrevoService) createContactList(csvName string, folderName string, description string) (ContactList, error) {
+	folderID, err := b.GetOrCreateFolder(folderName)
 
 	if err != nil {
-		return 0, fmt.Errorf("failed to get or create folder for contact lists: %w", err)
+		return ContactList{}, fmt.Errorf("failed to get or create folder for contact lists: %w", err)
 	}
 
 	if folderID <= 0 {
-		return 0, fmt.Errorf("invalid folder ID %d for contact list creation", folderID)
+		return ContactList{}, fmt.Errorf("invalid folder ID %d for contact list creation", folderID)
 	}
 
 	now := time.Now().Format("2006-01-02 15:04:05")
+	listName := fmt.Sprintf("Winners List - %s", now)
+
+	return b.createDescribedList(listName, folderID, description)
+}
+
+// createList creates listName in folderID, reusing an existing list of the
+// same name.
+func (b *BrevoService) createList(listName string, folderID int) (ContactList, error) {
+	return b.createDescribedList(listName, folderID, "")
+}
+
+// createDescribedList is createList that also sets the list's description
+// when it is not empty. A description Brevo refuses is dropped with a
+// warning so the run still gets its list; the returned list's Description
+// is the one actually set.
+func (b *BrevoService) createDescribedList(listName string, folderID int, description string) (ContactList, error) {
 	payload := map[string]any{
-		"name":     fmt.Sprintf("Winners List - %s", now),
+		"name":     listName,
 		"folderId": folderID,
 	}
+	if description != "" {
+		payload["description"] = description
+	}
 
 	url := "https://api.brevo.com/v3/contacts/lists"
 
-	resp, err := b.makeAPIRequest("POST", url , payload)
+	resp, err := b.makeAPIRequest(opAdd, "POST", url , payload)
 
 	if err != nil {
-		return 0, fmt.Errorf("exception creating contact list: %w", err)
+		return ContactList{}, fmt.Errorf("exception creating contact list: %w", err)
 	}
 
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return 0, fmt.Errorf("failed to read contact list creation response body: %w", err)
+		return ContactList{}, fmt.Errorf("failed to read contact list creation response body: %w", err)
 	}
 
-	log.Printf("Create Contact List API response: %d - %s", resp.StatusCode, string(body))
+	log.Printf("Create Contact List API response: %d - %s", resp.StatusCode, b.logBody(body))
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
-		return 0, fmt.Errorf("failed to create contact list: status %d - %s", resp.StatusCode, string(body))
+	if isConflictResponse(resp.StatusCode, string(body)) {
+		log.Printf("Contact list '%s' already exists. Re-fetching its ID...", listName)
+		listID, err := b.findListInFolder(folderID, listName)
+		return ContactList{ID: listID, Name: listName, FolderID: folderID}, err
 	}
 
-	var result map[string]any
+	if description != "" && resp.StatusCode == http.StatusBadRequest && strings.Contains(strings.ToLower(string(body)), "description") {
+		log.Printf("Warning: Brevo refused the description of list '%s'. Creating it without one.", listName)
+		return b.createDescribedList(listName, folderID, "")
+	}
 
-	if err := json.Unmarshal(body, &result); err != nil {
-		return 0, fmt.Errorf("failed to decode list creation response: %w", err)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		return ContactList{}, fmt.Errorf("failed to create contact list: status %d - %s", resp.StatusCode, string(body))
 	}
 
-	listID, ok := result["id"].(float64)
+	listID, err := decodeCreatedID(body)
 
-	if !ok || listID <= 0 {
-		return 0, fmt.Errorf("invalid or missing list ID in response: %v", result)
+	if err != nil {
+		return ContactList{}, fmt.Errorf("invalid list creation response: %w", err)
 	}
 
-	log.Printf("Created new contact list with ID: %d", int(listID))
-	return int(listID), nil
+	log.Printf("Created new contact list with ID: %d", listID)
+	return ContactList{ID: listID, Name: listName, FolderID: folderID, Description: description}, nil
 }
 
-func mapCSVToObject(records [][]string) ([]CSVData, error) {
+func mapCSVToObject(records [][]string, decoder RowDecoder, opts CSVOptions) ([]CSVData, error) {
 	if len(records) < 2 {
-		return nil, fmt.Errorf("CSV file is empty or has no data rows")
+		return nil, ErrEmptyCSV
+	}
+
+	if decoder == nil {
+		decoder = PositionalDecoder{}
 	}
 
-	expectedColumns := 14
+	header := records[0]
 	data := make([]CSVData, 0, len(records)-1)
 
-	for i, row := range records[1:] { 
-		if len(row) != expectedColumns {
-			return nil, fmt.Errorf("row %d has %d columns, expected %d", i+1, len(row), expectedColumns)
-		}
-
-		data = append(data, CSVData {
-			NAT:        row[0],
-			STOP:       row[1],
-			CATEGORY:   row[2],
-			ID:         row[3],
-			Contacts:   row[4],
-			Email:      row[5],
-			Website:    row[6],
-			VendorName: row[7],
-			Address:    row[8],
-			IdCode:     row[9],
-			Phone:      row[10],
-			Fax:        row[11],
-			City:       row[12],
-			Country:    row[13],
-		})
+	for i, row := range records[1:] {
+		contact, err := decoder.Decode(header, row)
+		if err != nil {
+			return nil, fmt.Errorf("row %d %w", i+1, err)
+		}
+
+		opts.normalize(&contact)
+		data = append(data, contact)
 	}
 
 	return data, nil
 }
 
-func (b *BrevoService) ProcessCSVAndSendCampaign(csvPath string) (ProcessingResults, error) {
+// importContact adds or updates a single CSV row and records the outcome in
+// results. Failures are tagged retryable when they were transient; the index
+// of the recorded failure in results.Errors is returned, -1 when there was
+// none.
+func (b *BrevoService) importContact(row int, data *CSVData, existingContacts map[string]bool, listIDs []int, results *ProcessingResults) int {
+	if data.Email == "" {
+		return results.Errors.addRow(row, data.Email, ErrMissingEmail, "Skipping contact with no email address")
+	}
+
+	if results.skipExcluded(row, data) {
+		return -1
+	}
+
+	started := time.Now()
+	resp, outcome, err := b.addContact(row, data.Email, existingContacts, listIDs, data)
+	results.latencies = append(results.latencies, time.Since(started))
+
+	if err != nil && b.contactTimedOut() {
+		log.Printf("Giving up on %s after %s", data.Email, b.contactBudget)
+		return results.Errors.addRow(row, data.Email, fmt.Errorf("contact timed out after %s", b.contactBudget), "Contact exceeded its time budget")
+	}
+
+	if err != nil {
+		return results.Errors.addRow(row, data.Email, err, "Failed to add/update contact")
+	}
+
+	if resp.Body != nil {
+		resp.Body.Close()
+	}
+
+	if outcome.smsConflict == SMSConflictSkip {
+		results.Skipped = append(results.Skipped, ContactResult{
+			Row:         row,
+			Email:       data.Email,
+			Data:        data,
+			Reason:      "SMS already associated with another contact",
+			StatusCode:  resp.StatusCode,
+			SMSConflict: outcome.smsConflict,
+		})
+		return -1
+	}
+
+	action, ok := ContactActionForStatus(resp.StatusCode)
+	if !ok {
+		statusErr := &apiStatusError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("unexpected status %d", resp.StatusCode)}
+		return results.Errors.addRow(row, data.Email, statusErr, "Failed to add/update contact")
+	}
+
+	contactResult := ContactResult{
+		Row:        row,
+		Email:      data.Email,
+		Data:       data,
+		Action:     action,
+		StatusCode: resp.StatusCode,
+		SMSConflict: outcome.smsConflict,
+		Truncations: outcome.truncations,
+		Preserved:   outcome.preserved,
+		InclusionReason: b.contactReason(data),
+		ContactID:   outcome.contactID,
+	}
+	if len(listIDs) > 1 {
+		contactResult.ListIDs = listIDs
+	}
+
+	if action == ContactActionUpdated {
+		contactResult.Changes = outcome.changes
+		results.UpdatedContacts = append(results.UpdatedContacts, contactResult)
+	} else {
+		results.AddedToCampaign = append(results.AddedToCampaign, contactResult)
+	}
+	return -1
+}
+
+// ProcessCSVAndSendCampaign imports csvPath and sends the run's campaign.
+// The results it returns are final, with ErrorCategories filled in, also
+// when the run stopped early.
+func (b *BrevoService) ProcessCSVAndSendCampaign(csvPath string, opts ProcessOptions) (ProcessingResults, error) {
+	results, err := b.processCSVAndSendCampaign(csvPath, opts)
+	results.ErrorCategories = results.Errors.ByCategory()
+	return results, err
+}
+
+func (b *BrevoService) processCSVAndSendCampaign(csvPath string, opts ProcessOptions) (ProcessingResults, error) {
+	b, cancelBudget := b.withAPIBudget()
+	defer cancelBudget()
+
+	if opts.RunTimeout > 0 {
+		ctx, cancel := context.WithTimeout(b.context(), opts.RunTimeout)
+		defer cancel()
+
+		b = b.WithContext(ctx)
+		log.Printf("Run timeout set to %s", opts.RunTimeout)
+	}
+
 	results := ProcessingResults{
 		AddedToCampaign:   []ContactResult{},
 		UpdatedContacts:   []ContactResult{},
+		Skipped:           []ContactResult{},
 		Errors:            []ErrorResult{},
 		TotalExistingContacts: 0,
 	}
 
-	file, err := os.Open(csvPath)
+	hash := sha256.New()
+	file, err := b.openCSV(csvPath, hash)
 
 	if err != nil {
 		return results, fmt.Errorf("failed to open CSV file: %w", err)
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
+	records, err := readCSVRecords(file, opts.CSV)
 
 	if err != nil {
 		return results, fmt.Errorf("failed to read CSV: %w", err)
 	}
 
-	csvData, err := mapCSVToObject(records)
+	// The hash covers the whole file, including anything the CSV reader
+	// left unread.
+	if _, err := io.Copy(io.Discard, file); err != nil {
+		log.Printf("Warning: Could not hash CSV file %s: %v", csvPath, err)
+	} else {
+		results.csvHash = hex.EncodeToString(hash.Sum(nil))
+	}
+
+	csvData, err := mapCSVToObject(records, opts.Decoder, opts.CSV)
+
+	if errors.Is(err, ErrEmptyCSV) {
+		log.Printf("CSV file %s has no data rows. Nothing to import.", csvPath)
+		return results, nil
+	}
 
 	if err != nil {
 		return results, fmt.Errorf("failed to map CSV data: %w", err)
 	}
 
-	existingContacts, err := b.GetExistingContantsEmail()
+	results.Warnings = append(results.Warnings, b.checkAttributeSchema()...)
 
-	if err != nil {
+	// A bulk import cannot fetch each contact before writing it, so the
+	// immutable attributes are checked against the values fetched here.
+	states := newContactStates(opts, opts.BulkImport && len(b.config.ImmutableAttributes) > 0)
+
+	existingContacts, err := b.fetchRunContacts(states)
+
+	var partialErr *PartialFetchError
+	if errors.As(err, &partialErr) && b.config.AllowPartialFetch {
+		warning := fmt.Sprintf("existing contacts fetch stopped early (%v); continuing with %d contacts, dedup may be imperfect", partialErr, partialErr.Fetched)
+		log.Printf("Warning: %s", warning)
+		results.Warnings = append(results.Warnings, warning)
+	} else if err != nil {
 		return results, fmt.Errorf("failed to fetch existing contacts: %w", err)
 	}
 
 	results.TotalExistingContacts = len(existingContacts)
+	if opts.SkipBlacklisted {
+		results.exclude(states.blacklisted, ExclusionBlacklisted)
+	}
 
-	csvName := strings.TrimSuffix(filepath.Base(csvPath), ".csv")
-
-	listID, err := b.CreateNewContactList(csvName)
+	if opts.SuppressionListPath != "" {
+		suppressed, err := readSuppressionList(opts.SuppressionListPath)
+		if err != nil {
+			return results, err
+		}
+		log.Printf("Loaded %d suppressed emails from %s", len(suppressed), opts.SuppressionListPath)
+		results.exclude(suppressed, ExclusionSuppressed)
+	}
 
-	if err != nil {
-		return results, fmt.Errorf("failed to create contact list: %w", err)
+	if len(opts.DomainAllowlist) > 0 {
+		log.Printf("Limiting the run to contacts at %s", strings.Join(opts.DomainAllowlist, ", "))
+		results.exclude(outsideAllowlist(csvData, opts.DomainAllowlist), ExclusionDomainNotAllowlisted)
 	}
 
-	for _, data := range csvData {
-		if data.Email == "" {
-			results.Errors = append(results.Errors, ErrorResult{
-				Email:  data.Email,
-				Error:  "missing email",
-				Details: "Skipping contact with no email address",
-			})
-			continue
+	csvName := csvBaseName(csvPath)
+
+	var list ContactList
+	if b.config.TargetListID > 0 {
+		list, err = b.GetList(b.config.TargetListID)
+		if err != nil {
+			return results, fmt.Errorf("failed to load target list %d: %w", b.config.TargetListID, err)
+		}
+		log.Printf("Importing into target list '%s' (ID: %d)", list.Name, list.ID)
+	} else if b.retryListID > 0 && opts.Cumulative == nil {
+		list, err = b.GetList(b.retryListID)
+		if err != nil {
+			return results, fmt.Errorf("failed to load list %d of the failed attempt: %w", b.retryListID, err)
+		}
+		log.Printf("Importing into list '%s' (ID: %d) of the failed attempt", list.Name, list.ID)
+	} else {
+		folderName, err := resolveFolderName(opts.FolderStrategy, FolderMeta{
+			CSVName:  csvName,
+			Date:     time.Now(),
+			Category: firstCategory(csvData),
+		})
+		if err != nil {
+			return results, fmt.Errorf("failed to resolve folder name: %w", err)
 		}
 
-		_ , err := b.AddContact(data.Email, existingContacts, []int{listID}, &data)
+		if opts.Cumulative != nil {
+			list, err = b.cumulativeList(*opts.Cumulative, folderName)
+		} else {
+			var description string
+			description, err = b.listDescription(ListDescriptionData{CSVName: csvName, Folder: folderName, RunID: opts.RunID})
+			if err != nil {
+				return results, err
+			}
+			list, err = b.createContactList(csvName, folderName, description)
+			results.ListDescription = list.Description
+		}
 		if err != nil {
-			results.Errors = append(results.Errors, ErrorResult{
-				Email:  data.Email,
-				Error:  err.Error(),
-				Details: "Failed to add/update contact",
-			})
-			continue
+			return results, fmt.Errorf("failed to create contact list: %w", err)
+		}
+	}
+
+	listID := list.ID
+	results.ListID = list.ID
+	results.ListName = list.Name
+	results.FolderID = list.FolderID
+
+	if opts.SkipListUnsubscribed {
+		results.exclude(states.unsubscribedFrom(listID), ExclusionListUnsubscribed)
+	}
+
+	var router *listRouter
+	if opts.ListRouting != nil {
+		router, err = newListRouter(*opts.ListRouting, list)
+		if err != nil {
+			return results, err
+		}
+		for _, value := range sortedKeys(opts.ListRouting.ListIDs) {
+			if err := b.ValidateListID(opts.ListRouting.ListIDs[value]); err != nil {
+				return results, fmt.Errorf("invalid routing list for '%s': %w", value, err)
+			}
 		}
+	}
 
-		contactResult := ContactResult{
-			Email: data.Email,
-			Data:  &data,
+	if opts.BulkImport {
+		complete := b.config.TargetListID == 0 && partialErr == nil
+		if err := b.bulkImportContacts(csvData, listID, existingContacts, complete, states, opts, &results); err != nil {
+			return results, fmt.Errorf("bulk import failed: %w", err)
 		}
+		if router != nil {
+			b.routeImportedContacts(router, listID, &results)
+		}
+	} else {
+		for i, data := range csvData {
+			if err := b.runTimeoutError(opts); err != nil {
+				results.ContactLatency = computeLatencyStats(results.latencies)
+				return results, fmt.Errorf("stopped after %d of %d contacts: %w", i, len(csvData), err)
+			}
 
-		if existingContacts[strings.ToLower(data.Email)] {
-			contactResult.Action = "Updated"
-			results.UpdatedContacts = append(results.UpdatedContacts, contactResult)
-		} else {
-			contactResult.Action = "Added"
-			results.AddedToCampaign = append(results.AddedToCampaign, contactResult)
+			row := i + 2 // 1-based, after the header line
+			// Rows that will not be imported are not routed, so they create
+			// no routed lists and record no routing decision.
+			listIDs := []int{listID}
+			if data.Email != "" && !results.isExcluded(data.Email) {
+				listIDs = b.routeContact(router, row, &data, listID, &results)
+			}
+			contactService, cancel := b.withContactBudget(opts)
+			contactService.importContact(row, &data, existingContacts, listIDs, &results)
+			cancel()
+		}
+	}
+
+	results.ContactLatency = computeLatencyStats(results.latencies)
+	log.Printf("AddContact latency: p50 %.0fms, p95 %.0fms, max %.0fms over %d calls",
+		results.ContactLatency.P50Ms, results.ContactLatency.P95Ms, results.ContactLatency.MaxMs, results.ContactLatency.Count)
+
+	if err := b.runTimeoutError(opts); err != nil {
+		return results, fmt.Errorf("stopped before creating the campaign: %w", err)
+	}
+
+	if opts.SkipCampaign {
+		log.Printf("Campaign creation disabled. List %d populated without sending.", listID)
+		return results, nil
+	}
+
+	if opts.Cumulative != nil {
+		log.Printf("Contacts accumulated into list %d. The campaign is sent on %s.", listID, opts.Cumulative.SendDay)
+		return results, nil
+	}
+
+	if err := b.checkRecipientCap(opts, listID, len(results.AddedToCampaign)+len(results.UpdatedContacts)); err != nil {
+		return results, err
+	}
+
+	if skip := b.checkRecipientMinimum(opts, listID, len(results.AddedToCampaign)+len(results.UpdatedContacts)); skip != nil {
+		log.Printf("Not creating a campaign for list %d: %d recipients, minimum is %d", listID, skip.Recipients, skip.MinRecipients)
+		results.CampaignSkipped = skip
+		return results, nil
+	}
+
+	campaign := b.config.Campaign
+	campaign.Language = b.campaignLanguage(campaign, &results)
+	if campaign.Language != "" {
+		log.Printf("Using the '%s' campaign template", campaign.Language)
+	}
+
+	if router != nil {
+		if !opts.ListRouting.PerListCampaigns {
+			log.Printf("Contacts routed by %s. Skipping the campaign step.", opts.ListRouting.Column)
+			return results, nil
+		}
+		results.RoutedCampaigns = b.sendRoutedCampaigns(router, opts, campaign, CampaignMeta{CSVName: csvName, RunID: opts.RunID}, &results)
+		return results, nil
+	}
+
+	if opts.TimezoneSchedule != nil {
+		results.TimezoneCampaigns, err = b.scheduleByTimezone(*opts.TimezoneSchedule, opts, list, campaign, CampaignMeta{
+			CSVName: csvName,
+			RunID:   opts.RunID,
+			Count:   len(results.AddedToCampaign) + len(results.UpdatedContacts),
+		}, &results)
+		return results, err
+	}
+
+	var scheduledAt time.Time
+	if opts.ScheduleAfter > 0 {
+		// A scheduled campaign sends by itself, so one that needs
+		// confirmation is created unscheduled and left pending instead.
+		if b.pendingConfirmation(opts, 0, listID, len(results.AddedToCampaign)+len(results.UpdatedContacts)) != nil {
+			log.Printf("Not scheduling the campaign: it needs confirmation first")
+		} else if scheduledAt, err = b.scheduleAfter(opts.ScheduleAfter); err != nil {
+			return results, err
 		}
 	}
 
-	campaignResult := b.CreateNewCampaign(listID)
+	campaignResult := b.createCampaign(map[string][]int{"listIds": {listID}}, campaign, CampaignMeta{
+		CSVName: csvName,
+		RunID:   opts.RunID,
+		Count:   len(results.AddedToCampaign) + len(results.UpdatedContacts),
+	}, scheduledAt)
 	results.CampaignInfo = campaignResult
 	if !campaignResult.Success {
-		results.Errors = append(results.Errors, ErrorResult{
-			Error:  campaignResult.Error,
-			Details: "Failed to create campaign",
-		})
+		statusErr := &apiStatusError{StatusCode: campaignResult.StatusCode, Message: campaignResult.Error}
+		results.Errors.Add("", statusErr, "Failed to create campaign")
+		return results, nil
+	}
+
+	if err := b.runTimeoutError(opts); err != nil {
+		return results, fmt.Errorf("stopped before sending campaign %d: %w", campaignResult.CampaignID, err)
+	}
+
+	if pending := b.pendingConfirmation(opts, campaignResult.CampaignID, listID, len(results.AddedToCampaign)+len(results.UpdatedContacts)); pending != nil {
+		log.Printf("Campaign %d has %d recipients, above the confirmation threshold of %d. Not sending until ConfirmAndSend is called.",
+			pending.CampaignID, pending.Recipients, pending.Threshold)
+		results.PendingConfirmation = pending
+		return results, nil
+	}
+
+	if !scheduledAt.IsZero() {
+		log.Printf("Campaign %d scheduled for %s (%s after processing)", campaignResult.CampaignID, scheduledAt.Format(time.RFC3339), opts.ScheduleAfter)
+		results.CampaignScheduledAt = &scheduledAt
 		return results, nil
 	}
 
 	sendResult := b.SendCampaignToContacts(campaignResult.CampaignID)
 	if !sendResult.Success {
-		results.Errors = append(results.Errors, ErrorResult{
-			Error:  sendResult.Error,
-			Details: "Failed to send campaign",
-		})
+		log.Printf("Campaign %d was created but not sent. Call ResumeSend(%d) to send it without re-running the import.",
+			campaignResult.CampaignID, campaignResult.CampaignID)
+		statusErr := &apiStatusError{StatusCode: sendResult.StatusCode, Message: sendResult.Error}
+		results.Errors.Add("", statusErr, fmt.Sprintf("Failed to send campaign %d (resume with ResumeSend)", campaignResult.CampaignID))
+	} else if opts.VerifySentTimeout > 0 {
+		b.verifyCampaignSent(campaignResult.CampaignID, opts.VerifySentTimeout, &results)
 	}
 
 	return results, nil
@@ -775,24 +1884,159 @@ func Start(csvPath string) {
 		log.Fatalf("Failed to initialize Brevo service: %v", err)
 	}
 
-	results, err := service.ProcessCSVAndSendCampaign(csvPath)
+	service.Start(csvPath)
+}
+
+// Start processes csvPath with the service's configuration, retrying the
+// whole run under RunRetry. A long-lived service can start one run after
+// another; attempts of one run do not leak into the next.
+func (b *BrevoService) Start(csvPath string) {
+	service := b.WithContext(b.context())
+	service.retryListID = 0
+
+	if service.config.PlanPath != "" {
+		service.writePlan(csvPath)
+		return
+	}
+
+	policy := service.config.RunRetry
+	for attempt := 1; ; attempt++ {
+		if policy.MaxAttempts > 1 {
+			log.Printf("Run attempt %d of %d", attempt, policy.MaxAttempts)
+		}
+
+		results, err := service.runOnce(csvPath)
+		if err == nil {
+			service.sendCumulativeIfDue()
+			return
+		}
+		if attempt >= policy.MaxAttempts {
+			return
+		}
+
+		if !service.isRetryableRun(results, err) {
+			log.Printf("Not retrying the run: %v", err)
+			return
+		}
+		if results.ListID > 0 && service.config.TargetListID == 0 {
+			service.retryListID = results.ListID
+		}
+
+		delay := policy.backoff(attempt)
+		log.Printf("Run attempt %d failed. Retrying the whole run in %s...", attempt, delay)
+		time.Sleep(delay)
+	}
+}
+
+// runOnce processes csvPath a single time, writing the run report and the
+// results CSV when configured.
+func (b *BrevoService) runOnce(csvPath string) (ProcessingResults, error) {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	var err error
+	startedAt := time.Now()
+	report := RunReport{
+		SchemaVersion: ReportSchemaVersion,
+		Version:   Version,
+		RunID:     newRunID(startedAt),
+		CSVPath:   csvPath,
+		StartedAt: startedAt,
+		Config:    redactConfig(b.config),
+	}
+
+	var reconciliation *Reconciliation
+	if b.config.Process.ReconcileCounts {
+		reconciliation = b.reconcileBefore()
+	}
+
+	opts := b.config.Process
+	opts.RunID = report.RunID
+
+	results, err := b.ProcessCSVAndSendCampaign(csvPath, opts)
+	if budgetErr := b.apiBudgetError(); budgetErr != nil && !errors.Is(err, ErrAPIBudgetExceeded) {
+		if err == nil {
+			err = budgetErr
+		} else {
+			err = fmt.Errorf("%w (%v)", budgetErr, err)
+		}
+	}
+
+	if reconciliation != nil {
+		b.afterRun().reconcileAfter(reconciliation, results)
+		report.Reconciliation = reconciliation
+	}
+
+	if b.config.SortResults {
+		results.SortByEmail()
+	}
+
+	report.Results = results
+	report.CSVHash = results.csvHash
+	report.APICalls = b.apiBudget.used()
+	report.FinishedAt = time.Now()
+	log.Printf("API calls this run: %d", report.APICalls)
+	report.Duration = report.FinishedAt.Sub(startedAt).String()
+
+	if err != nil {
+		report.Error = err.Error()
+	}
+
+	reporting := b.afterRun()
+	report.CampaignHTMLPath = reporting.archiveCampaignHTML(results)
+
+	reporting.notifyWebhook(report)
+	reporting.sendDigest(report)
+
+	if b.config.ReportPath != "" {
+		if err := WriteRunReport(report, b.config.ReportPath); err != nil {
+			log.Printf("Failed to write run report: %v", err)
+		} else {
+			log.Printf("Run report %s written to %s", report.RunID, b.config.ReportPath)
+		}
+	}
+
+	if b.config.ReportCSVPath != "" {
+		if err := WriteResultsCSV(results, b.config.ReportCSVPath); err != nil {
+			log.Printf("Failed to write results CSV: %v", err)
+		} else {
+			log.Printf("Results CSV written to %s", b.config.ReportCSVPath)
+		}
+	}
+
 	if err != nil {
 		log.Printf("Failed to process CSV and send campaign: %v", err)
-		return
+		return results, err
 	}
 
 	log.Printf("Processing Results:")
 	log.Printf("Total Existing Contacts: %d", results.TotalExistingContacts)
 	log.Printf("Added Contacts: %d", len(results.AddedToCampaign))
 	log.Printf("Updated Contacts: %d", len(results.UpdatedContacts))
-	log.Printf("Errors: %d", len(results.Errors))
+	log.Printf("Errors: %d", results.Errors.Count())
+	for _, category := range sortedKeys(results.ErrorCategories) {
+		log.Printf("Errors (%s): %d", category, results.ErrorCategories[category])
+	}
+	for _, reason := range sortedKeys(results.Excluded) {
+		log.Printf("Excluded (%s): %d", reason, results.Excluded[reason])
+	}
+	for _, domain := range sortedKeys(results.SkippedDomains) {
+		log.Printf("Skipped (domain not allowlisted) %s: %d", domain, results.SkippedDomains[domain])
+	}
 	log.Printf("Campaign: %s (ID: %d, Success: %v)", 
 		results.CampaignInfo.CampaignName, 
 		results.CampaignInfo.CampaignID, 
 		results.CampaignInfo.Success)
+	if at := results.CampaignScheduledAt; at != nil {
+		log.Printf("Campaign scheduled for %s", at.Format(time.RFC3339))
+	}
+	if skip := results.CampaignSkipped; skip != nil {
+		log.Printf("Campaign skipped: %s (%d recipients)", skip.Reason, skip.Recipients)
+	}
 
 	for _, errResult := range results.Errors {
 		log.Printf("Error: %s (%s)", errResult.Error, errResult.Details)
 	}
-}
 
+	return results, nil
+}
@@ -0,0 +1,175 @@
+package brevo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// redirectTransport sends every request to the test server, whatever host
+// the service addressed, so the hardcoded Brevo URLs reach the mock.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestService returns a service whose API calls go to handler. The
+// server is closed when the test ends.
+func newTestService(t *testing.T, handler http.Handler) (*BrevoService, *httptest.Server) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parse test server URL: %v", err)
+	}
+
+	service := &BrevoService{
+		config: Config{
+			APIKey:      "test-key",
+			SenderName:  "Test",
+			SenderEmail: "sender@example.com",
+			Timeouts:    DefaultTimeouts(),
+			Process:     DefaultProcessOptions(),
+		},
+		httpClient:        &http.Client{Transport: redirectTransport{target: target}},
+		ctx:               context.Background(),
+		credentials:       newCredentials("test-key"),
+		statsCache:        &accountStatsCache{},
+		deprecations:      &deprecationTracker{seen: make(map[string]DeprecationNotice)},
+		limiter:           NewRateLimiter(0, 0),
+		contactIDs:        newContactIDs(),
+		renamedAttributes: newRenamedAttributes(),
+	}
+	return service, server
+}
+
+// runServer mocks the Brevo endpoints a ProcessCSVAndSendCampaign run
+// calls: one Winners folder, list 10, import process 5 and campaign 20. onContact, when set,
+// answers contact creates; returning false falls back to a 201.
+type runServer struct {
+	t         *testing.T
+	existing  []BrevoContact
+	onContact func(w http.ResponseWriter, r *http.Request, payload ContactPayload) bool
+
+	mu        sync.Mutex
+	contacts  []ContactPayload
+	campaigns []CampaignPayload
+	sends     int
+	imports   int
+	imported  []importContact
+}
+
+func (s *runServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route := r.Method + " " + r.URL.Path
+
+	switch {
+	case route == "GET /v3/contacts/attributes":
+		fmt.Fprint(w, `{"attributes":[]}`)
+	case route == "GET /v3/contacts":
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		page := ContactsResponse{Contacts: []BrevoContact{}, Count: len(s.existing)}
+		if offset < len(s.existing) {
+			page.Contacts = s.existing[offset:]
+		}
+		json.NewEncoder(w).Encode(page)
+	case route == "GET /v3/contacts/folders":
+		fmt.Fprintf(w, `{"folders":[{"id":1,"name":%q}],"count":1}`, DefaultFolderName)
+	case route == "POST /v3/contacts/lists":
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":10}`)
+	case route == "GET /v3/contacts/lists/10":
+		s.mu.Lock()
+		count := len(s.contacts)
+		s.mu.Unlock()
+		fmt.Fprintf(w, `{"id":10,"name":"Winners List","folderId":1,"totalSubscribers":%d}`, count)
+	case route == "POST /v3/contacts":
+		var payload ContactPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		s.mu.Lock()
+		s.contacts = append(s.contacts, payload)
+		s.mu.Unlock()
+
+		if s.onContact != nil && s.onContact(w, r, payload) {
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":1}`)
+	case route == "POST /v3/contacts/import":
+		var payload importPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		s.mu.Lock()
+		s.imports++
+		s.imported = append(s.imported, payload.JSONBody...)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"processId":5}`)
+	case route == "GET /v3/processes/5":
+		fmt.Fprint(w, `{"id":5,"status":"completed"}`)
+	case route == "POST /v3/emailCampaigns":
+		var payload CampaignPayload
+		json.NewDecoder(r.Body).Decode(&payload)
+		s.mu.Lock()
+		s.campaigns = append(s.campaigns, payload)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":20}`)
+	case route == "GET /v3/emailCampaigns/20":
+		status := "draft"
+		s.mu.Lock()
+		if s.sends > 0 {
+			status = "sent"
+		}
+		s.mu.Unlock()
+		fmt.Fprintf(w, `{"id":20,"status":%q}`, status)
+	case route == "POST /v3/emailCampaigns/20/sendNow":
+		s.mu.Lock()
+		s.sends++
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.t.Errorf("unexpected request %s", r.URL)
+		http.NotFound(w, r)
+	}
+}
+
+// newRunService returns a service wired to a runServer, with an inline
+// campaign body so runs do not depend on the template files.
+func newRunService(t *testing.T) (*BrevoService, *runServer) {
+	t.Helper()
+
+	mock := &runServer{t: t}
+	service, _ := newTestService(t, mock)
+	service.config.Campaign.Subject = "Winners"
+	service.config.Campaign.InlineHTML = "<html><body><p>Hello</p></body></html>"
+	return service, mock
+}
+
+// writeCSV writes lines to a CSV file in a temporary directory and returns
+// its path.
+func writeCSV(t *testing.T, lines ...string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "winners.csv")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,92 @@
+package brevo
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SMSConflictStrategy decides what happens when Brevo rejects a contact
+// because its SMS number already belongs to another contact.
+type SMSConflictStrategy string
+
+const (
+	// SMSConflictDropSMS retries the contact without the SMS attribute.
+	SMSConflictDropSMS SMSConflictStrategy = "drop_sms"
+	// SMSConflictSkip leaves the contact untouched and reports it as skipped.
+	SMSConflictSkip SMSConflictStrategy = "skip"
+	// SMSConflictForceClear clears the SMS from the contact that holds it,
+	// then retries the full payload so the number moves to this contact.
+	SMSConflictForceClear SMSConflictStrategy = "force_clear"
+)
+
+func parseSMSConflictStrategy(value string) (SMSConflictStrategy, error) {
+	switch strategy := SMSConflictStrategy(strings.ToLower(strings.TrimSpace(value))); strategy {
+	case "":
+		return SMSConflictDropSMS, nil
+	case SMSConflictDropSMS, SMSConflictSkip, SMSConflictForceClear:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("unknown SMS conflict strategy '%s' (use drop_sms, skip or force_clear)", value)
+	}
+}
+
+// contactSender sends a contact payload the way the rejected request did,
+// returning the response with its body read.
+type contactSender func(payload ContactPayload) (*http.Response, []byte, error)
+
+// resolveSMSConflict applies the configured strategy to a payload Brevo
+// rejected for a duplicate SMS, resending it through send. Skipped contacts
+// get the original response back with SMSConflictSkip so the caller can
+// record them; the other strategies close it before resending.
+func (b *BrevoService) resolveSMSConflict(email string, payload ContactPayload, resp *http.Response, send contactSender) (*http.Response, SMSConflictStrategy, error) {
+	strategy := b.config.SMSConflictStrategy
+	if strategy == "" {
+		strategy = SMSConflictDropSMS
+	}
+
+	switch strategy {
+	case SMSConflictSkip:
+		log.Printf("SMS already exists for another contact. Skipping %s.", email)
+		return resp, strategy, nil
+	case SMSConflictForceClear:
+		resp.Body.Close()
+		resp, err := b.forceClearSMS(email, payload, send)
+		return resp, strategy, err
+	default:
+		resp.Body.Close()
+		resp, err := b.retryWithoutSMS(email, payload, send)
+		return resp, strategy, err
+	}
+}
+
+func (b *BrevoService) forceClearSMS(email string, payload ContactPayload, send contactSender) (*http.Response, error) {
+	sms := fmt.Sprint(payload.Attributes["SMS"])
+	log.Printf("SMS %s already exists for another contact. Clearing it there before updating %s...", sms, email)
+
+	clearURL := fmt.Sprintf("https://api.brevo.com/v3/contacts/%s?identifierType=phone_id", url.PathEscape(sms))
+	clearPayload := map[string]any{"attributes": map[string]any{"SMS": ""}}
+
+	resp, err := b.makeAPIRequest(opAdd, "PUT", clearURL, clearPayload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear SMS %s from conflicting contact: %w", sms, err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to clear SMS %s from conflicting contact: status %d - %s", sms, resp.StatusCode, string(body))
+	}
+
+	resp, body, err = send(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Retry after clearing SMS - Brevo API response: %d - %s", resp.StatusCode, b.logBody(body))
+	return resp, nil
+}
@@ -0,0 +1,112 @@
+package brevo
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Source opens the CSV at a location such as "s3://bucket/key.csv". Cloud
+// storage adapters implement it and are registered per URL scheme with
+// WithSource, so a run streams the file without downloading it first.
+type Source interface {
+	Open(location string) (io.ReadCloser, error)
+}
+
+// LocalFileSource reads plain paths and file:// URLs from disk.
+type LocalFileSource struct{}
+
+func (LocalFileSource) Open(location string) (io.ReadCloser, error) {
+	return os.Open(strings.TrimPrefix(location, "file://"))
+}
+
+// WithSource registers src for locations starting with scheme + "://".
+func WithSource(scheme string, src Source) Option {
+	return func(b *BrevoService) {
+		if b.sources == nil {
+			b.sources = make(map[string]Source)
+		}
+		b.sources[strings.ToLower(scheme)] = src
+	}
+}
+
+// sourceFor returns the Source registered for location's scheme. Locations
+// without a scheme are local paths.
+func (b *BrevoService) sourceFor(location string) (Source, error) {
+	scheme, _, ok := strings.Cut(location, "://")
+	if !ok || strings.EqualFold(scheme, "file") {
+		return LocalFileSource{}, nil
+	}
+
+	src, ok := b.sources[strings.ToLower(scheme)]
+	if !ok {
+		return nil, fmt.Errorf("no source registered for scheme '%s'", scheme)
+	}
+	return src, nil
+}
+
+// openSource opens location as stored, without decompressing it.
+func (b *BrevoService) openSource(location string) (io.ReadCloser, error) {
+	src, err := b.sourceFor(location)
+	if err != nil {
+		return nil, err
+	}
+	return src.Open(location)
+}
+
+// openCSV opens location for reading, transparently decompressing gzip
+// content, which is detected from the stream rather than the file name.
+// When hash is not nil, the file as stored, before any decompression, is
+// written to it as it is read.
+func (b *BrevoService) openCSV(location string, hash io.Writer) (io.ReadCloser, error) {
+	raw, err := b.openSource(location)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored io.Reader = raw
+	if hash != nil {
+		stored = io.TeeReader(raw, hash)
+	}
+
+	buffered := bufio.NewReader(stored)
+	magic, _ := buffered.Peek(2)
+	if len(magic) < 2 || magic[0] != 0x1f || magic[1] != 0x8b {
+		return readCloser{Reader: buffered, Closer: raw}, nil
+	}
+
+	gz, err := gzip.NewReader(buffered)
+	if err != nil {
+		raw.Close()
+		return nil, fmt.Errorf("failed to read gzip stream: %w", err)
+	}
+	return readCloser{Reader: gz, Closer: closers{gz, raw}}, nil
+}
+
+// csvBaseName is location's file name without the .gz and .csv extensions.
+func csvBaseName(location string) string {
+	name := filepath.Base(location)
+	name = strings.TrimSuffix(name, ".gz")
+	return strings.TrimSuffix(name, ".csv")
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type closers []io.Closer
+
+func (c closers) Close() error {
+	var first error
+	for _, closer := range c {
+		if err := closer.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,59 @@
+package brevo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenCSVHashesStoredBytes(t *testing.T) {
+	content := []byte("email,name\na@example.com,Ana\n")
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write(content)
+	gz.Close()
+
+	tests := []struct {
+		name   string
+		file   string
+		stored []byte
+	}{
+		{name: "plain", file: "winners.csv", stored: content},
+		{name: "gzip", file: "winners.csv.gz", stored: compressed.Bytes()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, tt.stored, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			hash := sha256.New()
+			file, err := (&BrevoService{}).openCSV(path, hash)
+			if err != nil {
+				t.Fatalf("openCSV() error = %v", err)
+			}
+			defer file.Close()
+
+			read, err := io.ReadAll(file)
+			if err != nil {
+				t.Fatalf("read error = %v", err)
+			}
+			if !bytes.Equal(read, content) {
+				t.Errorf("read %q, want %q", read, content)
+			}
+
+			want := sha256.Sum256(tt.stored)
+			if got := hex.EncodeToString(hash.Sum(nil)); got != hex.EncodeToString(want[:]) {
+				t.Errorf("hash = %s, want the hash of the stored file", got)
+			}
+		})
+	}
+}
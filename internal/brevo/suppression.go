@@ -0,0 +1,244 @@
+package brevo
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Brevo returns at most 100 blocked contacts per page.
+const blockedContactsPageSize int = 100
+
+// ExclusionSuppressed marks contacts found in the suppression list.
+const ExclusionSuppressed string = "suppressed"
+
+var suppressionsCSVHeader = []string{"email", "reason_code", "reason", "blocked_at", "sender_email"}
+
+type blockedContact struct {
+	Email       string `json:"email"`
+	SenderEmail string `json:"senderEmail"`
+	Reason      struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"reason"`
+	BlockedAt string `json:"blockedAt"`
+}
+
+type blockedContactsResponse struct {
+	Contacts []blockedContact `json:"contacts"`
+	Count    int              `json:"count"`
+}
+
+// blacklistImportPayload imports contacts with emailBlacklisted set,
+// creating the ones Brevo does not know yet.
+type blacklistImportPayload struct {
+	JSONBody               []importContact `json:"jsonBody"`
+	EmailBlacklist         bool            `json:"emailBlacklist"`
+	UpdateExistingContacts bool            `json:"updateExistingContacts"`
+}
+
+// ImportSuppressionList marks every email in the suppression file at path as
+// emailBlacklisted in Brevo. The file may be a CSV with an email column or a
+// plain list with one email per line.
+func (b *BrevoService) ImportSuppressionList(path string) error {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	emails, err := readSuppressionList(path)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Blacklisting %d suppressed emails from %s...", len(emails), path)
+
+	list := make([]string, 0, len(emails))
+	for email := range emails {
+		list = append(list, email)
+	}
+
+	if _, err := b.BlacklistEmails(list); err != nil {
+		return err
+	}
+
+	log.Printf("Finished blacklisting %d suppressed emails", len(list))
+	return nil
+}
+
+// BlacklistEmails marks emails as emailBlacklisted in Brevo through the
+// contact import, in batches, creating the contacts that do not exist yet so
+// a suppressed email is blocked before it is ever imported. While Brevo
+// answers 429 it backs off per the retry policy. It returns how many emails
+// were in batches Brevo accepted before any error.
+func (b *BrevoService) BlacklistEmails(emails []string) (int, error) {
+	blacklisted := 0
+
+	for start := 0; start < len(emails); start += importChunkSize {
+		end := min(start+importChunkSize, len(emails))
+
+		contacts := make([]importContact, 0, end-start)
+		for _, email := range emails[start:end] {
+			contacts = append(contacts, importContact{Email: email})
+		}
+
+		payload := blacklistImportPayload{JSONBody: contacts, EmailBlacklist: true, UpdateExistingContacts: true}
+
+		for attempt := 1; ; attempt++ {
+			resp, err := b.makeAPIRequest(opAdd, "POST", "https://api.brevo.com/v3/contacts/import", payload)
+			if err != nil {
+				return blacklisted, fmt.Errorf("exception blacklisting emails %d-%d: %w", start+1, end, err)
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			if resp.StatusCode == http.StatusTooManyRequests && attempt < b.config.Retry.MaxAttempts {
+				log.Printf("Rate limited blacklisting emails %d-%d. Retrying in %s...", start+1, end, b.config.Retry.backoff(attempt))
+				if !b.wait(attempt) {
+					return blacklisted, b.context().Err()
+				}
+				continue
+			}
+
+			if resp.StatusCode != http.StatusAccepted {
+				return blacklisted, fmt.Errorf("failed to blacklist emails %d-%d: status %d - %s", start+1, end, resp.StatusCode, string(body))
+			}
+
+			break
+		}
+
+		blacklisted += end - start
+	}
+
+	return blacklisted, nil
+}
+
+// readSuppressionList reads the lowercased emails from path.
+func readSuppressionList(path string) (map[string]bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open suppression list: %w", err)
+	}
+	defer file.Close()
+
+	emails, err := readEmails(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suppression list: %w", err)
+	}
+
+	return emails, nil
+}
+
+// readEmails collects the lowercased emails in a CSV or one-per-line list.
+// Any field containing an @ counts, so headers and extra columns are ignored.
+func readEmails(r io.Reader) (map[string]bool, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make(map[string]bool)
+	for _, record := range records {
+		for _, field := range record {
+			field = strings.ToLower(strings.TrimSpace(field))
+			if strings.Contains(field, "@") {
+				emails[field] = true
+			}
+		}
+	}
+
+	return emails, nil
+}
+
+// ExportSuppressions writes every contact Brevo blocks from email to a CSV
+// at path. The transactional blocklist (unsubscribed, hard bounced, spam
+// complaint, or blacklisted by an admin) comes with Brevo's reason and the
+// time it was blocked; contacts only blacklisted from marketing emails are
+// added after it, with the time the contact was last modified.
+func (b *BrevoService) ExportSuppressions(path string) error {
+	b, cancel := b.withAPIBudget()
+	defer cancel()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create suppressions CSV '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(suppressionsCSVHeader); err != nil {
+		return fmt.Errorf("failed to write suppressions CSV header: %w", err)
+	}
+
+	exported := 0
+	seen := make(map[string]bool)
+
+	err = forEachPage(b.context(), blockedContactsPageSize, 0, func(limit, offset int) ([]blockedContact, int, error) {
+		url := fmt.Sprintf("https://api.brevo.com/v3/smtp/blockedContacts?limit=%d&offset=%d", limit, offset)
+
+		body, err := b.getJSON(url)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to fetch blocked contacts at offset %d: %w", offset, err)
+		}
+
+		var page blockedContactsResponse
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode blocked contacts at offset %d: %w", offset, err)
+		}
+
+		return page.Contacts, page.Count, nil
+	}, func(page []blockedContact, offset int) error {
+		for _, c := range page {
+			record := []string{c.Email, c.Reason.Code, c.Reason.Message, c.BlockedAt, c.SenderEmail}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write suppressions CSV row for %s: %w", c.Email, err)
+			}
+			seen[strings.ToLower(c.Email)] = true
+		}
+		exported += len(page)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = forEachPage(b.context(), 1000, 0, func(limit, offset int) ([]BrevoContact, int, error) {
+		page, err := b.getContactsPage(limit, offset)
+		return page, 0, err
+	}, func(page []BrevoContact, offset int) error {
+		for _, c := range page {
+			email := strings.ToLower(c.Email)
+			if !c.EmailBlacklisted || email == "" || seen[email] {
+				continue
+			}
+
+			record := []string{c.Email, "emailBlacklisted", "Blacklisted from marketing emails", c.ModifiedAt, ""}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write suppressions CSV row for %s: %w", c.Email, err)
+			}
+			seen[email] = true
+			exported++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush suppressions CSV: %w", err)
+	}
+
+	log.Printf("Exported %d suppressed contacts to %s", exported, path)
+	return file.Close()
+}
@@ -0,0 +1,123 @@
+package brevo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBlacklistEmails(t *testing.T) {
+	emails := make([]string, importChunkSize+1)
+	for i := range emails {
+		emails[i] = fmt.Sprintf("suppressed%d@example.com", i)
+	}
+
+	tests := []struct {
+		name            string
+		statuses        []int
+		wantBlacklisted int
+		wantRequests    int
+		wantErr         bool
+	}{
+		{
+			name:            "every batch accepted",
+			statuses:        []int{http.StatusAccepted, http.StatusAccepted},
+			wantBlacklisted: len(emails),
+			wantRequests:    2,
+		},
+		{
+			name:            "rate limited then accepted",
+			statuses:        []int{http.StatusTooManyRequests, http.StatusAccepted, http.StatusAccepted},
+			wantBlacklisted: len(emails),
+			wantRequests:    3,
+		},
+		{
+			name:            "rejected batch stops the run",
+			statuses:        []int{http.StatusAccepted, http.StatusBadRequest},
+			wantBlacklisted: importChunkSize,
+			wantRequests:    2,
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/v3/contacts/import" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					http.NotFound(w, r)
+					return
+				}
+
+				var payload blacklistImportPayload
+				json.NewDecoder(r.Body).Decode(&payload)
+				if !payload.EmailBlacklist || !payload.UpdateExistingContacts || len(payload.JSONBody) == 0 {
+					t.Errorf("payload = %+v, want a blacklisting create-or-update import", payload)
+				}
+
+				w.WriteHeader(tt.statuses[min(requests, len(tt.statuses)-1)])
+				fmt.Fprint(w, `{"processId":7}`)
+				requests++
+			}))
+			service.config.Retry = RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+			blacklisted, err := service.BlacklistEmails(emails)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BlacklistEmails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if blacklisted != tt.wantBlacklisted {
+				t.Errorf("blacklisted = %d, want %d", blacklisted, tt.wantBlacklisted)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestExportSuppressionsMergesMarketingBlacklist(t *testing.T) {
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		switch r.URL.Path {
+		case "/v3/smtp/blockedContacts":
+			fmt.Fprint(w, `{"contacts":[{"email":"bounced@example.com","senderEmail":"news@example.com","reason":{"code":"hardBounce","message":"Hard bounce"},"blockedAt":"2026-10-01T10:00:00Z"}],"count":1}`)
+		case "/v3/contacts":
+			if offset != "0" {
+				fmt.Fprint(w, `{"contacts":[],"count":3}`)
+				return
+			}
+			fmt.Fprint(w, `{"contacts":[
+				{"email":"Bounced@example.com","emailBlacklisted":true},
+				{"email":"suppressed@example.com","emailBlacklisted":true,"modifiedAt":"2026-10-02T09:00:00Z"},
+				{"email":"active@example.com"}
+			],"count":3}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	path := filepath.Join(t.TempDir(), "suppressions.csv")
+	if err := service.ExportSuppressions(path); err != nil {
+		t.Fatalf("ExportSuppressions() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"email,reason_code,reason,blocked_at,sender_email",
+		"bounced@example.com,hardBounce,Hard bounce,2026-10-01T10:00:00Z,news@example.com",
+		"suppressed@example.com,emailBlacklisted,Blacklisted from marketing emails,2026-10-02T09:00:00Z,",
+	}, "\n") + "\n"
+	if string(data) != want {
+		t.Errorf("suppressions CSV =\n%s\nwant\n%s", data, want)
+	}
+}
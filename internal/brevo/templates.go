@@ -0,0 +1,89 @@
+package brevo
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// languagePattern matches the template languages that are safe to put in a
+// template filename, such as "ka" or "pt-br".
+var languagePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]+)?$`)
+
+func validateLanguage(lang string) error {
+	if !languagePattern.MatchString(lang) {
+		return fmt.Errorf("invalid campaign language '%s' (use a code such as 'en' or 'pt-br')", lang)
+	}
+	return nil
+}
+
+// Template language used for contacts from each country. Countries not
+// listed use the default template.
+var countryLanguages = map[string]string{
+	"GE": "ka",
+	"AM": "hy",
+	"AZ": "az",
+	"RU": "ru",
+	"BY": "ru",
+	"KZ": "ru",
+	"UA": "uk",
+	"TR": "tr",
+	"DE": "de",
+	"AT": "de",
+	"FR": "fr",
+	"GB": "en",
+	"US": "en",
+	"IE": "en",
+	"CA": "en",
+}
+
+// LanguageForCountry returns the template language for a country name or
+// ISO code, or "" when the default template should be used.
+func LanguageForCountry(country string) string {
+	code, ok := normalizeCountry(country)
+	if !ok {
+		return ""
+	}
+	return countryLanguages[code]
+}
+
+// campaignLanguage returns the template language for the run's campaign:
+// cfg.Language when it is set, otherwise the language of the recipients'
+// country when they all share one. Mixed countries use the default template.
+func (b *BrevoService) campaignLanguage(cfg CampaignConfig, results *ProcessingResults) string {
+	if cfg.Language != "" {
+		return cfg.Language
+	}
+
+	language, first := "", true
+	for _, contacts := range [][]ContactResult{results.AddedToCampaign, results.UpdatedContacts} {
+		for _, contact := range contacts {
+			country := b.config.DefaultCountry
+			if contact.Data != nil && strings.TrimSpace(contact.Data.Country) != "" {
+				country = contact.Data.Country
+			}
+
+			contactLanguage := LanguageForCountry(country)
+			if !first && contactLanguage != language {
+				return ""
+			}
+			language, first = contactLanguage, false
+		}
+	}
+
+	return language
+}
+
+// templateCandidates lists the files to try for filename in lang, most
+// specific first.
+func templateCandidates(filename, lang string) []string {
+	if lang == "" {
+		return []string{filename}
+	}
+
+	ext := filepath.Ext(filename)
+	localized := strings.TrimSuffix(filename, ext) + "." + lang + ext
+
+	return []string{localized, filename}
+}
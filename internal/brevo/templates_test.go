@@ -0,0 +1,71 @@
+package brevo
+
+import "testing"
+
+func TestCampaignLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		configured     string
+		defaultCountry string
+		countries      []string
+		want           string
+	}{
+		{name: "configured language wins", configured: "en", countries: []string{"GE"}, want: "en"},
+		{name: "shared country", countries: []string{"GE", "Georgia"}, want: "ka"},
+		{name: "mixed countries", countries: []string{"GE", "DE"}, want: ""},
+		{name: "blank country uses the default", defaultCountry: "DE", countries: []string{"", "AT"}, want: "de"},
+		{name: "country without a template", countries: []string{"JP"}, want: ""},
+		{name: "no recipients", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BrevoService{config: Config{DefaultCountry: tt.defaultCountry}}
+
+			var results ProcessingResults
+			for i, country := range tt.countries {
+				contact := ContactResult{Data: &CSVData{Country: country}}
+				if i%2 == 0 {
+					results.AddedToCampaign = append(results.AddedToCampaign, contact)
+				} else {
+					results.UpdatedContacts = append(results.UpdatedContacts, contact)
+				}
+			}
+
+			if got := b.campaignLanguage(CampaignConfig{Language: tt.configured}, &results); got != tt.want {
+				t.Errorf("campaignLanguage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCampaignLanguage(t *testing.T) {
+	tests := []struct {
+		language string
+		wantErr  bool
+	}{
+		{language: "", wantErr: false},
+		{language: "ka", wantErr: false},
+		{language: "pt-br", wantErr: false},
+		{language: "/../../x", wantErr: true},
+		{language: "en/../secret", wantErr: true},
+		{language: "english", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			err := CampaignConfig{Subject: "Winners", Language: tt.language}.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCountryLanguagesAreValid(t *testing.T) {
+	for country, language := range countryLanguages {
+		if err := validateLanguage(language); err != nil {
+			t.Errorf("language of %s: %v", country, err)
+		}
+	}
+}
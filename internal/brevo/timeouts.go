@@ -0,0 +1,80 @@
+package brevo
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Default per-operation timeouts. Bulk fetches page through the whole
+// account and need far longer than a single contact write.
+const (
+	DefaultFetchTimeout    = 2 * time.Minute
+	DefaultAddTimeout      = 15 * time.Second
+	DefaultCampaignTimeout = 60 * time.Second
+)
+
+type operation int
+
+const (
+	opFetch operation = iota
+	opAdd
+	opCampaign
+)
+
+type Timeouts struct {
+	FetchTimeout    time.Duration
+	AddTimeout      time.Duration
+	CampaignTimeout time.Duration
+}
+
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		FetchTimeout:    DefaultFetchTimeout,
+		AddTimeout:      DefaultAddTimeout,
+		CampaignTimeout: DefaultCampaignTimeout,
+	}
+}
+
+func (t Timeouts) forOperation(op operation) time.Duration {
+	switch op {
+	case opFetch:
+		return t.FetchTimeout
+	case opCampaign:
+		return t.CampaignTimeout
+	default:
+		return t.AddTimeout
+	}
+}
+
+// WithContext returns a shallow copy of the service whose requests are bound
+// to ctx, so cancelling ctx aborts any in-flight API call.
+func (b *BrevoService) WithContext(ctx context.Context) *BrevoService {
+	if ctx == nil {
+		panic("nil context")
+	}
+
+	copied := *b
+	copied.ctx = ctx
+	return &copied
+}
+
+func (b *BrevoService) context() context.Context {
+	if b.ctx == nil {
+		return context.Background()
+	}
+	return b.ctx
+}
+
+// cancelOnClose releases the per-request context once the caller is done
+// reading the response body.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,201 @@
+package brevo
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+	_ "time/tzdata" // the nightly host may lack a zoneinfo database
+)
+
+// scheduleLeadTime keeps a send time from being so close that Brevo rejects
+// it as being in the past.
+const scheduleLeadTime = 10 * time.Minute
+
+// countryTimezones picks the main business timezone of each country in
+// countryCodes. Countries spanning several zones use their capital's.
+var countryTimezones = map[string]string{
+	"GE": "Asia/Tbilisi", "AM": "Asia/Yerevan", "AZ": "Asia/Baku", "TR": "Europe/Istanbul",
+	"RU": "Europe/Moscow", "UA": "Europe/Kyiv", "BY": "Europe/Minsk", "KZ": "Asia/Almaty",
+	"IR": "Asia/Tehran", "DE": "Europe/Berlin", "FR": "Europe/Paris", "IT": "Europe/Rome",
+	"ES": "Europe/Madrid", "NL": "Europe/Amsterdam", "BE": "Europe/Brussels", "AT": "Europe/Vienna",
+	"CH": "Europe/Zurich", "PL": "Europe/Warsaw", "CZ": "Europe/Prague", "RO": "Europe/Bucharest",
+	"BG": "Europe/Sofia", "GR": "Europe/Athens", "LT": "Europe/Vilnius", "LV": "Europe/Riga",
+	"EE": "Europe/Tallinn", "SE": "Europe/Stockholm", "NO": "Europe/Oslo", "DK": "Europe/Copenhagen",
+	"FI": "Europe/Helsinki", "GB": "Europe/London", "IE": "Europe/Dublin", "US": "America/New_York",
+	"CA": "America/Toronto", "CN": "Asia/Shanghai", "IN": "Asia/Kolkata", "JP": "Asia/Tokyo",
+	"KR": "Asia/Seoul", "IL": "Asia/Jerusalem", "AE": "Asia/Dubai",
+}
+
+// TimezoneAwareSchedule replaces the immediate send with one scheduled
+// campaign per recipient timezone, each arriving at LocalHour:LocalMinute
+// local time. Contacts whose country has no known timezone use
+// DefaultTimezone, or UTC when that is empty. A run above the confirmation
+// threshold creates the campaigns unscheduled and leaves each pending for
+// ConfirmAndSend, since a scheduled campaign sends by itself.
+type TimezoneAwareSchedule struct {
+	LocalHour       int
+	LocalMinute     int
+	DefaultTimezone string
+}
+
+// TimezoneCampaign is the scheduled campaign for one timezone group.
+type TimezoneCampaign struct {
+	Timezone    string         `json:"timezone"`
+	ListID      int            `json:"list_id"`
+	Recipients  int            `json:"recipients"`
+	ScheduledAt time.Time      `json:"scheduled_at"`
+	Campaign    CampaignResult `json:"campaign"`
+	// Pending is set instead of ScheduledAt when the run needs
+	// confirmation before anything is sent.
+	Pending *PendingConfirmation `json:"pending_confirmation,omitempty"`
+}
+
+// parseLocalTime reads an "HH:MM" send time.
+func parseLocalTime(value string) (int, int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, 0, fmt.Errorf("expected HH:MM, got '%s'", value)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// nextSendTime returns the next hour:minute in loc that is at least
+// scheduleLeadTime after now.
+func nextSendTime(now time.Time, loc *time.Location, hour, minute int) time.Time {
+	local := now.In(loc)
+	send := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+	if send.Before(now.Add(scheduleLeadTime)) {
+		send = send.AddDate(0, 0, 1)
+	}
+	return send
+}
+
+// contactTimezone resolves the timezone of a contact from its Country column,
+// falling back to DEFAULT_COUNTRY and then to the schedule's default.
+func (b *BrevoService) contactTimezone(data *CSVData, schedule TimezoneAwareSchedule) string {
+	if data != nil {
+		if tz, ok := countryTimezones[b.resolveCountry(data)]; ok {
+			return tz
+		}
+	}
+
+	if schedule.DefaultTimezone != "" {
+		return schedule.DefaultTimezone
+	}
+	return "UTC"
+}
+
+// scheduleByTimezone groups the run's recipients by timezone and schedules a
+// campaign for each group. With a single group the run's list is used as is;
+// otherwise each group gets its own list next to it. It fails when no group
+// got its campaign.
+func (b *BrevoService) scheduleByTimezone(schedule TimezoneAwareSchedule, opts ProcessOptions, list ContactList, cfg CampaignConfig, meta CampaignMeta, results *ProcessingResults) ([]TimezoneCampaign, error) {
+	groups := make(map[string][]string)
+	for _, contacts := range [][]ContactResult{results.AddedToCampaign, results.UpdatedContacts} {
+		for _, c := range contacts {
+			tz := b.contactTimezone(c.Data, schedule)
+			groups[tz] = append(groups[tz], c.Email)
+		}
+	}
+
+	timezones := make([]string, 0, len(groups))
+	for tz := range groups {
+		timezones = append(timezones, tz)
+	}
+	sort.Strings(timezones)
+
+	pending := b.pendingConfirmation(opts, 0, list.ID, meta.Count)
+	if pending != nil {
+		log.Printf("Not scheduling the timezone campaigns: %d recipients need confirmation first (threshold %d)", pending.Recipients, pending.Threshold)
+	}
+
+	now := b.now()
+	campaigns := make([]TimezoneCampaign, 0, len(timezones))
+	created := 0
+
+	for _, tz := range timezones {
+		emails := groups[tz]
+		entry := TimezoneCampaign{Timezone: tz, ListID: list.ID, Recipients: len(emails)}
+
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			b.recordScheduleError(results, tz, fmt.Sprintf("unknown timezone '%s': %v", tz, err))
+			continue
+		}
+		sendAt := nextSendTime(now, loc, schedule.LocalHour, schedule.LocalMinute)
+		if pending == nil {
+			entry.ScheduledAt = sendAt
+		}
+
+		if len(timezones) > 1 {
+			groupList, err := b.createTimezoneList(list, tz, emails)
+			if err != nil {
+				b.recordScheduleError(results, tz, err.Error())
+				continue
+			}
+			entry.ListID = groupList.ID
+		}
+
+		groupMeta := meta
+		groupMeta.Source = meta.CSVName
+		groupMeta.CSVName = fmt.Sprintf("%s (%s)", meta.CSVName, tz)
+		groupMeta.Count = len(emails)
+		if len(timezones) == 1 {
+			groupMeta.CSVName = meta.CSVName
+		}
+
+		entry.Campaign = b.createCampaign(map[string][]int{"listIds": {entry.ListID}}, cfg, groupMeta, entry.ScheduledAt)
+		switch {
+		case !entry.Campaign.Success:
+			b.recordScheduleError(results, tz, entry.Campaign.Error)
+		case pending != nil:
+			created++
+			entry.Pending = &PendingConfirmation{CampaignID: entry.Campaign.CampaignID, Recipients: len(emails), Threshold: pending.Threshold}
+			log.Printf("Campaign %d for %d recipients in %s awaits ConfirmAndSend (would have been scheduled for %s)",
+				entry.Campaign.CampaignID, len(emails), tz, sendAt.Format(time.RFC3339))
+		default:
+			created++
+			log.Printf("Scheduled campaign %d for %d recipients in %s at %s",
+				entry.Campaign.CampaignID, len(emails), tz, entry.ScheduledAt.Format(time.RFC3339))
+		}
+
+		campaigns = append(campaigns, entry)
+	}
+
+	if created == 0 && len(timezones) > 0 {
+		return campaigns, fmt.Errorf("no timezone campaign could be created for %d timezone groups", len(timezones))
+	}
+
+	return campaigns, nil
+}
+
+func (b *BrevoService) createTimezoneList(list ContactList, tz string, emails []string) (ContactList, error) {
+	folderID := list.FolderID
+	if folderID <= 0 {
+		var err error
+		if folderID, err = b.GetOrCreateFolder(DefaultFolderName); err != nil {
+			return ContactList{}, fmt.Errorf("failed to get folder for %s list: %w", tz, err)
+		}
+	}
+
+	groupList, err := b.createList(fmt.Sprintf("%s - %s", list.Name, tz), folderID)
+	if err != nil {
+		return ContactList{}, fmt.Errorf("failed to create %s list: %w", tz, err)
+	}
+
+	added, err := b.addContactsToList(groupList.ID, emails)
+	if err != nil {
+		return ContactList{}, fmt.Errorf("failed to fill %s list %d: %w", tz, groupList.ID, err)
+	}
+
+	log.Printf("Added %d of %d contacts to %s list %d", added, len(emails), tz, groupList.ID)
+	return groupList, nil
+}
+
+func (b *BrevoService) recordScheduleError(results *ProcessingResults, tz string, message string) {
+	i := results.Errors.addRow(0, "", errors.New(message), fmt.Sprintf("Failed to schedule campaign for %s", tz))
+	results.Errors[i].Retryable = false
+}
@@ -0,0 +1,24 @@
+package brevo
+
+// PayloadTransformer can modify any field of a contact payload right before
+// it is sent. It runs once per contact, after every built-in step (attribute
+// mapping, consent date, ClearOnEmpty and immutable attributes), so its
+// changes are sent as made. If Brevo then rejects the SMS as a duplicate,
+// the SMSConflictStrategy works on the transformed payload: with the default
+// drop_sms an SMS value set by the transformer is dropped too while its other
+// changes are kept. Bulk imports send only its attribute changes, as the
+// email and lists of an imported contact are fixed.
+type PayloadTransformer func(*ContactPayload)
+
+// SetPayloadTransformer registers transformer for all later contact
+// requests. Pass nil to remove it.
+func (b *BrevoService) SetPayloadTransformer(transformer PayloadTransformer) {
+	b.payloadTransformer = transformer
+}
+
+// transformPayload runs the registered transformer, if any, on payload.
+func (b *BrevoService) transformPayload(payload *ContactPayload) {
+	if b.payloadTransformer != nil {
+		b.payloadTransformer(payload)
+	}
+}
@@ -0,0 +1,141 @@
+package brevo
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPayloadTransformer(t *testing.T) {
+	addSegment := func(payload *ContactPayload) {
+		if payload.Attributes == nil {
+			payload.Attributes = make(map[string]any)
+		}
+		payload.Attributes["SEGMENT"] = "vip"
+	}
+
+	tests := []struct {
+		name        string
+		transformer PayloadTransformer
+		smsConflict bool
+		wantBodies  []ContactPayload
+	}{
+		{
+			name: "no transformer",
+			wantBodies: []ContactPayload{
+				{Email: "a@example.com", UpdateEnabled: true, Attributes: map[string]any{"SMS": "+995555000000"}, ListIds: []int{3}},
+			},
+		},
+		{
+			name:        "adds an attribute",
+			transformer: addSegment,
+			wantBodies: []ContactPayload{
+				{Email: "a@example.com", UpdateEnabled: true, Attributes: map[string]any{"SMS": "+995555000000", "SEGMENT": "vip"}, ListIds: []int{3}},
+			},
+		},
+		{
+			name: "adds a list",
+			transformer: func(payload *ContactPayload) {
+				payload.ListIds = append(payload.ListIds, 9)
+			},
+			wantBodies: []ContactPayload{
+				{Email: "a@example.com", UpdateEnabled: true, Attributes: map[string]any{"SMS": "+995555000000"}, ListIds: []int{3, 9}},
+			},
+		},
+		{
+			name:        "SMS retry keeps the transformer's changes",
+			transformer: addSegment,
+			smsConflict: true,
+			wantBodies: []ContactPayload{
+				{Email: "a@example.com", UpdateEnabled: true, Attributes: map[string]any{"SMS": "+995555000000", "SEGMENT": "vip"}, ListIds: []int{3}},
+				{Email: "a@example.com", UpdateEnabled: true, Attributes: map[string]any{"SEGMENT": "vip"}, ListIds: []int{3}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []ContactPayload
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var payload ContactPayload
+				if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+					t.Errorf("decode contact payload: %v", err)
+				}
+				bodies = append(bodies, payload)
+
+				if tt.smsConflict && len(bodies) == 1 {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"code":"duplicate_parameter","message":"SMS is already associated with another Contact"}`))
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id":1}`))
+			}))
+			service.SetPayloadTransformer(tt.transformer)
+
+			resp, err := service.AddContact("a@example.com", map[string]bool{}, []int{3}, &CSVData{Phone: "+995555000000"})
+			if err != nil {
+				t.Fatalf("AddContact() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if !reflect.DeepEqual(bodies, tt.wantBodies) {
+				t.Errorf("sent payloads = %+v, want %+v", bodies, tt.wantBodies)
+			}
+		})
+	}
+}
+
+func TestPayloadTransformerRunsLast(t *testing.T) {
+	setDate := func(payload *ContactPayload) {
+		payload.Attributes["OPT_IN_DATE"] = "2020-01-01"
+	}
+
+	t.Run("single contact", func(t *testing.T) {
+		var sent ContactPayload
+		service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&sent)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":1}`))
+		}))
+		service.config.Consent = ConsentConfig{DefaultToImportDate: true}
+		service.SetPayloadTransformer(setDate)
+
+		resp, err := service.AddContact("a@example.com", map[string]bool{}, []int{3}, &CSVData{Email: "a@example.com"})
+		if err != nil {
+			t.Fatalf("AddContact() error = %v", err)
+		}
+		resp.Body.Close()
+
+		if got := sent.Attributes["OPT_IN_DATE"]; got != "2020-01-01" {
+			t.Errorf("sent OPT_IN_DATE = %v, want the transformer's 2020-01-01", got)
+		}
+	})
+
+	t.Run("bulk import", func(t *testing.T) {
+		service, mock := newRunService(t)
+		service.config.Consent = ConsentConfig{DefaultToImportDate: true}
+		service.clock = func() time.Time { return time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC) }
+		service.SetPayloadTransformer(setDate)
+
+		opts := service.config.Process
+		opts.Decoder = HeaderDecoder{}
+		opts.SkipCampaign = true
+		opts.BulkImport = true
+
+		if _, err := service.ProcessCSVAndSendCampaign(writeCSV(t, "email", "a@example.com", "b@example.com"), opts); err != nil {
+			t.Fatalf("ProcessCSVAndSendCampaign() error = %v", err)
+		}
+
+		var dates []any
+		for _, contact := range mock.imported {
+			dates = append(dates, contact.Attributes["OPT_IN_DATE"])
+		}
+		if !slices.Equal(dates, []any{"2020-01-01", "2020-01-01"}) {
+			t.Errorf("imported OPT_IN_DATE values = %v, want the transformer's for both", dates)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package brevo
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+// Ping checks that Brevo is reachable and accepts the API key.
+func (b *BrevoService) Ping() error {
+	if _, err := b.getAccount(); err != nil {
+		return fmt.Errorf("brevo did not accept the API key: %w", err)
+	}
+	return nil
+}
+
+// ValidateConfig loads the configuration from the environment and checks
+// everything a run depends on, returning every problem at once.
+func ValidateConfig() error {
+	service, err := NewBrevoService()
+	if err != nil {
+		return fmt.Errorf("configuration: %w", err)
+	}
+	return service.ValidateConfig()
+}
+
+// ValidateConfig checks the API key, the sender and the campaign template
+// against Brevo, returning every problem joined into one error.
+func (b *BrevoService) ValidateConfig() error {
+	var problems []error
+
+	if err := b.Ping(); err != nil {
+		problems = append(problems, fmt.Errorf("api key: %w", err))
+	} else {
+		// Sender checks need a working key.
+		if _, err := b.selectSender(); err != nil {
+			problems = append(problems, fmt.Errorf("sender: %w", err))
+		}
+		problems = append(problems, b.validateConfiguredIDs()...)
+	}
+
+	if err := b.config.Campaign.Validate(); err != nil {
+		problems = append(problems, fmt.Errorf("campaign: %w", err))
+	}
+
+	if htmlContent, err := b.renderCampaignHTML(b.config.Campaign); err != nil {
+		problems = append(problems, fmt.Errorf("template: %w", err))
+	} else if _, err := checkCampaignHTML(htmlContent, b.config.Campaign); err != nil {
+		problems = append(problems, fmt.Errorf("template: %w", err))
+	}
+
+	if after := b.config.Process.ScheduleAfter; after > 0 {
+		if _, err := b.scheduleAfter(after); err != nil {
+			problems = append(problems, fmt.Errorf("schedule: %w", err))
+		}
+	}
+
+	if err := errors.Join(problems...); err != nil {
+		return err
+	}
+
+	log.Printf("Configuration is valid")
+	return nil
+}
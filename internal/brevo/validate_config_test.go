@@ -0,0 +1,129 @@
+package brevo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		accountStatus int
+		configure     func(b *BrevoService)
+		wantProblems  []string
+		wantAbsent    []string
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name:          "api key rejected",
+			accountStatus: http.StatusUnauthorized,
+			configure: func(b *BrevoService) {
+				b.config.SenderFallbacks = []Sender{{Email: "backup@example.com"}}
+			},
+			wantProblems: []string{"api key: brevo did not accept the API key"},
+			wantAbsent:   []string{"sender:"},
+		},
+		{
+			name: "no verified sender",
+			configure: func(b *BrevoService) {
+				b.config.SenderFallbacks = []Sender{{Email: "backup@example.com"}}
+			},
+			wantProblems: []string{"sender: none of 2 configured senders is verified"},
+		},
+		{
+			name: "missing target list",
+			configure: func(b *BrevoService) {
+				b.config.TargetListID = 99
+			},
+			wantProblems: []string{"target list:"},
+		},
+		{
+			name: "invalid campaign subject",
+			configure: func(b *BrevoService) {
+				b.config.Campaign.Subject = "\xff"
+			},
+			wantProblems: []string{"campaign: campaign subject and name must be valid UTF-8"},
+		},
+		{
+			name: "template cannot be loaded",
+			configure: func(b *BrevoService) {
+				b.config.Campaign.InlineHTML = ""
+				b.config.Campaign.TemplateFile = "does-not-exist.html"
+			},
+			wantProblems: []string{"template: failed to load HTML template"},
+		},
+		{
+			name: "schedule outside Brevo's window",
+			configure: func(b *BrevoService) {
+				b.config.Process.ScheduleAfter = time.Minute
+			},
+			wantProblems: []string{"schedule:"},
+		},
+		{
+			name:          "every problem is reported at once",
+			accountStatus: http.StatusUnauthorized,
+			configure: func(b *BrevoService) {
+				b.config.Campaign.Subject = "\xff"
+				b.config.Process.ScheduleAfter = 60 * 24 * time.Hour
+			},
+			wantProblems: []string{"api key:", "campaign:", "schedule:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v3/account":
+					if tt.accountStatus != 0 {
+						w.WriteHeader(tt.accountStatus)
+						fmt.Fprint(w, `{"code":"unauthorized"}`)
+						return
+					}
+					fmt.Fprint(w, `{"email":"ops@example.com"}`)
+				case "/v3/senders":
+					fmt.Fprint(w, `{"senders":[{"email":"sender@example.com","active":false},{"email":"backup@example.com","active":false}]}`)
+				case "/v3/contacts/lists/99":
+					http.Error(w, `{"code":"document_not_found"}`, http.StatusNotFound)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			}))
+			service.config.Campaign.Subject = "Winners"
+			service.config.Campaign.InlineHTML = "<html><body><p>Hello</p></body></html>"
+			if tt.configure != nil {
+				tt.configure(service)
+			}
+
+			err := service.ValidateConfig()
+
+			if len(tt.wantProblems) == 0 {
+				if err != nil {
+					t.Fatalf("ValidateConfig() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ValidateConfig() succeeded, want %v", tt.wantProblems)
+			}
+			for _, want := range tt.wantProblems {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("ValidateConfig() error = %q, want it to mention %q", err, want)
+				}
+			}
+			for _, absent := range tt.wantAbsent {
+				if strings.Contains(err.Error(), absent) {
+					t.Errorf("ValidateConfig() error = %q, should not mention %q", err, absent)
+				}
+			}
+			if lines := strings.Count(err.Error(), "\n") + 1; lines != len(tt.wantProblems) {
+				t.Errorf("ValidateConfig() reported %d problems, want %d:\n%v", lines, len(tt.wantProblems), err)
+			}
+		})
+	}
+}
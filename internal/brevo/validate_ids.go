@@ -0,0 +1,85 @@
+package brevo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrNotFound is matched, through errors.Is, by every NotFoundError.
+var ErrNotFound = errors.New("not found")
+
+// NotFoundError reports that Brevo has no Resource with ID, typically a stale
+// ID left in the configuration.
+type NotFoundError struct {
+	Resource string
+	ID       int
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %d not found", e.Resource, e.ID)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return ErrNotFound
+}
+
+// ValidateListID confirms that contact list id exists, returning a
+// *NotFoundError when it does not.
+func (b *BrevoService) ValidateListID(id int) error {
+	if id <= 0 {
+		return fmt.Errorf("invalid list ID %d", id)
+	}
+
+	_, err := b.GetList(id)
+	return err
+}
+
+// ValidateFolderID confirms that folder id exists, returning a
+// *NotFoundError when it does not.
+func (b *BrevoService) ValidateFolderID(id int) error {
+	if id <= 0 {
+		return fmt.Errorf("invalid folder ID %d", id)
+	}
+
+	url := fmt.Sprintf("%s/%d", FolderUrl, id)
+
+	resp, err := b.makeAPIRequest(opFetch, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("error fetching folder %d: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return &NotFoundError{Resource: "folder", ID: id}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to fetch folder %d: status %d - %s", id, resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// validateConfiguredIDs checks the list IDs taken from the configuration.
+func (b *BrevoService) validateConfiguredIDs() []error {
+	var problems []error
+
+	if id := b.config.TargetListID; id > 0 {
+		if err := b.ValidateListID(id); err != nil {
+			problems = append(problems, fmt.Errorf("target list: %w", err))
+		}
+	}
+
+	if routing := b.config.Process.ListRouting; routing != nil {
+		for _, value := range sortedKeys(routing.ListIDs) {
+			if err := b.ValidateListID(routing.ListIDs[value]); err != nil {
+				problems = append(problems, fmt.Errorf("routing list for '%s': %w", value, err))
+			}
+		}
+	}
+
+	return problems
+}
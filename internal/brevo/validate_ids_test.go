@@ -0,0 +1,86 @@
+package brevo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestValidateIDs(t *testing.T) {
+	tests := []struct {
+		name         string
+		validate     func(b *BrevoService, id int) error
+		path         string
+		id           int
+		status       int
+		wantErr      bool
+		wantNotFound bool
+		wantRequests int
+	}{
+		{name: "list found", validate: (*BrevoService).ValidateListID, path: "/v3/contacts/lists/12", id: 12, status: http.StatusOK, wantRequests: 1},
+		{name: "list not found", validate: (*BrevoService).ValidateListID, path: "/v3/contacts/lists/12", id: 12, status: http.StatusNotFound, wantErr: true, wantNotFound: true, wantRequests: 1},
+		{name: "list lookup fails", validate: (*BrevoService).ValidateListID, path: "/v3/contacts/lists/12", id: 12, status: http.StatusBadGateway, wantErr: true, wantRequests: 1},
+		{name: "invalid list ID", validate: (*BrevoService).ValidateListID, id: 0, wantErr: true},
+		{name: "folder found", validate: (*BrevoService).ValidateFolderID, path: "/v3/contacts/folders/4", id: 4, status: http.StatusOK, wantRequests: 1},
+		{name: "folder not found", validate: (*BrevoService).ValidateFolderID, path: "/v3/contacts/folders/4", id: 4, status: http.StatusNotFound, wantErr: true, wantNotFound: true, wantRequests: 1},
+		{name: "folder lookup fails", validate: (*BrevoService).ValidateFolderID, path: "/v3/contacts/folders/4", id: 4, status: http.StatusBadGateway, wantErr: true, wantRequests: 1},
+		{name: "invalid folder ID", validate: (*BrevoService).ValidateFolderID, id: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != tt.path {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprintf(w, `{"id":%d,"name":"Winners"}`, tt.id)
+			}))
+
+			err := tt.validate(service, tt.id)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validate(%d) error = %v, wantErr %v", tt.id, err, tt.wantErr)
+			}
+			var notFound *NotFoundError
+			if errors.As(err, &notFound) != tt.wantNotFound || errors.Is(err, ErrNotFound) != tt.wantNotFound {
+				t.Errorf("validate(%d) error = %v, want not found %v", tt.id, err, tt.wantNotFound)
+			}
+			if tt.wantNotFound && notFound.ID != tt.id {
+				t.Errorf("NotFoundError.ID = %d, want %d", notFound.ID, tt.id)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRunWithStaleTargetList(t *testing.T) {
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/contacts/attributes":
+			fmt.Fprint(w, `{"attributes":[]}`)
+		case "/v3/contacts/lists/404/contacts":
+			fmt.Fprint(w, `{"contacts":[],"count":0}`)
+		case "/v3/contacts/lists/404":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":"document_not_found","message":"List ID does not exist"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	service.config.TargetListID = 404
+
+	opts := service.config.Process
+	opts.Decoder = HeaderDecoder{}
+	_, err := service.ProcessCSVAndSendCampaign(writeCSV(t, "email", "a@example.com"), opts)
+
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) || notFound.Resource != "list" || notFound.ID != 404 {
+		t.Errorf("ProcessCSVAndSendCampaign() error = %v, want list 404 not found", err)
+	}
+}
@@ -0,0 +1,20 @@
+package brevo
+
+// Version identifies this build in the User-Agent header. Release builds set
+// it with:
+//
+//	go build -ldflags "-X github.com/Ka10ken1/better-brevo-service/internal/brevo.Version=1.2.0"
+var Version = "dev"
+
+// DefaultUserAgent is sent with every Brevo request unless Config.UserAgent
+// overrides it.
+func DefaultUserAgent() string {
+	return "better-brevo-service/" + Version
+}
+
+func (b *BrevoService) userAgent() string {
+	if b.config.UserAgent != "" {
+		return b.config.UserAgent
+	}
+	return DefaultUserAgent()
+}
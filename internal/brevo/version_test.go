@@ -0,0 +1,41 @@
+package brevo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestUserAgentHeader(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		userAgent string
+		want      string
+	}{
+		{name: "default", version: "dev", want: "better-brevo-service/dev"},
+		{name: "release build", version: "1.2.0", want: "better-brevo-service/1.2.0"},
+		{name: "configured override", version: "1.2.0", userAgent: "winners-sync/3", want: "winners-sync/3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := Version
+			Version = tt.version
+			t.Cleanup(func() { Version = previous })
+
+			var got string
+			service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("User-Agent")
+				w.Write([]byte(`{"id":7,"status":"draft"}`))
+			}))
+			service.config.UserAgent = tt.userAgent
+
+			if _, err := service.GetCampaignStatus(7); err != nil {
+				t.Fatalf("GetCampaignStatus() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("User-Agent = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
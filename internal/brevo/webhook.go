@@ -0,0 +1,217 @@
+package brevo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	WebhookFormatGeneric string = "generic"
+	WebhookFormatSlack   string = "slack"
+	WebhookFormatTeams   string = "teams"
+
+	// DefaultCampaignPreviewURL is formatted with the campaign ID.
+	DefaultCampaignPreviewURL string = "https://app.brevo.com/camp/preview/%d"
+
+	webhookTimeout = 10 * time.Second
+)
+
+// WebhookConfig posts a summary to URL whenever a run finishes. Format picks
+// one of the built-in payloads; TemplateFile replaces it with a text/template
+// that must render JSON and can use the same fields as WebhookData plus a
+// json function for escaping, e.g. {"text": {{json .Summary}}}.
+type WebhookConfig struct {
+	URL          string
+	Format       string
+	TemplateFile string
+	// PreviewURL is formatted with the campaign ID to link the campaign.
+	PreviewURL string
+}
+
+// WebhookData is what webhook templates render.
+type WebhookData struct {
+	RunID        string `json:"run_id"`
+	Success      bool   `json:"success"`
+	Error        string `json:"error,omitempty"`
+	CampaignName string `json:"campaign_name,omitempty"`
+	CampaignID   int    `json:"campaign_id,omitempty"`
+	PreviewLink  string `json:"preview_link,omitempty"`
+	Added        int    `json:"added"`
+	Updated      int    `json:"updated"`
+	Skipped      int    `json:"skipped"`
+	Errors       int    `json:"errors"`
+	Summary      string `json:"summary"`
+}
+
+var webhookTemplates = map[string]string{
+	WebhookFormatGeneric: `{{jsonObject .}}`,
+
+	WebhookFormatSlack: `{
+  "text": {{json .Summary}},
+  "blocks": [
+    {"type": "section", "text": {"type": "mrkdwn", "text": {{json .Summary}}}},
+    {"type": "section", "fields": [
+      {"type": "mrkdwn", "text": {{json (printf "*Added*\n%d" .Added)}}},
+      {"type": "mrkdwn", "text": {{json (printf "*Updated*\n%d" .Updated)}}},
+      {"type": "mrkdwn", "text": {{json (printf "*Skipped*\n%d" .Skipped)}}},
+      {"type": "mrkdwn", "text": {{json (printf "*Errors*\n%d" .Errors)}}}
+    ]}{{if .PreviewLink}},
+    {"type": "actions", "elements": [
+      {"type": "button", "text": {"type": "plain_text", "text": "Open campaign"}, "url": {{json .PreviewLink}}}
+    ]}{{end}}
+  ]
+}`,
+
+	WebhookFormatTeams: `{
+  "type": "message",
+  "attachments": [{
+    "contentType": "application/vnd.microsoft.card.adaptive",
+    "content": {
+      "$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+      "type": "AdaptiveCard",
+      "version": "1.4",
+      "body": [
+        {"type": "TextBlock", "text": {{json .Summary}}, "wrap": true, "weight": "Bolder"},
+        {"type": "FactSet", "facts": [
+          {"title": "Added", "value": {{json (printf "%d" .Added)}}},
+          {"title": "Updated", "value": {{json (printf "%d" .Updated)}}},
+          {"title": "Skipped", "value": {{json (printf "%d" .Skipped)}}},
+          {"title": "Errors", "value": {{json (printf "%d" .Errors)}}}
+        ]}
+      ]{{if .PreviewLink}},
+      "actions": [{"type": "Action.OpenUrl", "title": "Open campaign", "url": {{json .PreviewLink}}}]{{end}}
+    }
+  }]
+}`,
+}
+
+var webhookFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"jsonObject": func(v any) (string, error) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		return string(data), err
+	},
+}
+
+func newWebhookData(report RunReport, previewURL string) WebhookData {
+	results := report.Results
+	data := WebhookData{
+		RunID:        report.RunID,
+		Success:      report.Error == "",
+		Error:        report.Error,
+		CampaignName: results.CampaignInfo.CampaignName,
+		CampaignID:   results.CampaignInfo.CampaignID,
+		Added:        len(results.AddedToCampaign),
+		Updated:      len(results.UpdatedContacts),
+		Skipped:      len(results.Skipped),
+		Errors:       len(results.Errors),
+	}
+
+	if data.CampaignID > 0 {
+		if previewURL == "" {
+			previewURL = DefaultCampaignPreviewURL
+		}
+		data.PreviewLink = fmt.Sprintf(previewURL, data.CampaignID)
+	}
+
+	switch {
+	case !data.Success:
+		data.Summary = fmt.Sprintf("Brevo run %s failed: %s", data.RunID, data.Error)
+	case data.CampaignID > 0:
+		data.Summary = fmt.Sprintf("Brevo run %s finished: campaign '%s' (ID %d)", data.RunID, data.CampaignName, data.CampaignID)
+	default:
+		data.Summary = fmt.Sprintf("Brevo run %s finished without a campaign", data.RunID)
+	}
+
+	return data
+}
+
+// renderWebhook renders the payload for cfg and checks that it is valid JSON,
+// so a broken override template fails loudly instead of posting garbage.
+func renderWebhook(cfg WebhookConfig, data WebhookData) ([]byte, error) {
+	source, ok := webhookTemplates[strings.ToLower(cfg.Format)]
+	if cfg.Format == "" {
+		source, ok = webhookTemplates[WebhookFormatGeneric], true
+	}
+
+	if cfg.TemplateFile != "" {
+		content, err := os.ReadFile(cfg.TemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read webhook template '%s': %w", cfg.TemplateFile, err)
+		}
+		source, ok = string(content), true
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("unknown webhook format '%s' (use generic, slack or teams)", cfg.Format)
+	}
+
+	tmpl, err := template.New("webhook").Funcs(webhookFuncs).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render webhook template: %w", err)
+	}
+
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("webhook template did not render valid JSON")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// notifyWebhook posts the run summary. Failures are logged and do not
+// affect the run.
+func (b *BrevoService) notifyWebhook(report RunReport) {
+	cfg := b.config.Webhook
+	if cfg.URL == "" {
+		return
+	}
+
+	body, err := renderWebhook(cfg, newWebhookData(report, cfg.PreviewURL))
+	if err != nil {
+		log.Printf("Failed to build run webhook: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("Failed to create run webhook request: %v", err)
+		return
+	}
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("User-Agent", b.userAgent())
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		log.Printf("Failed to post run webhook: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		log.Printf("Run webhook returned %d - %s", resp.StatusCode, b.logBody(respBody))
+		return
+	}
+
+	log.Printf("Run webhook posted (%s format)", cfg.Format)
+}
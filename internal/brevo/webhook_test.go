@@ -0,0 +1,190 @@
+package brevo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func webhookReport(runErr string) RunReport {
+	return RunReport{
+		RunID: "r1",
+		Error: runErr,
+		Results: ProcessingResults{
+			CampaignInfo:    CampaignResult{Success: true, CampaignID: 20, CampaignName: "Winners \"May\""},
+			AddedToCampaign: make([]ContactResult, 3),
+			UpdatedContacts: make([]ContactResult, 2),
+			Skipped:         make([]ContactResult, 1),
+		},
+	}
+}
+
+func TestRenderWebhookFormats(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		check  func(t *testing.T, payload map[string]any)
+	}{
+		{
+			name:   "generic",
+			format: WebhookFormatGeneric,
+			check: func(t *testing.T, payload map[string]any) {
+				if payload["campaign_id"] != float64(20) || payload["campaign_name"] != `Winners "May"` || payload["added"] != float64(3) {
+					t.Errorf("generic payload = %v, want the campaign and counts", payload)
+				}
+				if payload["preview_link"] != "https://app.brevo.com/camp/preview/20" {
+					t.Errorf("preview_link = %v", payload["preview_link"])
+				}
+			},
+		},
+		{
+			name: "empty format is generic",
+			check: func(t *testing.T, payload map[string]any) {
+				if payload["run_id"] != "r1" || payload["success"] != true {
+					t.Errorf("generic payload = %v", payload)
+				}
+			},
+		},
+		{
+			name:   "slack",
+			format: "Slack",
+			check: func(t *testing.T, payload map[string]any) {
+				blocks := payload["blocks"].([]any)
+				if len(blocks) != 3 {
+					t.Fatalf("slack blocks = %d, want summary, counts and the preview button", len(blocks))
+				}
+				fields := blocks[1].(map[string]any)["fields"].([]any)
+				if text := fields[1].(map[string]any)["text"]; text != "*Updated*\n2" {
+					t.Errorf("updated field = %q", text)
+				}
+				button := blocks[2].(map[string]any)["elements"].([]any)[0].(map[string]any)
+				if button["url"] != "https://app.brevo.com/camp/preview/20" {
+					t.Errorf("button url = %v", button["url"])
+				}
+				if !strings.Contains(payload["text"].(string), `'Winners "May"' (ID 20)`) {
+					t.Errorf("slack text = %q", payload["text"])
+				}
+			},
+		},
+		{
+			name:   "teams",
+			format: WebhookFormatTeams,
+			check: func(t *testing.T, payload map[string]any) {
+				card := payload["attachments"].([]any)[0].(map[string]any)["content"].(map[string]any)
+				facts := card["body"].([]any)[1].(map[string]any)["facts"].([]any)
+				if value := facts[0].(map[string]any)["value"]; value != "3" {
+					t.Errorf("added fact = %v, want \"3\"", value)
+				}
+				action := card["actions"].([]any)[0].(map[string]any)
+				if action["url"] != "https://app.brevo.com/camp/preview/20" {
+					t.Errorf("action url = %v", action["url"])
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := renderWebhook(WebhookConfig{Format: tt.format}, newWebhookData(webhookReport(""), ""))
+			if err != nil {
+				t.Fatalf("renderWebhook() error = %v", err)
+			}
+
+			var payload map[string]any
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("rendered payload is not JSON: %v\n%s", err, body)
+			}
+			tt.check(t, payload)
+		})
+	}
+}
+
+func TestRenderWebhookWithoutCampaign(t *testing.T) {
+	report := webhookReport("failed to fetch existing contacts")
+	report.Results.CampaignInfo = CampaignResult{}
+
+	for _, format := range []string{WebhookFormatGeneric, WebhookFormatSlack, WebhookFormatTeams} {
+		t.Run(format, func(t *testing.T) {
+			body, err := renderWebhook(WebhookConfig{Format: format}, newWebhookData(report, ""))
+			if err != nil {
+				t.Fatalf("renderWebhook() error = %v", err)
+			}
+			if strings.Contains(string(body), "preview") {
+				t.Errorf("payload links a campaign that does not exist: %s", body)
+			}
+			if !strings.Contains(string(body), "Brevo run r1 failed: failed to fetch existing contacts") {
+				t.Errorf("payload does not report the failure: %s", body)
+			}
+		})
+	}
+}
+
+func TestRenderWebhookTemplateOverride(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  string
+	}{
+		{name: "custom payload", template: `{"msg": {{json .Summary}}, "id": {{.CampaignID}}}`, want: `{"msg": "Brevo run r1 finished: campaign 'Winners \"May\"' (ID 20)", "id": 20}`},
+		{name: "not JSON", template: `campaign {{.CampaignID}}`, wantErr: "valid JSON"},
+		{name: "unknown field", template: `{"x": {{.Nope}}}`, wantErr: "failed to render"},
+		{name: "broken template", template: `{"x": {{.Summary}`, wantErr: "invalid webhook template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "webhook.json.tmpl")
+			if err := os.WriteFile(path, []byte(tt.template), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			body, err := renderWebhook(WebhookConfig{Format: WebhookFormatSlack, TemplateFile: path}, newWebhookData(webhookReport(""), ""))
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("renderWebhook() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("renderWebhook() error = %v", err)
+			}
+			if string(body) != tt.want {
+				t.Errorf("renderWebhook() = %s, want %s", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderWebhookUnknownFormat(t *testing.T) {
+	if _, err := renderWebhook(WebhookConfig{Format: "discord"}, WebhookData{}); err == nil {
+		t.Errorf("renderWebhook() accepted an unknown format")
+	}
+}
+
+func TestNotifyWebhookPostsPayload(t *testing.T) {
+	var received []byte
+	var contentType string
+	service, _ := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hooks/runs" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		contentType = r.Header.Get("content-type")
+		received, _ = io.ReadAll(r.Body)
+	}))
+	service.config.Webhook = WebhookConfig{URL: "https://hooks.example.com/hooks/runs", Format: WebhookFormatSlack, PreviewURL: "https://brevo.example/c/%d"}
+
+	service.notifyWebhook(webhookReport(""))
+
+	if contentType != "application/json" {
+		t.Errorf("content-type = %q", contentType)
+	}
+	if !strings.Contains(string(received), `"url": "https://brevo.example/c/20"`) {
+		t.Errorf("posted payload = %s, want the configured preview link", received)
+	}
+}
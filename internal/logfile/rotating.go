@@ -0,0 +1,153 @@
+package logfile
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+)
+
+const (
+	DefaultMaxSizeMB  int = 10
+	DefaultMaxBackups int = 5
+)
+
+// RotatingFile is an io.Writer that appends to a log file and, once the file
+// would grow past its size limit, renames it to path.1 (shifting older
+// backups up to path.N) and starts a new one. Backups beyond the limit are
+// deleted.
+type RotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// Open opens path for appending, creating it if needed.
+func Open(path string, maxSizeMB int, maxBackups int) (*RotatingFile, error) {
+	if maxSizeMB <= 0 {
+		maxSizeMB = DefaultMaxSizeMB
+	}
+	if maxBackups < 0 {
+		maxBackups = 0
+	}
+
+	r := &RotatingFile{
+		path:       path,
+		maxBytes:   int64(maxSizeMB) << 20,
+		maxBackups: maxBackups,
+	}
+
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// FromEnv opens the file named by LOG_FILE, sized by LOG_MAX_SIZE_MB and
+// LOG_MAX_BACKUPS. It returns nil when LOG_FILE is unset.
+func FromEnv() (*RotatingFile, error) {
+	path := os.Getenv("LOG_FILE")
+	if path == "" {
+		return nil, nil
+	}
+
+	maxSizeMB, err := envInt("LOG_MAX_SIZE_MB", DefaultMaxSizeMB)
+	if err != nil {
+		return nil, err
+	}
+
+	maxBackups, err := envInt("LOG_MAX_BACKUPS", DefaultMaxBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	return Open(path, maxSizeMB, maxBackups)
+}
+
+func envInt(key string, fallback int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", key, value, err)
+	}
+
+	return n, nil
+}
+
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.size > 0 && r.size+int64(len(p)) > r.maxBytes {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.file.Close()
+}
+
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file '%s': %w", r.path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file '%s': %w", r.path, err)
+	}
+
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file '%s': %w", r.path, err)
+	}
+
+	if r.maxBackups == 0 {
+		if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove log file '%s': %w", r.path, err)
+		}
+		return r.open()
+	}
+
+	os.Remove(r.backupPath(r.maxBackups))
+
+	for i := r.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(r.backupPath(i), r.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate log backup %d: %w", i, err)
+		}
+	}
+
+	if err := os.Rename(r.path, r.backupPath(1)); err != nil {
+		return fmt.Errorf("failed to rotate log file '%s': %w", r.path, err)
+	}
+
+	return r.open()
+}
+
+func (r *RotatingFile) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", r.path, n)
+}